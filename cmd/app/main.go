@@ -5,15 +5,27 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	gateway "avito-test/internal/gateway/http"
+	"avito-test/internal/logger"
+	"avito-test/internal/metrics"
+	"avito-test/internal/notify"
 	pr "avito-test/internal/repository/pull_request/postgres"
+	sr "avito-test/internal/repository/stats/postgres"
 	tr "avito-test/internal/repository/team/postgres"
+	tokr "avito-test/internal/repository/token/postgres"
+	txr "avito-test/internal/repository/transaction/postgres"
 	ur "avito-test/internal/repository/user/postgres"
+	"avito-test/internal/tracing"
 	"avito-test/internal/usecase"
+	"github.com/gin-gonic/gin"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 )
@@ -48,7 +60,7 @@ func setupDB(ctx context.Context) (*sql.DB, error) {
 		return nil, fmt.Errorf("database.PingContext: %w", err)
 	}
 
-	log.Printf("data base connected: %s", dsn)
+	slog.InfoContext(ctx, "database connected", slog.String("dsn", dsn))
 	return dbConn, nil
 }
 
@@ -59,42 +71,156 @@ func getEnv(key, def string) string {
 	return def
 }
 
+// parseKeyValues разбирает строку вида "a=1,b=2".
+func parseKeyValues(raw string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid key=value pair %q", pair)
+		}
+		result[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return result, nil
+}
+
+func setupReviewerSelector(counter usecase.OpenReviewCounter) (usecase.ReviewerSelector, error) {
+	teams, err := parseKeyValues(getEnv("REVIEWER_STRATEGY_TEAMS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("REVIEWER_STRATEGY_TEAMS: %w", err)
+	}
+	rawWeights, err := parseKeyValues(getEnv("REVIEWER_WEIGHTS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("REVIEWER_WEIGHTS: %w", err)
+	}
+	weights := make(map[string]int, len(rawWeights))
+	for userID, raw := range rawWeights {
+		w, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("REVIEWER_WEIGHTS: weight of %s: %w", userID, err)
+		}
+		weights[userID] = w
+	}
+
+	return usecase.NewReviewerSelector(usecase.ReviewerSelectorConfig{
+		Default: getEnv("REVIEWER_STRATEGY", usecase.StrategyRandom),
+		Teams:   teams,
+		Weights: weights,
+	}, nil, counter)
+}
+
+// fatal логирует ошибку запуска и завершает процесс.
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.Any("error", err))
+	os.Exit(1)
+}
+
 func main() {
-	if err := godotenv.Load(".env"); err != nil {
-		log.Printf("Error loading .env file: %v", err)
+	envErr := godotenv.Load(".env")
+
+	appLogger, err := logger.New(os.Stdout, getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "LOG_LEVEL: %v\n", err)
+		os.Exit(1)
 	}
+	slog.SetDefault(appLogger)
 
-	ctx := context.Background()
+	if envErr != nil {
+		slog.Warn("can't load .env file", slog.Any("error", envErr))
+	}
+	// в debug режиме gin пишет в stdout текстовые строки, которые ломают JSON лог
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		ServiceName: getEnv("OTEL_SERVICE_NAME", "pr-reviewer"),
+		Exporter:    getEnv("OTEL_TRACES_EXPORTER", tracing.ExporterNone),
+		File:        getEnv("OTEL_TRACES_FILE", ""),
+	})
+	if err != nil {
+		fatal("can't setup tracing", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			slog.Error("can't flush traces", slog.Any("error", err))
+		}
+	}()
 
 	conn, err := setupDB(ctx)
 	if err != nil {
-		log.Fatal(err)
+		fatal("can't connect to database", err)
 	}
 	defer conn.Close()
 
-	database := db.New(conn)
+	database := db.New(tracing.NewDBTX(conn))
+
+	appMetrics := metrics.New()
+	if err := appMetrics.RegisterDB(conn, getEnv("DB_NAME", "avito")); err != nil {
+		fatal("can't register database metrics", err)
+	}
 
 	userRepo := ur.NewUserRepository(database)
 	teamRepo := tr.NewTeamRepository(database)
 	prRepo := pr.NewPullRequestRepository(database)
 	reqOwnerRepo := ur.NewRequestOwnerRepository(database)
+	statsRepo := sr.NewStatsRepository(database)
+	tokenRepo := tokr.NewTokenRepository(database)
+	transactor := txr.NewTransactor(conn, database, txr.WithTxWrapper(tracing.WrapDBTX))
+
+	selector, err := setupReviewerSelector(reqOwnerRepo)
+	if err != nil {
+		fatal("can't setup reviewer selector", err)
+	}
 
-	prUC := usecase.NewPullRequest(prRepo, teamRepo, userRepo, reqOwnerRepo)
-	teamUC := usecase.NewTeam(teamRepo, userRepo)
-	userUC := usecase.NewUser(userRepo, reqOwnerRepo, prRepo)
+	prUC := usecase.NewPullRequest(prRepo, teamRepo, userRepo, reqOwnerRepo, transactor, selector, appMetrics)
+	teamUC := usecase.NewTeam(teamRepo, userRepo, reqOwnerRepo, transactor, appMetrics)
+	userUC := usecase.NewUser(userRepo, reqOwnerRepo, prRepo, teamRepo)
+	statsUC := usecase.NewStats(statsRepo, teamRepo)
+	authUC := usecase.NewAuth(tokenRepo, userRepo, os.Getenv("AUTH_BOOTSTRAP_TOKEN"))
 
 	usecases := gateway.UseCases{
 		User:        userUC,
 		Team:        teamUC,
 		PullRequest: prUC,
+		Stats:       statsUC,
+		Auth:        authUC,
+	}
+
+	drainDelay, err := time.ParseDuration(getEnv("SHUTDOWN_DRAIN_DELAY", "0s"))
+	if err != nil {
+		fatal("invalid SHUTDOWN_DRAIN_DELAY", err)
+	}
+
+	// 0 отключает проверку сроков ревью, например если она запущена в другом экземпляре сервиса
+	slaInterval, err := time.ParseDuration(getEnv("REVIEW_SLA_CHECK_INTERVAL", "5m"))
+	if err != nil {
+		fatal("invalid REVIEW_SLA_CHECK_INTERVAL", err)
+	}
+	if slaInterval > 0 {
+		reviewSLA := usecase.NewReviewSLA(reqOwnerRepo, userRepo, teamRepo, &prUC, notify.NewLogNotifier(appLogger), nil)
+		go reviewSLA.Run(ctx, slaInterval)
 	}
 
 	server := gateway.NewServer(usecases,
 		gateway.WithHost("localhost"),
 		gateway.WithPort(8080),
+		gateway.WithHealthDB(conn),
+		gateway.WithMetrics(appMetrics),
+		gateway.WithDrainDelay(drainDelay),
+		gateway.WithLogger(appLogger),
 	)
 
 	if err := server.Run(ctx); err != nil {
-		log.Fatal(err)
+		fatal("server stopped with error", err)
 	}
 }
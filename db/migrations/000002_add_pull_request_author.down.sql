@@ -0,0 +1,4 @@
+DROP INDEX idx_pr_author_id;
+
+ALTER TABLE pull_requests
+    DROP COLUMN AuthorID;
@@ -0,0 +1,10 @@
+ALTER TABLE pull_requests
+    ADD COLUMN AuthorID TEXT REFERENCES users (UserID);
+
+UPDATE pull_requests pr
+SET AuthorID = upr.UserID
+FROM users_pull_requests upr
+WHERE upr.PullRequestID = pr.PullRequestID
+  AND upr.Role = 'author';
+
+CREATE INDEX idx_pr_author_id ON pull_requests (AuthorID);
@@ -0,0 +1 @@
+DROP INDEX idx_pr_created_at;
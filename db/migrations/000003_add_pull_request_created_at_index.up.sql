@@ -0,0 +1 @@
+CREATE INDEX idx_pr_created_at ON pull_requests (CreatedAt);
@@ -0,0 +1,10 @@
+CREATE TABLE api_tokens
+(
+    TokenID   TEXT UNIQUE NOT NULL PRIMARY KEY,
+    Name      TEXT        NOT NULL,
+    TokenHash TEXT UNIQUE NOT NULL,
+    Scope     VARCHAR(16) NOT NULL CHECK (Scope IN ('admin', 'user')),
+    UserID    TEXT REFERENCES users (UserID),
+    CreatedAt TIMESTAMP   NOT NULL DEFAULT now(),
+    RevokedAt TIMESTAMP
+);
@@ -0,0 +1,4 @@
+DROP INDEX idx_ut_team_leads;
+
+ALTER TABLE users_team
+    DROP COLUMN Role;
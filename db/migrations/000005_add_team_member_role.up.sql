@@ -0,0 +1,4 @@
+ALTER TABLE users_team
+    ADD COLUMN Role VARCHAR(16) NOT NULL DEFAULT 'member' CHECK (Role IN ('lead', 'member'));
+
+CREATE INDEX idx_ut_team_leads ON users_team (UserID) WHERE Role = 'lead';
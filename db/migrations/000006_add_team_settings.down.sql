@@ -0,0 +1,4 @@
+ALTER TABLE pull_requests
+    DROP COLUMN MinReviewers;
+
+DROP TABLE team_settings;
@@ -0,0 +1,10 @@
+CREATE TABLE team_settings
+(
+    TeamName     TEXT PRIMARY KEY REFERENCES teams (TeamName) ON DELETE CASCADE,
+    MinReviewers INT       NOT NULL CHECK (MinReviewers >= 0),
+    MaxReviewers INT       NOT NULL CHECK (MaxReviewers >= MinReviewers),
+    UpdatedAt    TIMESTAMP NOT NULL DEFAULT now()
+);
+
+ALTER TABLE pull_requests
+    ADD COLUMN MinReviewers INT NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+CREATE TABLE review_decisions
+(
+    DecisionID    BIGSERIAL PRIMARY KEY,
+    PullRequestID TEXT        NOT NULL REFERENCES pull_requests (PullRequestID),
+    UserID        TEXT        NOT NULL REFERENCES users (UserID),
+    Decision      VARCHAR(32) NOT NULL CHECK (Decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    Comment       TEXT        NOT NULL DEFAULT '',
+    CreatedAt     TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_rd_pull_request_user ON review_decisions (PullRequestID, UserID, CreatedAt DESC);
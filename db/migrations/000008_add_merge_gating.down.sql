@@ -0,0 +1,4 @@
+DROP TABLE merge_overrides;
+
+ALTER TABLE team_settings
+    DROP COLUMN RequiredApprovals;
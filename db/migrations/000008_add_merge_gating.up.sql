@@ -0,0 +1,14 @@
+ALTER TABLE team_settings
+    ADD COLUMN RequiredApprovals INT NOT NULL DEFAULT 0 CHECK (RequiredApprovals >= 0);
+
+CREATE TABLE merge_overrides
+(
+    OverrideID    BIGSERIAL PRIMARY KEY,
+    PullRequestID TEXT      NOT NULL REFERENCES pull_requests (PullRequestID),
+    ActorUserID   TEXT      NOT NULL DEFAULT '',
+    ActorTokenID  TEXT      NOT NULL DEFAULT '',
+    Conditions    TEXT      NOT NULL,
+    CreatedAt     TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_mo_pull_request ON merge_overrides (PullRequestID);
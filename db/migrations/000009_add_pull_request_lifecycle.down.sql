@@ -0,0 +1,2 @@
+ALTER TABLE pull_requests
+    DROP CONSTRAINT chk_pr_status;
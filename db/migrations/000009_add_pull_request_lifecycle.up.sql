@@ -0,0 +1,7 @@
+-- PR до этой миграции могли сохраняться с пустым статусом вместо OPEN
+UPDATE pull_requests
+SET Status = 'OPEN'
+WHERE Status = '';
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT chk_pr_status CHECK (Status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED', 'REOPENED'));
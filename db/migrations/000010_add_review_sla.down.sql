@@ -0,0 +1,7 @@
+ALTER TABLE team_settings
+    DROP COLUMN ReassignAfterHours,
+    DROP COLUMN ReviewSLAHours;
+
+ALTER TABLE users_pull_requests
+    DROP COLUMN NotifiedAt,
+    DROP COLUMN AssignedAt;
@@ -0,0 +1,7 @@
+ALTER TABLE users_pull_requests
+    ADD COLUMN AssignedAt TIMESTAMP NOT NULL DEFAULT now(),
+    ADD COLUMN NotifiedAt TIMESTAMP;
+
+ALTER TABLE team_settings
+    ADD COLUMN ReviewSLAHours     INT NOT NULL DEFAULT 24 CHECK (ReviewSLAHours >= 0),
+    ADD COLUMN ReassignAfterHours INT NOT NULL DEFAULT 0 CHECK (ReassignAfterHours >= 0);
@@ -0,0 +1,3 @@
+DROP TABLE assignment_events;
+
+DROP FUNCTION assignment_events_append_only();
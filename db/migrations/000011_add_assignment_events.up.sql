@@ -0,0 +1,44 @@
+CREATE TABLE assignment_events
+(
+    EventID        BIGSERIAL PRIMARY KEY,
+    PullRequestID  TEXT        NOT NULL REFERENCES pull_requests (PullRequestID),
+    EventType      VARCHAR(32) NOT NULL CHECK (EventType IN ('ASSIGNED', 'UNASSIGNED', 'REASSIGNED', 'MERGED', 'STATUS_CHANGED')),
+    UserID         TEXT        NOT NULL DEFAULT '',
+    PreviousUserID TEXT        NOT NULL DEFAULT '',
+    Status         VARCHAR(32) NOT NULL DEFAULT '',
+    ActorUserID    TEXT        NOT NULL DEFAULT '',
+    ActorTokenID   TEXT        NOT NULL DEFAULT '',
+    Reason         TEXT        NOT NULL DEFAULT '',
+    Strategy       TEXT        NOT NULL DEFAULT '',
+    CreatedAt      TIMESTAMP   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_ae_pull_request ON assignment_events (PullRequestID, EventID);
+CREATE INDEX idx_ae_user ON assignment_events (UserID, EventID);
+CREATE INDEX idx_ae_previous_user ON assignment_events (PreviousUserID, EventID);
+
+-- история только дополняется: изменение и удаление событий запрещены
+CREATE FUNCTION assignment_events_append_only() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'assignment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_ae_append_only
+    BEFORE UPDATE OR DELETE
+    ON assignment_events
+    FOR EACH ROW
+EXECUTE FUNCTION assignment_events_append_only();
+
+-- текущие назначения и слияния до появления истории
+INSERT INTO assignment_events (PullRequestID, EventType, UserID, Reason, CreatedAt)
+SELECT PullRequestID, 'ASSIGNED', UserID, 'backfill', AssignedAt
+FROM users_pull_requests
+WHERE Role = 'reviewer';
+
+INSERT INTO assignment_events (PullRequestID, EventType, Status, Reason, CreatedAt)
+SELECT PullRequestID, 'MERGED', Status, 'backfill', MergedAt
+FROM pull_requests
+WHERE Status = 'MERGED'
+  AND MergedAt IS NOT NULL;
@@ -0,0 +1,16 @@
+-- объяснение выбора ревьюверов: пул кандидатов, причины исключения, стратегия и ее оценки
+CREATE TABLE assignment_explanations
+(
+    ExplanationID  BIGSERIAL PRIMARY KEY,
+    PullRequestID  TEXT      NOT NULL REFERENCES pull_requests (PullRequestID),
+    Reason         TEXT      NOT NULL DEFAULT '',
+    Strategy       TEXT      NOT NULL DEFAULT '',
+    TeamName       TEXT      NOT NULL DEFAULT '',
+    ReplacedUserID TEXT      NOT NULL DEFAULT '',
+    Slots          INT       NOT NULL DEFAULT 0,
+    Selected       JSONB     NOT NULL DEFAULT '[]',
+    Candidates     JSONB     NOT NULL DEFAULT '[]',
+    CreatedAt      TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_aex_pull_request ON assignment_explanations (PullRequestID, ExplanationID);
@@ -0,0 +1,6 @@
+ALTER TABLE review_decisions
+    ALTER COLUMN CreatedAt TYPE TIMESTAMP USING CreatedAt;
+
+ALTER TABLE users_pull_requests
+    ALTER COLUMN NotifiedAt TYPE TIMESTAMP USING NotifiedAt AT TIME ZONE 'UTC',
+    ALTER COLUMN AssignedAt TYPE TIMESTAMP USING AssignedAt;
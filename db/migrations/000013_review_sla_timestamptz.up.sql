@@ -0,0 +1,8 @@
+-- сроки ревью считаются в UTC: время назначения, напоминания и решения ревьювера хранится с часовым поясом.
+-- AssignedAt и CreatedAt решений заполнялись now() в часовом поясе сессии БД, NotifiedAt - временем сервиса в UTC
+ALTER TABLE users_pull_requests
+    ALTER COLUMN AssignedAt TYPE TIMESTAMPTZ USING AssignedAt,
+    ALTER COLUMN NotifiedAt TYPE TIMESTAMPTZ USING NotifiedAt AT TIME ZONE 'UTC';
+
+ALTER TABLE review_decisions
+    ALTER COLUMN CreatedAt TYPE TIMESTAMPTZ USING CreatedAt;
@@ -0,0 +1,7 @@
+-- пользователи, исключенные автором из ревьюверов PR: не выбираются ни при назначении, ни при заменах
+CREATE TABLE pull_request_excluded_reviewers
+(
+    PullRequestID TEXT NOT NULL REFERENCES pull_requests (PullRequestID),
+    UserID        TEXT NOT NULL REFERENCES users (UserID),
+    PRIMARY KEY (PullRequestID, UserID)
+);
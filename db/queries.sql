@@ -23,7 +23,35 @@ FROM teams t
 WHERE ut.userid = $1;
 
 -- name: SaveUserTeam :exec
-INSERT INTO users_team (teamname, userid) VALUES ($1, $2);
+INSERT INTO users_team (teamname, userid, role) VALUES ($1, $2, $3);
+
+-- name: GetTeamMembers :many
+SELECT u.userid,
+       u.username,
+       u.isactive,
+       ut.role
+FROM users u
+         JOIN users_team ut ON ut.userid = u.userid
+WHERE ut.teamname = $1
+ORDER BY u.userid;
+
+-- name: IsTeamLead :one
+SELECT EXISTS (SELECT 1
+               FROM users_team lead
+                        JOIN users_team member ON member.teamname = lead.teamname
+               WHERE lead.userid = sqlc.arg(lead_id)
+                 AND lead.role = 'lead'
+                 AND member.userid = sqlc.arg(member_id)) AS is_lead;
+
+-- name: DeleteUserTeam :execrows
+DELETE FROM users_team WHERE teamname = $1 AND userid = $2;
+
+-- name: DeleteTeamMembers :many
+DELETE FROM users_team WHERE teamname = $1
+RETURNING userid;
+
+-- name: DeleteTeam :execrows
+DELETE FROM teams WHERE teamname = $1;
 
 -- name: GetUsers :many
 SELECT * FROM users;
@@ -37,8 +65,23 @@ SELECT * FROM teams WHERE teamname = $1;
 -- name: GetTeams :many
 SELECT teamname FROM teams;
 
+-- name: GetTeamSettings :one
+SELECT teamname, minreviewers, maxreviewers, updatedat, requiredapprovals, reviewslahours, reassignafterhours
+FROM team_settings
+WHERE teamname = $1;
+
+-- name: UpsertTeamSettings :exec
+INSERT INTO team_settings (teamname, minreviewers, maxreviewers, requiredapprovals, reviewslahours, reassignafterhours)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (teamname) DO UPDATE SET minreviewers       = EXCLUDED.minreviewers,
+                                     maxreviewers       = EXCLUDED.maxreviewers,
+                                     requiredapprovals  = EXCLUDED.requiredapprovals,
+                                     reviewslahours     = EXCLUDED.reviewslahours,
+                                     reassignafterhours = EXCLUDED.reassignafterhours,
+                                     updatedat          = now();
+
 -- name: CreatePullRequest :exec
-INSERT INTO pull_requests (pullrequestid, name, status) VALUES ($1, $2, $3);
+INSERT INTO pull_requests (pullrequestid, name, authorid, status, minreviewers) VALUES ($1, $2, $3, $4, $5);
 
 -- name: UpdatePullRequestStatus :exec
 UPDATE pull_requests SET status = $1, mergedat = $2 WHERE pullrequestid = $3;
@@ -47,13 +90,92 @@ UPDATE pull_requests SET status = $1, mergedat = $2 WHERE pullrequestid = $3;
 INSERT INTO users_pull_requests (pullrequestid, userid, role) VALUES ($1, $2, $3);
 
 -- name: GetPullRequestByID :one
-SELECT * FROM pull_requests WHERE pullrequestid = $1;
+SELECT pr.pullrequestid,
+       pr.name,
+       pr.authorid,
+       pr.status,
+       pr.createdat,
+       pr.mergedat,
+       pr.minreviewers,
+       COALESCE(json_agg(upr.userid ORDER BY upr.userid) FILTER (WHERE upr.role = 'reviewer'), '[]')::json AS reviewers,
+       COALESCE((SELECT json_agg(json_build_object('user_id', rd.userid, 'decision', rd.decision, 'comment', rd.comment,
+                                                   'created_at', rd.createdat) ORDER BY rd.userid)
+                 FROM (SELECT DISTINCT ON (d.userid) d.userid, d.decision, d.comment, d.createdat
+                       FROM review_decisions d
+                                JOIN users_pull_requests r
+                                     ON r.pullrequestid = d.pullrequestid AND r.userid = d.userid AND r.role = 'reviewer'
+                                         AND d.createdat >= r.assignedat
+                       WHERE d.pullrequestid = pr.pullrequestid
+                       ORDER BY d.userid, d.createdat DESC, d.decisionid DESC) rd), '[]')::json AS reviews
+FROM pull_requests pr
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid
+WHERE pr.pullrequestid = $1
+GROUP BY pr.pullrequestid;
 
 -- name: GetPullRequests :many
-SELECT * FROM pull_requests;
+SELECT pr.pullrequestid,
+       pr.name,
+       pr.authorid,
+       pr.status,
+       pr.createdat,
+       pr.mergedat,
+       pr.minreviewers,
+       COALESCE(json_agg(upr.userid ORDER BY upr.userid) FILTER (WHERE upr.role = 'reviewer'), '[]')::json AS reviewers,
+       COALESCE((SELECT json_agg(json_build_object('user_id', rd.userid, 'decision', rd.decision, 'comment', rd.comment,
+                                                   'created_at', rd.createdat) ORDER BY rd.userid)
+                 FROM (SELECT DISTINCT ON (d.userid) d.userid, d.decision, d.comment, d.createdat
+                       FROM review_decisions d
+                                JOIN users_pull_requests r
+                                     ON r.pullrequestid = d.pullrequestid AND r.userid = d.userid AND r.role = 'reviewer'
+                                         AND d.createdat >= r.assignedat
+                       WHERE d.pullrequestid = pr.pullrequestid
+                       ORDER BY d.userid, d.createdat DESC, d.decisionid DESC) rd), '[]')::json AS reviews
+FROM pull_requests pr
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid
+GROUP BY pr.pullrequestid
+ORDER BY pr.createdat;
+
+-- name: CreateReviewDecision :exec
+INSERT INTO review_decisions (pullrequestid, userid, decision, comment) VALUES ($1, $2, $3, $4);
+
+-- name: CreateMergeOverride :exec
+INSERT INTO merge_overrides (pullrequestid, actoruserid, actortokenid, conditions) VALUES ($1, $2, $3, $4);
+
+-- name: CreateAssignmentEvent :exec
+INSERT INTO assignment_events (pullrequestid, eventtype, userid, previoususerid, status, actoruserid, actortokenid, reason, strategy)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetPullRequestAssignmentEvents :many
+SELECT eventid, pullrequestid, eventtype, userid, previoususerid, status, actoruserid, actortokenid, reason, strategy, createdat
+FROM assignment_events
+WHERE pullrequestid = $1
+ORDER BY eventid;
+
+-- name: CreateAssignmentExplanation :exec
+INSERT INTO assignment_explanations (pullrequestid, reason, strategy, teamname, replaceduserid, slots, selected, candidates)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetPullRequestAssignmentExplanations :many
+SELECT explanationid, pullrequestid, reason, strategy, teamname, replaceduserid, slots, selected, candidates, createdat
+FROM assignment_explanations
+WHERE pullrequestid = $1
+ORDER BY explanationid;
+
+-- name: CreateExcludedReviewer :exec
+INSERT INTO pull_request_excluded_reviewers (pullrequestid, userid) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: GetPullRequestExcludedReviewers :many
+SELECT userid FROM pull_request_excluded_reviewers WHERE pullrequestid = $1 ORDER BY userid;
+
+-- name: GetUserAssignmentEvents :many
+SELECT eventid, pullrequestid, eventtype, userid, previoususerid, status, actoruserid, actortokenid, reason, strategy, createdat
+FROM assignment_events
+WHERE userid = $1
+   OR previoususerid = $1
+ORDER BY eventid;
 
 -- name: GetUsersAssignedPullRequest :many
-SELECT pull_requests.pullrequestid, role FROM pull_requests, users_pull_requests WHERE pull_requests.pullrequestid = (SELECT pullrequestid FROM users_pull_requests WHERE users_pull_requests.userid = $1);
+SELECT pullrequestid, role FROM users_pull_requests WHERE userid = $1;
 
 -- name: GetListOfUsersByPullRequestID :many
 SELECT userid, role FROM users_pull_requests WHERE pullrequestid = $1;
@@ -61,3 +183,154 @@ SELECT userid, role FROM users_pull_requests WHERE pullrequestid = $1;
 -- name: DeletePullRequestAssignOfUser :exec
 DELETE FROM users_pull_requests WHERE pullrequestid = $1 AND userid = $2;
 
+-- name: GetOpenReviewCounts :many
+SELECT upr.userid, COUNT(*) AS open_reviews
+FROM users_pull_requests upr
+         JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid
+WHERE upr.role = 'reviewer'
+  AND pr.status IN ('OPEN', 'REOPENED')
+GROUP BY upr.userid;
+
+-- name: GetPendingReviewAssignments :many
+SELECT upr.pullrequestid, pr.authorid, upr.userid, upr.assignedat, upr.notifiedat
+FROM users_pull_requests upr
+         JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid
+WHERE upr.role = 'reviewer'
+  AND pr.status IN ('OPEN', 'REOPENED')
+  AND NOT EXISTS (SELECT 1
+                  FROM review_decisions rd
+                  WHERE rd.pullrequestid = upr.pullrequestid
+                    AND rd.userid = upr.userid
+                    AND rd.decision IN ('APPROVED', 'CHANGES_REQUESTED')
+                    AND rd.createdat >= upr.assignedat)
+ORDER BY upr.assignedat, upr.pullrequestid, upr.userid;
+
+-- name: MarkAssignmentNotified :exec
+UPDATE users_pull_requests SET notifiedat = $1 WHERE pullrequestid = $2 AND userid = $3;
+
+-- name: DeactivateTeamMembers :many
+UPDATE users u
+SET isactive = false
+FROM users_team ut
+WHERE ut.userid = u.userid
+  AND ut.teamname = sqlc.arg(teamname)
+  AND (sqlc.arg(all_members)::bool OR u.userid IN (SELECT jsonb_array_elements_text(sqlc.arg(user_ids)::jsonb)))
+RETURNING u.userid;
+
+-- name: ReplaceTeamReviewers :many
+WITH slots AS (
+    SELECT upr.pullrequestid,
+           upr.userid                                                               AS old_userid,
+           row_number() OVER (PARTITION BY upr.pullrequestid ORDER BY upr.userid) AS slot
+    FROM users_pull_requests upr
+             JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid
+    WHERE upr.role = 'reviewer'
+      AND pr.status IN ('OPEN', 'REOPENED')
+      AND upr.userid IN (SELECT jsonb_array_elements_text(sqlc.arg(user_ids)::jsonb))
+      AND (NOT sqlc.arg(team_pull_requests_only)::bool OR
+           pr.authorid IN (SELECT userid FROM users_team WHERE teamname = sqlc.arg(teamname)))
+),
+     candidates AS (
+         SELECT p.pullrequestid,
+                ut.userid                                                              AS new_userid,
+                row_number() OVER (PARTITION BY p.pullrequestid ORDER BY random()) AS slot
+         FROM (SELECT DISTINCT pullrequestid FROM slots) p
+                  JOIN pull_requests pr ON pr.pullrequestid = p.pullrequestid
+                  JOIN users_team ut ON ut.teamname = sqlc.arg(teamname)
+                  JOIN users u ON u.userid = ut.userid AND u.isactive
+         WHERE ut.userid <> COALESCE(pr.authorid, '')
+           AND ut.userid NOT IN (SELECT jsonb_array_elements_text(sqlc.arg(user_ids)::jsonb))
+           AND NOT EXISTS (SELECT 1
+                           FROM users_pull_requests a
+                           WHERE a.pullrequestid = p.pullrequestid
+                             AND a.userid = ut.userid)
+           AND NOT EXISTS (SELECT 1
+                           FROM pull_request_excluded_reviewers x
+                           WHERE x.pullrequestid = p.pullrequestid
+                             AND x.userid = ut.userid)
+     ),
+     plan AS MATERIALIZED (
+         SELECT s.pullrequestid, s.old_userid, c.new_userid
+         FROM slots s
+                  LEFT JOIN candidates c ON c.pullrequestid = s.pullrequestid AND c.slot = s.slot
+     ),
+     removed AS (
+         DELETE FROM users_pull_requests upr
+             USING plan
+             WHERE upr.pullrequestid = plan.pullrequestid
+                 AND upr.userid = plan.old_userid
+                 AND upr.role = 'reviewer'
+             RETURNING upr.pullrequestid
+     ),
+     added AS (
+         INSERT INTO users_pull_requests (pullrequestid, userid, role)
+             SELECT pullrequestid, new_userid, 'reviewer'
+             FROM plan
+             WHERE new_userid IS NOT NULL
+             RETURNING pullrequestid
+     )
+SELECT pullrequestid, old_userid, new_userid
+FROM plan
+ORDER BY pullrequestid, old_userid;
+
+-- name: GetReviewerAssignmentStats :many
+SELECT upr.userid,
+       COUNT(*) FILTER (WHERE pr.status IN ('OPEN', 'REOPENED')) AS open_reviews,
+       COUNT(*) FILTER (WHERE pr.status = 'MERGED')                AS merged_reviews
+FROM pull_requests pr
+         JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid AND upr.role = 'reviewer'
+WHERE (sqlc.narg(teamname)::text IS NULL OR pr.authorid IN (SELECT userid FROM users_team WHERE teamname = sqlc.narg(teamname)))
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR pr.createdat >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR pr.createdat < sqlc.narg(created_to))
+GROUP BY upr.userid
+ORDER BY upr.userid;
+
+-- name: GetPullRequestReviewerStats :many
+SELECT pr.pullrequestid, pr.status, COUNT(upr.userid) AS reviewers
+FROM pull_requests pr
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid AND upr.role = 'reviewer'
+WHERE (sqlc.narg(teamname)::text IS NULL OR pr.authorid IN (SELECT userid FROM users_team WHERE teamname = sqlc.narg(teamname)))
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR pr.createdat >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR pr.createdat < sqlc.narg(created_to))
+GROUP BY pr.pullrequestid, pr.status
+ORDER BY pr.pullrequestid;
+
+-- name: GetTeamStats :many
+SELECT ut.teamname,
+       COUNT(DISTINCT pr.pullrequestid) FILTER (WHERE pr.status IN ('OPEN', 'REOPENED')) AS open_pull_requests,
+       COUNT(DISTINCT pr.pullrequestid) FILTER (WHERE pr.status = 'MERGED')                AS merged_pull_requests,
+       COUNT(upr.userid)                                                                  AS assignments
+FROM pull_requests pr
+         JOIN users_team ut ON ut.userid = pr.authorid
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid AND upr.role = 'reviewer'
+WHERE (sqlc.narg(teamname)::text IS NULL OR ut.teamname = sqlc.narg(teamname))
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR pr.createdat >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR pr.createdat < sqlc.narg(created_to))
+GROUP BY ut.teamname
+ORDER BY ut.teamname;
+
+-- name: GetMergeTimeStats :one
+SELECT COUNT(*) AS merged_pull_requests,
+       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.mergedat - pr.createdat)), 0)::float8 AS median_merge_seconds
+FROM pull_requests pr
+WHERE pr.status = 'MERGED'
+  AND pr.mergedat IS NOT NULL
+  AND (sqlc.narg(teamname)::text IS NULL OR pr.authorid IN (SELECT userid FROM users_team WHERE teamname = sqlc.narg(teamname)))
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR pr.createdat >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR pr.createdat < sqlc.narg(created_to));
+
+-- name: CreateAPIToken :exec
+INSERT INTO api_tokens (tokenid, name, tokenhash, scope, userid) VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetAPITokenByHash :one
+SELECT tokenid, name, tokenhash, scope, userid, createdat, revokedat
+FROM api_tokens
+WHERE tokenhash = $1 AND revokedat IS NULL;
+
+-- name: GetAPITokens :many
+SELECT tokenid, name, tokenhash, scope, userid, createdat, revokedat
+FROM api_tokens
+ORDER BY createdat, tokenid;
+
+-- name: RevokeAPIToken :execrows
+UPDATE api_tokens SET revokedat = now() WHERE tokenid = $1 AND revokedat IS NULL;
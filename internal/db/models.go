@@ -6,21 +6,94 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type ApiToken struct {
+	Tokenid   string         `db:"tokenid" json:"tokenid"`
+	Name      string         `db:"name" json:"name"`
+	Tokenhash string         `db:"tokenhash" json:"tokenhash"`
+	Scope     string         `db:"scope" json:"scope"`
+	Userid    sql.NullString `db:"userid" json:"userid"`
+	Createdat time.Time      `db:"createdat" json:"createdat"`
+	Revokedat sql.NullTime   `db:"revokedat" json:"revokedat"`
+}
+
+type AssignmentEvent struct {
+	Eventid        int64     `db:"eventid" json:"eventid"`
+	Pullrequestid  string    `db:"pullrequestid" json:"pullrequestid"`
+	Eventtype      string    `db:"eventtype" json:"eventtype"`
+	Userid         string    `db:"userid" json:"userid"`
+	Previoususerid string    `db:"previoususerid" json:"previoususerid"`
+	Status         string    `db:"status" json:"status"`
+	Actoruserid    string    `db:"actoruserid" json:"actoruserid"`
+	Actortokenid   string    `db:"actortokenid" json:"actortokenid"`
+	Reason         string    `db:"reason" json:"reason"`
+	Strategy       string    `db:"strategy" json:"strategy"`
+	Createdat      time.Time `db:"createdat" json:"createdat"`
+}
+
+type AssignmentExplanation struct {
+	Explanationid  int64           `db:"explanationid" json:"explanationid"`
+	Pullrequestid  string          `db:"pullrequestid" json:"pullrequestid"`
+	Reason         string          `db:"reason" json:"reason"`
+	Strategy       string          `db:"strategy" json:"strategy"`
+	Teamname       string          `db:"teamname" json:"teamname"`
+	Replaceduserid string          `db:"replaceduserid" json:"replaceduserid"`
+	Slots          int32           `db:"slots" json:"slots"`
+	Selected       json.RawMessage `db:"selected" json:"selected"`
+	Candidates     json.RawMessage `db:"candidates" json:"candidates"`
+	Createdat      time.Time       `db:"createdat" json:"createdat"`
+}
+
+type MergeOverride struct {
+	Overrideid    int64     `db:"overrideid" json:"overrideid"`
+	Pullrequestid string    `db:"pullrequestid" json:"pullrequestid"`
+	Actoruserid   string    `db:"actoruserid" json:"actoruserid"`
+	Actortokenid  string    `db:"actortokenid" json:"actortokenid"`
+	Conditions    string    `db:"conditions" json:"conditions"`
+	Createdat     time.Time `db:"createdat" json:"createdat"`
+}
+
 type PullRequest struct {
 	Pullrequestid string         `db:"pullrequestid" json:"pullrequestid"`
 	Name          sql.NullString `db:"name" json:"name"`
 	Status        string         `db:"status" json:"status"`
 	Createdat     time.Time      `db:"createdat" json:"createdat"`
 	Mergedat      sql.NullTime   `db:"mergedat" json:"mergedat"`
+	Authorid      sql.NullString `db:"authorid" json:"authorid"`
+	Minreviewers  int32          `db:"minreviewers" json:"minreviewers"`
+}
+
+type PullRequestExcludedReviewer struct {
+	Pullrequestid string `db:"pullrequestid" json:"pullrequestid"`
+	Userid        string `db:"userid" json:"userid"`
+}
+
+type ReviewDecision struct {
+	Decisionid    int64     `db:"decisionid" json:"decisionid"`
+	Pullrequestid string    `db:"pullrequestid" json:"pullrequestid"`
+	Userid        string    `db:"userid" json:"userid"`
+	Decision      string    `db:"decision" json:"decision"`
+	Comment       string    `db:"comment" json:"comment"`
+	Createdat     time.Time `db:"createdat" json:"createdat"`
 }
 
 type Team struct {
 	Teamname string `db:"teamname" json:"teamname"`
 }
 
+type TeamSetting struct {
+	Teamname           string    `db:"teamname" json:"teamname"`
+	Minreviewers       int32     `db:"minreviewers" json:"minreviewers"`
+	Maxreviewers       int32     `db:"maxreviewers" json:"maxreviewers"`
+	Updatedat          time.Time `db:"updatedat" json:"updatedat"`
+	Requiredapprovals  int32     `db:"requiredapprovals" json:"requiredapprovals"`
+	Reviewslahours     int32     `db:"reviewslahours" json:"reviewslahours"`
+	Reassignafterhours int32     `db:"reassignafterhours" json:"reassignafterhours"`
+}
+
 type User struct {
 	Userid   string `db:"userid" json:"userid"`
 	Username string `db:"username" json:"username"`
@@ -28,12 +101,15 @@ type User struct {
 }
 
 type UsersPullRequest struct {
-	Pullrequestid string `db:"pullrequestid" json:"pullrequestid"`
-	Userid        string `db:"userid" json:"userid"`
-	Role          string `db:"role" json:"role"`
+	Pullrequestid string       `db:"pullrequestid" json:"pullrequestid"`
+	Userid        string       `db:"userid" json:"userid"`
+	Role          string       `db:"role" json:"role"`
+	Assignedat    time.Time    `db:"assignedat" json:"assignedat"`
+	Notifiedat    sql.NullTime `db:"notifiedat" json:"notifiedat"`
 }
 
 type UsersTeam struct {
 	Teamname string `db:"teamname" json:"teamname"`
 	Userid   string `db:"userid" json:"userid"`
+	Role     string `db:"role" json:"role"`
 }
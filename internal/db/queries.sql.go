@@ -8,6 +8,8 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
 const assignUserPullRequest = `-- name: AssignUserPullRequest :exec
@@ -25,18 +27,167 @@ func (q *Queries) AssignUserPullRequest(ctx context.Context, arg AssignUserPullR
 	return err
 }
 
+const createAPIToken = `-- name: CreateAPIToken :exec
+INSERT INTO api_tokens (tokenid, name, tokenhash, scope, userid) VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateAPITokenParams struct {
+	Tokenid   string         `db:"tokenid" json:"tokenid"`
+	Name      string         `db:"name" json:"name"`
+	Tokenhash string         `db:"tokenhash" json:"tokenhash"`
+	Scope     string         `db:"scope" json:"scope"`
+	Userid    sql.NullString `db:"userid" json:"userid"`
+}
+
+func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) error {
+	_, err := q.db.ExecContext(ctx, createAPIToken,
+		arg.Tokenid,
+		arg.Name,
+		arg.Tokenhash,
+		arg.Scope,
+		arg.Userid,
+	)
+	return err
+}
+
+const createAssignmentEvent = `-- name: CreateAssignmentEvent :exec
+INSERT INTO assignment_events (pullrequestid, eventtype, userid, previoususerid, status, actoruserid, actortokenid, reason, strategy)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+type CreateAssignmentEventParams struct {
+	Pullrequestid  string `db:"pullrequestid" json:"pullrequestid"`
+	Eventtype      string `db:"eventtype" json:"eventtype"`
+	Userid         string `db:"userid" json:"userid"`
+	Previoususerid string `db:"previoususerid" json:"previoususerid"`
+	Status         string `db:"status" json:"status"`
+	Actoruserid    string `db:"actoruserid" json:"actoruserid"`
+	Actortokenid   string `db:"actortokenid" json:"actortokenid"`
+	Reason         string `db:"reason" json:"reason"`
+	Strategy       string `db:"strategy" json:"strategy"`
+}
+
+func (q *Queries) CreateAssignmentEvent(ctx context.Context, arg CreateAssignmentEventParams) error {
+	_, err := q.db.ExecContext(ctx, createAssignmentEvent,
+		arg.Pullrequestid,
+		arg.Eventtype,
+		arg.Userid,
+		arg.Previoususerid,
+		arg.Status,
+		arg.Actoruserid,
+		arg.Actortokenid,
+		arg.Reason,
+		arg.Strategy,
+	)
+	return err
+}
+
+const createAssignmentExplanation = `-- name: CreateAssignmentExplanation :exec
+INSERT INTO assignment_explanations (pullrequestid, reason, strategy, teamname, replaceduserid, slots, selected, candidates)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateAssignmentExplanationParams struct {
+	Pullrequestid  string          `db:"pullrequestid" json:"pullrequestid"`
+	Reason         string          `db:"reason" json:"reason"`
+	Strategy       string          `db:"strategy" json:"strategy"`
+	Teamname       string          `db:"teamname" json:"teamname"`
+	Replaceduserid string          `db:"replaceduserid" json:"replaceduserid"`
+	Slots          int32           `db:"slots" json:"slots"`
+	Selected       json.RawMessage `db:"selected" json:"selected"`
+	Candidates     json.RawMessage `db:"candidates" json:"candidates"`
+}
+
+func (q *Queries) CreateAssignmentExplanation(ctx context.Context, arg CreateAssignmentExplanationParams) error {
+	_, err := q.db.ExecContext(ctx, createAssignmentExplanation,
+		arg.Pullrequestid,
+		arg.Reason,
+		arg.Strategy,
+		arg.Teamname,
+		arg.Replaceduserid,
+		arg.Slots,
+		arg.Selected,
+		arg.Candidates,
+	)
+	return err
+}
+
+const createExcludedReviewer = `-- name: CreateExcludedReviewer :exec
+INSERT INTO pull_request_excluded_reviewers (pullrequestid, userid) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`
+
+type CreateExcludedReviewerParams struct {
+	Pullrequestid string `db:"pullrequestid" json:"pullrequestid"`
+	Userid        string `db:"userid" json:"userid"`
+}
+
+func (q *Queries) CreateExcludedReviewer(ctx context.Context, arg CreateExcludedReviewerParams) error {
+	_, err := q.db.ExecContext(ctx, createExcludedReviewer, arg.Pullrequestid, arg.Userid)
+	return err
+}
+
+const createMergeOverride = `-- name: CreateMergeOverride :exec
+INSERT INTO merge_overrides (pullrequestid, actoruserid, actortokenid, conditions) VALUES ($1, $2, $3, $4)
+`
+
+type CreateMergeOverrideParams struct {
+	Pullrequestid string `db:"pullrequestid" json:"pullrequestid"`
+	Actoruserid   string `db:"actoruserid" json:"actoruserid"`
+	Actortokenid  string `db:"actortokenid" json:"actortokenid"`
+	Conditions    string `db:"conditions" json:"conditions"`
+}
+
+func (q *Queries) CreateMergeOverride(ctx context.Context, arg CreateMergeOverrideParams) error {
+	_, err := q.db.ExecContext(ctx, createMergeOverride,
+		arg.Pullrequestid,
+		arg.Actoruserid,
+		arg.Actortokenid,
+		arg.Conditions,
+	)
+	return err
+}
+
 const createPullRequest = `-- name: CreatePullRequest :exec
-INSERT INTO pull_requests (pullrequestid, name, status) VALUES ($1, $2, $3)
+INSERT INTO pull_requests (pullrequestid, name, authorid, status, minreviewers) VALUES ($1, $2, $3, $4, $5)
 `
 
 type CreatePullRequestParams struct {
 	Pullrequestid string         `db:"pullrequestid" json:"pullrequestid"`
 	Name          sql.NullString `db:"name" json:"name"`
+	Authorid      sql.NullString `db:"authorid" json:"authorid"`
 	Status        string         `db:"status" json:"status"`
+	Minreviewers  int32          `db:"minreviewers" json:"minreviewers"`
 }
 
 func (q *Queries) CreatePullRequest(ctx context.Context, arg CreatePullRequestParams) error {
-	_, err := q.db.ExecContext(ctx, createPullRequest, arg.Pullrequestid, arg.Name, arg.Status)
+	_, err := q.db.ExecContext(ctx, createPullRequest,
+		arg.Pullrequestid,
+		arg.Name,
+		arg.Authorid,
+		arg.Status,
+		arg.Minreviewers,
+	)
+	return err
+}
+
+const createReviewDecision = `-- name: CreateReviewDecision :exec
+INSERT INTO review_decisions (pullrequestid, userid, decision, comment) VALUES ($1, $2, $3, $4)
+`
+
+type CreateReviewDecisionParams struct {
+	Pullrequestid string `db:"pullrequestid" json:"pullrequestid"`
+	Userid        string `db:"userid" json:"userid"`
+	Decision      string `db:"decision" json:"decision"`
+	Comment       string `db:"comment" json:"comment"`
+}
+
+func (q *Queries) CreateReviewDecision(ctx context.Context, arg CreateReviewDecisionParams) error {
+	_, err := q.db.ExecContext(ctx, createReviewDecision,
+		arg.Pullrequestid,
+		arg.Userid,
+		arg.Decision,
+		arg.Comment,
+	)
 	return err
 }
 
@@ -49,6 +200,45 @@ func (q *Queries) CreateTeam(ctx context.Context, teamname string) error {
 	return err
 }
 
+const deactivateTeamMembers = `-- name: DeactivateTeamMembers :many
+UPDATE users u
+SET isactive = false
+FROM users_team ut
+WHERE ut.userid = u.userid
+  AND ut.teamname = $1
+  AND ($2::bool OR u.userid IN (SELECT jsonb_array_elements_text($3::jsonb)))
+RETURNING u.userid
+`
+
+type DeactivateTeamMembersParams struct {
+	Teamname   string          `db:"teamname" json:"teamname"`
+	AllMembers bool            `db:"all_members" json:"all_members"`
+	UserIds    json.RawMessage `db:"user_ids" json:"user_ids"`
+}
+
+func (q *Queries) DeactivateTeamMembers(ctx context.Context, arg DeactivateTeamMembersParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, deactivateTeamMembers, arg.Teamname, arg.AllMembers, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var userid string
+		if err := rows.Scan(&userid); err != nil {
+			return nil, err
+		}
+		items = append(items, userid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deletePullRequestAssignOfUser = `-- name: DeletePullRequestAssignOfUser :exec
 DELETE FROM users_pull_requests WHERE pullrequestid = $1 AND userid = $2
 `
@@ -63,6 +253,121 @@ func (q *Queries) DeletePullRequestAssignOfUser(ctx context.Context, arg DeleteP
 	return err
 }
 
+const deleteTeam = `-- name: DeleteTeam :execrows
+DELETE FROM teams WHERE teamname = $1
+`
+
+func (q *Queries) DeleteTeam(ctx context.Context, teamname string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTeam, teamname)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteTeamMembers = `-- name: DeleteTeamMembers :many
+DELETE FROM users_team WHERE teamname = $1
+RETURNING userid
+`
+
+func (q *Queries) DeleteTeamMembers(ctx context.Context, teamname string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, deleteTeamMembers, teamname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var userid string
+		if err := rows.Scan(&userid); err != nil {
+			return nil, err
+		}
+		items = append(items, userid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteUserTeam = `-- name: DeleteUserTeam :execrows
+DELETE FROM users_team WHERE teamname = $1 AND userid = $2
+`
+
+type DeleteUserTeamParams struct {
+	Teamname string `db:"teamname" json:"teamname"`
+	Userid   string `db:"userid" json:"userid"`
+}
+
+func (q *Queries) DeleteUserTeam(ctx context.Context, arg DeleteUserTeamParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserTeam, arg.Teamname, arg.Userid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAPITokenByHash = `-- name: GetAPITokenByHash :one
+SELECT tokenid, name, tokenhash, scope, userid, createdat, revokedat
+FROM api_tokens
+WHERE tokenhash = $1 AND revokedat IS NULL
+`
+
+func (q *Queries) GetAPITokenByHash(ctx context.Context, tokenhash string) (ApiToken, error) {
+	row := q.db.QueryRowContext(ctx, getAPITokenByHash, tokenhash)
+	var i ApiToken
+	err := row.Scan(
+		&i.Tokenid,
+		&i.Name,
+		&i.Tokenhash,
+		&i.Scope,
+		&i.Userid,
+		&i.Createdat,
+		&i.Revokedat,
+	)
+	return i, err
+}
+
+const getAPITokens = `-- name: GetAPITokens :many
+SELECT tokenid, name, tokenhash, scope, userid, createdat, revokedat
+FROM api_tokens
+ORDER BY createdat, tokenid
+`
+
+func (q *Queries) GetAPITokens(ctx context.Context) ([]ApiToken, error) {
+	rows, err := q.db.QueryContext(ctx, getAPITokens)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiToken
+	for rows.Next() {
+		var i ApiToken
+		if err := rows.Scan(
+			&i.Tokenid,
+			&i.Name,
+			&i.Tokenhash,
+			&i.Scope,
+			&i.Userid,
+			&i.Createdat,
+			&i.Revokedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getListOfUsersByPullRequestID = `-- name: GetListOfUsersByPullRequestID :many
 SELECT userid, role FROM users_pull_requests WHERE pullrequestid = $1
 `
@@ -95,42 +400,388 @@ func (q *Queries) GetListOfUsersByPullRequestID(ctx context.Context, pullrequest
 	return items, nil
 }
 
+const getMergeTimeStats = `-- name: GetMergeTimeStats :one
+SELECT COUNT(*) AS merged_pull_requests,
+       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.mergedat - pr.createdat)), 0)::float8 AS median_merge_seconds
+FROM pull_requests pr
+WHERE pr.status = 'MERGED'
+  AND pr.mergedat IS NOT NULL
+  AND ($1::text IS NULL OR pr.authorid IN (SELECT userid FROM users_team WHERE teamname = $1))
+  AND ($2::timestamp IS NULL OR pr.createdat >= $2)
+  AND ($3::timestamp IS NULL OR pr.createdat < $3)
+`
+
+type GetMergeTimeStatsParams struct {
+	Teamname    sql.NullString `db:"teamname" json:"teamname"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+}
+
+type GetMergeTimeStatsRow struct {
+	MergedPullRequests int64   `db:"merged_pull_requests" json:"merged_pull_requests"`
+	MedianMergeSeconds float64 `db:"median_merge_seconds" json:"median_merge_seconds"`
+}
+
+func (q *Queries) GetMergeTimeStats(ctx context.Context, arg GetMergeTimeStatsParams) (GetMergeTimeStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getMergeTimeStats, arg.Teamname, arg.CreatedFrom, arg.CreatedTo)
+	var i GetMergeTimeStatsRow
+	err := row.Scan(&i.MergedPullRequests, &i.MedianMergeSeconds)
+	return i, err
+}
+
+const getOpenReviewCounts = `-- name: GetOpenReviewCounts :many
+SELECT upr.userid, COUNT(*) AS open_reviews
+FROM users_pull_requests upr
+         JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid
+WHERE upr.role = 'reviewer'
+  AND pr.status IN ('OPEN', 'REOPENED')
+GROUP BY upr.userid
+`
+
+type GetOpenReviewCountsRow struct {
+	Userid      string `db:"userid" json:"userid"`
+	OpenReviews int64  `db:"open_reviews" json:"open_reviews"`
+}
+
+func (q *Queries) GetOpenReviewCounts(ctx context.Context) ([]GetOpenReviewCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOpenReviewCounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOpenReviewCountsRow
+	for rows.Next() {
+		var i GetOpenReviewCountsRow
+		if err := rows.Scan(&i.Userid, &i.OpenReviews); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingReviewAssignments = `-- name: GetPendingReviewAssignments :many
+SELECT upr.pullrequestid, pr.authorid, upr.userid, upr.assignedat, upr.notifiedat
+FROM users_pull_requests upr
+         JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid
+WHERE upr.role = 'reviewer'
+  AND pr.status IN ('OPEN', 'REOPENED')
+  AND NOT EXISTS (SELECT 1
+                  FROM review_decisions rd
+                  WHERE rd.pullrequestid = upr.pullrequestid
+                    AND rd.userid = upr.userid
+                    AND rd.decision IN ('APPROVED', 'CHANGES_REQUESTED')
+                    AND rd.createdat >= upr.assignedat)
+ORDER BY upr.assignedat, upr.pullrequestid, upr.userid
+`
+
+type GetPendingReviewAssignmentsRow struct {
+	Pullrequestid string       `db:"pullrequestid" json:"pullrequestid"`
+	Authorid      string       `db:"authorid" json:"authorid"`
+	Userid        string       `db:"userid" json:"userid"`
+	Assignedat    time.Time    `db:"assignedat" json:"assignedat"`
+	Notifiedat    sql.NullTime `db:"notifiedat" json:"notifiedat"`
+}
+
+func (q *Queries) GetPendingReviewAssignments(ctx context.Context) ([]GetPendingReviewAssignmentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingReviewAssignments)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPendingReviewAssignmentsRow
+	for rows.Next() {
+		var i GetPendingReviewAssignmentsRow
+		if err := rows.Scan(
+			&i.Pullrequestid,
+			&i.Authorid,
+			&i.Userid,
+			&i.Assignedat,
+			&i.Notifiedat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPullRequestAssignmentEvents = `-- name: GetPullRequestAssignmentEvents :many
+SELECT eventid, pullrequestid, eventtype, userid, previoususerid, status, actoruserid, actortokenid, reason, strategy, createdat
+FROM assignment_events
+WHERE pullrequestid = $1
+ORDER BY eventid
+`
+
+func (q *Queries) GetPullRequestAssignmentEvents(ctx context.Context, pullrequestid string) ([]AssignmentEvent, error) {
+	rows, err := q.db.QueryContext(ctx, getPullRequestAssignmentEvents, pullrequestid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AssignmentEvent
+	for rows.Next() {
+		var i AssignmentEvent
+		if err := rows.Scan(
+			&i.Eventid,
+			&i.Pullrequestid,
+			&i.Eventtype,
+			&i.Userid,
+			&i.Previoususerid,
+			&i.Status,
+			&i.Actoruserid,
+			&i.Actortokenid,
+			&i.Reason,
+			&i.Strategy,
+			&i.Createdat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPullRequestAssignmentExplanations = `-- name: GetPullRequestAssignmentExplanations :many
+SELECT explanationid, pullrequestid, reason, strategy, teamname, replaceduserid, slots, selected, candidates, createdat
+FROM assignment_explanations
+WHERE pullrequestid = $1
+ORDER BY explanationid
+`
+
+func (q *Queries) GetPullRequestAssignmentExplanations(ctx context.Context, pullrequestid string) ([]AssignmentExplanation, error) {
+	rows, err := q.db.QueryContext(ctx, getPullRequestAssignmentExplanations, pullrequestid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AssignmentExplanation
+	for rows.Next() {
+		var i AssignmentExplanation
+		if err := rows.Scan(
+			&i.Explanationid,
+			&i.Pullrequestid,
+			&i.Reason,
+			&i.Strategy,
+			&i.Teamname,
+			&i.Replaceduserid,
+			&i.Slots,
+			&i.Selected,
+			&i.Candidates,
+			&i.Createdat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPullRequestByID = `-- name: GetPullRequestByID :one
-SELECT pullrequestid, name, status, createdat, mergedat FROM pull_requests WHERE pullrequestid = $1
+SELECT pr.pullrequestid,
+       pr.name,
+       pr.authorid,
+       pr.status,
+       pr.createdat,
+       pr.mergedat,
+       pr.minreviewers,
+       COALESCE(json_agg(upr.userid ORDER BY upr.userid) FILTER (WHERE upr.role = 'reviewer'), '[]')::json AS reviewers,
+       COALESCE((SELECT json_agg(json_build_object('user_id', rd.userid, 'decision', rd.decision, 'comment', rd.comment,
+                                                   'created_at', rd.createdat) ORDER BY rd.userid)
+                 FROM (SELECT DISTINCT ON (d.userid) d.userid, d.decision, d.comment, d.createdat
+                       FROM review_decisions d
+                                JOIN users_pull_requests r
+                                     ON r.pullrequestid = d.pullrequestid AND r.userid = d.userid AND r.role = 'reviewer'
+                                         AND d.createdat >= r.assignedat
+                       WHERE d.pullrequestid = pr.pullrequestid
+                       ORDER BY d.userid, d.createdat DESC, d.decisionid DESC) rd), '[]')::json AS reviews
+FROM pull_requests pr
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid
+WHERE pr.pullrequestid = $1
+GROUP BY pr.pullrequestid
 `
 
-func (q *Queries) GetPullRequestByID(ctx context.Context, pullrequestid string) (PullRequest, error) {
+type GetPullRequestByIDRow struct {
+	Pullrequestid string          `db:"pullrequestid" json:"pullrequestid"`
+	Name          sql.NullString  `db:"name" json:"name"`
+	Authorid      sql.NullString  `db:"authorid" json:"authorid"`
+	Status        string          `db:"status" json:"status"`
+	Createdat     time.Time       `db:"createdat" json:"createdat"`
+	Mergedat      sql.NullTime    `db:"mergedat" json:"mergedat"`
+	Minreviewers  int32           `db:"minreviewers" json:"minreviewers"`
+	Reviewers     json.RawMessage `db:"reviewers" json:"reviewers"`
+	Reviews       json.RawMessage `db:"reviews" json:"reviews"`
+}
+
+func (q *Queries) GetPullRequestByID(ctx context.Context, pullrequestid string) (GetPullRequestByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getPullRequestByID, pullrequestid)
-	var i PullRequest
+	var i GetPullRequestByIDRow
 	err := row.Scan(
 		&i.Pullrequestid,
 		&i.Name,
+		&i.Authorid,
 		&i.Status,
 		&i.Createdat,
 		&i.Mergedat,
+		&i.Minreviewers,
+		&i.Reviewers,
+		&i.Reviews,
 	)
 	return i, err
 }
 
+const getPullRequestExcludedReviewers = `-- name: GetPullRequestExcludedReviewers :many
+SELECT userid FROM pull_request_excluded_reviewers WHERE pullrequestid = $1 ORDER BY userid
+`
+
+func (q *Queries) GetPullRequestExcludedReviewers(ctx context.Context, pullrequestid string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getPullRequestExcludedReviewers, pullrequestid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var userid string
+		if err := rows.Scan(&userid); err != nil {
+			return nil, err
+		}
+		items = append(items, userid)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPullRequestReviewerStats = `-- name: GetPullRequestReviewerStats :many
+SELECT pr.pullrequestid, pr.status, COUNT(upr.userid) AS reviewers
+FROM pull_requests pr
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid AND upr.role = 'reviewer'
+WHERE ($1::text IS NULL OR pr.authorid IN (SELECT userid FROM users_team WHERE teamname = $1))
+  AND ($2::timestamp IS NULL OR pr.createdat >= $2)
+  AND ($3::timestamp IS NULL OR pr.createdat < $3)
+GROUP BY pr.pullrequestid, pr.status
+ORDER BY pr.pullrequestid
+`
+
+type GetPullRequestReviewerStatsParams struct {
+	Teamname    sql.NullString `db:"teamname" json:"teamname"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+}
+
+type GetPullRequestReviewerStatsRow struct {
+	Pullrequestid string `db:"pullrequestid" json:"pullrequestid"`
+	Status        string `db:"status" json:"status"`
+	Reviewers     int64  `db:"reviewers" json:"reviewers"`
+}
+
+func (q *Queries) GetPullRequestReviewerStats(ctx context.Context, arg GetPullRequestReviewerStatsParams) ([]GetPullRequestReviewerStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPullRequestReviewerStats, arg.Teamname, arg.CreatedFrom, arg.CreatedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPullRequestReviewerStatsRow
+	for rows.Next() {
+		var i GetPullRequestReviewerStatsRow
+		if err := rows.Scan(&i.Pullrequestid, &i.Status, &i.Reviewers); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPullRequests = `-- name: GetPullRequests :many
-SELECT pullrequestid, name, status, createdat, mergedat FROM pull_requests
+SELECT pr.pullrequestid,
+       pr.name,
+       pr.authorid,
+       pr.status,
+       pr.createdat,
+       pr.mergedat,
+       pr.minreviewers,
+       COALESCE(json_agg(upr.userid ORDER BY upr.userid) FILTER (WHERE upr.role = 'reviewer'), '[]')::json AS reviewers,
+       COALESCE((SELECT json_agg(json_build_object('user_id', rd.userid, 'decision', rd.decision, 'comment', rd.comment,
+                                                   'created_at', rd.createdat) ORDER BY rd.userid)
+                 FROM (SELECT DISTINCT ON (d.userid) d.userid, d.decision, d.comment, d.createdat
+                       FROM review_decisions d
+                                JOIN users_pull_requests r
+                                     ON r.pullrequestid = d.pullrequestid AND r.userid = d.userid AND r.role = 'reviewer'
+                                         AND d.createdat >= r.assignedat
+                       WHERE d.pullrequestid = pr.pullrequestid
+                       ORDER BY d.userid, d.createdat DESC, d.decisionid DESC) rd), '[]')::json AS reviews
+FROM pull_requests pr
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid
+GROUP BY pr.pullrequestid
+ORDER BY pr.createdat
 `
 
-func (q *Queries) GetPullRequests(ctx context.Context) ([]PullRequest, error) {
+type GetPullRequestsRow struct {
+	Pullrequestid string          `db:"pullrequestid" json:"pullrequestid"`
+	Name          sql.NullString  `db:"name" json:"name"`
+	Authorid      sql.NullString  `db:"authorid" json:"authorid"`
+	Status        string          `db:"status" json:"status"`
+	Createdat     time.Time       `db:"createdat" json:"createdat"`
+	Mergedat      sql.NullTime    `db:"mergedat" json:"mergedat"`
+	Minreviewers  int32           `db:"minreviewers" json:"minreviewers"`
+	Reviewers     json.RawMessage `db:"reviewers" json:"reviewers"`
+	Reviews       json.RawMessage `db:"reviews" json:"reviews"`
+}
+
+func (q *Queries) GetPullRequests(ctx context.Context) ([]GetPullRequestsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getPullRequests)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []PullRequest
+	var items []GetPullRequestsRow
 	for rows.Next() {
-		var i PullRequest
+		var i GetPullRequestsRow
 		if err := rows.Scan(
 			&i.Pullrequestid,
 			&i.Name,
+			&i.Authorid,
 			&i.Status,
 			&i.Createdat,
 			&i.Mergedat,
+			&i.Minreviewers,
+			&i.Reviewers,
+			&i.Reviews,
 		); err != nil {
 			return nil, err
 		}
@@ -145,6 +796,54 @@ func (q *Queries) GetPullRequests(ctx context.Context) ([]PullRequest, error) {
 	return items, nil
 }
 
+const getReviewerAssignmentStats = `-- name: GetReviewerAssignmentStats :many
+SELECT upr.userid,
+       COUNT(*) FILTER (WHERE pr.status IN ('OPEN', 'REOPENED')) AS open_reviews,
+       COUNT(*) FILTER (WHERE pr.status = 'MERGED')                AS merged_reviews
+FROM pull_requests pr
+         JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid AND upr.role = 'reviewer'
+WHERE ($1::text IS NULL OR pr.authorid IN (SELECT userid FROM users_team WHERE teamname = $1))
+  AND ($2::timestamp IS NULL OR pr.createdat >= $2)
+  AND ($3::timestamp IS NULL OR pr.createdat < $3)
+GROUP BY upr.userid
+ORDER BY upr.userid
+`
+
+type GetReviewerAssignmentStatsParams struct {
+	Teamname    sql.NullString `db:"teamname" json:"teamname"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+}
+
+type GetReviewerAssignmentStatsRow struct {
+	Userid        string `db:"userid" json:"userid"`
+	OpenReviews   int64  `db:"open_reviews" json:"open_reviews"`
+	MergedReviews int64  `db:"merged_reviews" json:"merged_reviews"`
+}
+
+func (q *Queries) GetReviewerAssignmentStats(ctx context.Context, arg GetReviewerAssignmentStatsParams) ([]GetReviewerAssignmentStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewerAssignmentStats, arg.Teamname, arg.CreatedFrom, arg.CreatedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewerAssignmentStatsRow
+	for rows.Next() {
+		var i GetReviewerAssignmentStatsRow
+		if err := rows.Scan(&i.Userid, &i.OpenReviews, &i.MergedReviews); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeamByName = `-- name: GetTeamByName :one
 SELECT teamname FROM teams WHERE teamname = $1
 `
@@ -155,6 +854,129 @@ func (q *Queries) GetTeamByName(ctx context.Context, teamname string) (string, e
 	return teamname, err
 }
 
+const getTeamMembers = `-- name: GetTeamMembers :many
+SELECT u.userid,
+       u.username,
+       u.isactive,
+       ut.role
+FROM users u
+         JOIN users_team ut ON ut.userid = u.userid
+WHERE ut.teamname = $1
+ORDER BY u.userid
+`
+
+type GetTeamMembersRow struct {
+	Userid   string `db:"userid" json:"userid"`
+	Username string `db:"username" json:"username"`
+	Isactive bool   `db:"isactive" json:"isactive"`
+	Role     string `db:"role" json:"role"`
+}
+
+func (q *Queries) GetTeamMembers(ctx context.Context, teamname string) ([]GetTeamMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTeamMembers, teamname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTeamMembersRow
+	for rows.Next() {
+		var i GetTeamMembersRow
+		if err := rows.Scan(
+			&i.Userid,
+			&i.Username,
+			&i.Isactive,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTeamSettings = `-- name: GetTeamSettings :one
+SELECT teamname, minreviewers, maxreviewers, updatedat, requiredapprovals, reviewslahours, reassignafterhours
+FROM team_settings
+WHERE teamname = $1
+`
+
+func (q *Queries) GetTeamSettings(ctx context.Context, teamname string) (TeamSetting, error) {
+	row := q.db.QueryRowContext(ctx, getTeamSettings, teamname)
+	var i TeamSetting
+	err := row.Scan(
+		&i.Teamname,
+		&i.Minreviewers,
+		&i.Maxreviewers,
+		&i.Updatedat,
+		&i.Requiredapprovals,
+		&i.Reviewslahours,
+		&i.Reassignafterhours,
+	)
+	return i, err
+}
+
+const getTeamStats = `-- name: GetTeamStats :many
+SELECT ut.teamname,
+       COUNT(DISTINCT pr.pullrequestid) FILTER (WHERE pr.status IN ('OPEN', 'REOPENED')) AS open_pull_requests,
+       COUNT(DISTINCT pr.pullrequestid) FILTER (WHERE pr.status = 'MERGED')                AS merged_pull_requests,
+       COUNT(upr.userid)                                                                  AS assignments
+FROM pull_requests pr
+         JOIN users_team ut ON ut.userid = pr.authorid
+         LEFT JOIN users_pull_requests upr ON upr.pullrequestid = pr.pullrequestid AND upr.role = 'reviewer'
+WHERE ($1::text IS NULL OR ut.teamname = $1)
+  AND ($2::timestamp IS NULL OR pr.createdat >= $2)
+  AND ($3::timestamp IS NULL OR pr.createdat < $3)
+GROUP BY ut.teamname
+ORDER BY ut.teamname
+`
+
+type GetTeamStatsParams struct {
+	Teamname    sql.NullString `db:"teamname" json:"teamname"`
+	CreatedFrom sql.NullTime   `db:"created_from" json:"created_from"`
+	CreatedTo   sql.NullTime   `db:"created_to" json:"created_to"`
+}
+
+type GetTeamStatsRow struct {
+	Teamname           string `db:"teamname" json:"teamname"`
+	OpenPullRequests   int64  `db:"open_pull_requests" json:"open_pull_requests"`
+	MergedPullRequests int64  `db:"merged_pull_requests" json:"merged_pull_requests"`
+	Assignments        int64  `db:"assignments" json:"assignments"`
+}
+
+func (q *Queries) GetTeamStats(ctx context.Context, arg GetTeamStatsParams) ([]GetTeamStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTeamStats, arg.Teamname, arg.CreatedFrom, arg.CreatedTo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTeamStatsRow
+	for rows.Next() {
+		var i GetTeamStatsRow
+		if err := rows.Scan(
+			&i.Teamname,
+			&i.OpenPullRequests,
+			&i.MergedPullRequests,
+			&i.Assignments,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTeams = `-- name: GetTeams :many
 SELECT teamname FROM teams
 `
@@ -182,6 +1004,49 @@ func (q *Queries) GetTeams(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
+const getUserAssignmentEvents = `-- name: GetUserAssignmentEvents :many
+SELECT eventid, pullrequestid, eventtype, userid, previoususerid, status, actoruserid, actortokenid, reason, strategy, createdat
+FROM assignment_events
+WHERE userid = $1
+   OR previoususerid = $1
+ORDER BY eventid
+`
+
+func (q *Queries) GetUserAssignmentEvents(ctx context.Context, userid string) ([]AssignmentEvent, error) {
+	rows, err := q.db.QueryContext(ctx, getUserAssignmentEvents, userid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AssignmentEvent
+	for rows.Next() {
+		var i AssignmentEvent
+		if err := rows.Scan(
+			&i.Eventid,
+			&i.Pullrequestid,
+			&i.Eventtype,
+			&i.Userid,
+			&i.Previoususerid,
+			&i.Status,
+			&i.Actoruserid,
+			&i.Actortokenid,
+			&i.Reason,
+			&i.Strategy,
+			&i.Createdat,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByID = `-- name: GetUserByID :one
 SELECT userid, username, isactive FROM users WHERE userid = $1
 `
@@ -221,7 +1086,7 @@ func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
 }
 
 const getUsersAssignedPullRequest = `-- name: GetUsersAssignedPullRequest :many
-SELECT pull_requests.pullrequestid, role FROM pull_requests, users_pull_requests WHERE pull_requests.pullrequestid = (SELECT pullrequestid FROM users_pull_requests WHERE users_pull_requests.userid = $1)
+SELECT pullrequestid, role FROM users_pull_requests WHERE userid = $1
 `
 
 type GetUsersAssignedPullRequestRow struct {
@@ -314,6 +1179,146 @@ func (q *Queries) GetUsersTeams(ctx context.Context, userid string) ([]string, e
 	return items, nil
 }
 
+const isTeamLead = `-- name: IsTeamLead :one
+SELECT EXISTS (SELECT 1
+               FROM users_team lead
+                        JOIN users_team member ON member.teamname = lead.teamname
+               WHERE lead.userid = $1
+                 AND lead.role = 'lead'
+                 AND member.userid = $2) AS is_lead
+`
+
+type IsTeamLeadParams struct {
+	LeadID   string `db:"lead_id" json:"lead_id"`
+	MemberID string `db:"member_id" json:"member_id"`
+}
+
+func (q *Queries) IsTeamLead(ctx context.Context, arg IsTeamLeadParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isTeamLead, arg.LeadID, arg.MemberID)
+	var is_lead bool
+	err := row.Scan(&is_lead)
+	return is_lead, err
+}
+
+const markAssignmentNotified = `-- name: MarkAssignmentNotified :exec
+UPDATE users_pull_requests SET notifiedat = $1 WHERE pullrequestid = $2 AND userid = $3
+`
+
+type MarkAssignmentNotifiedParams struct {
+	Notifiedat    sql.NullTime `db:"notifiedat" json:"notifiedat"`
+	Pullrequestid string       `db:"pullrequestid" json:"pullrequestid"`
+	Userid        string       `db:"userid" json:"userid"`
+}
+
+func (q *Queries) MarkAssignmentNotified(ctx context.Context, arg MarkAssignmentNotifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markAssignmentNotified, arg.Notifiedat, arg.Pullrequestid, arg.Userid)
+	return err
+}
+
+const replaceTeamReviewers = `-- name: ReplaceTeamReviewers :many
+WITH slots AS (
+    SELECT upr.pullrequestid,
+           upr.userid                                                               AS old_userid,
+           row_number() OVER (PARTITION BY upr.pullrequestid ORDER BY upr.userid) AS slot
+    FROM users_pull_requests upr
+             JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid
+    WHERE upr.role = 'reviewer'
+      AND pr.status IN ('OPEN', 'REOPENED')
+      AND upr.userid IN (SELECT jsonb_array_elements_text($1::jsonb))
+      AND (NOT $2::bool OR
+           pr.authorid IN (SELECT userid FROM users_team WHERE teamname = $3))
+),
+     candidates AS (
+         SELECT p.pullrequestid,
+                ut.userid                                                              AS new_userid,
+                row_number() OVER (PARTITION BY p.pullrequestid ORDER BY random()) AS slot
+         FROM (SELECT DISTINCT pullrequestid FROM slots) p
+                  JOIN pull_requests pr ON pr.pullrequestid = p.pullrequestid
+                  JOIN users_team ut ON ut.teamname = $3
+                  JOIN users u ON u.userid = ut.userid AND u.isactive
+         WHERE ut.userid <> COALESCE(pr.authorid, '')
+           AND ut.userid NOT IN (SELECT jsonb_array_elements_text($1::jsonb))
+           AND NOT EXISTS (SELECT 1
+                           FROM users_pull_requests a
+                           WHERE a.pullrequestid = p.pullrequestid
+                             AND a.userid = ut.userid)
+           AND NOT EXISTS (SELECT 1
+                           FROM pull_request_excluded_reviewers x
+                           WHERE x.pullrequestid = p.pullrequestid
+                             AND x.userid = ut.userid)
+     ),
+     plan AS MATERIALIZED (
+         SELECT s.pullrequestid, s.old_userid, c.new_userid
+         FROM slots s
+                  LEFT JOIN candidates c ON c.pullrequestid = s.pullrequestid AND c.slot = s.slot
+     ),
+     removed AS (
+         DELETE FROM users_pull_requests upr
+             USING plan
+             WHERE upr.pullrequestid = plan.pullrequestid
+                 AND upr.userid = plan.old_userid
+                 AND upr.role = 'reviewer'
+             RETURNING upr.pullrequestid
+     ),
+     added AS (
+         INSERT INTO users_pull_requests (pullrequestid, userid, role)
+             SELECT pullrequestid, new_userid, 'reviewer'
+             FROM plan
+             WHERE new_userid IS NOT NULL
+             RETURNING pullrequestid
+     )
+SELECT pullrequestid, old_userid, new_userid
+FROM plan
+ORDER BY pullrequestid, old_userid
+`
+
+type ReplaceTeamReviewersParams struct {
+	UserIds              json.RawMessage `db:"user_ids" json:"user_ids"`
+	TeamPullRequestsOnly bool            `db:"team_pull_requests_only" json:"team_pull_requests_only"`
+	Teamname             string          `db:"teamname" json:"teamname"`
+}
+
+type ReplaceTeamReviewersRow struct {
+	Pullrequestid string         `db:"pullrequestid" json:"pullrequestid"`
+	OldUserid     string         `db:"old_userid" json:"old_userid"`
+	NewUserid     sql.NullString `db:"new_userid" json:"new_userid"`
+}
+
+func (q *Queries) ReplaceTeamReviewers(ctx context.Context, arg ReplaceTeamReviewersParams) ([]ReplaceTeamReviewersRow, error) {
+	rows, err := q.db.QueryContext(ctx, replaceTeamReviewers, arg.UserIds, arg.TeamPullRequestsOnly, arg.Teamname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReplaceTeamReviewersRow
+	for rows.Next() {
+		var i ReplaceTeamReviewersRow
+		if err := rows.Scan(&i.Pullrequestid, &i.OldUserid, &i.NewUserid); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeAPIToken = `-- name: RevokeAPIToken :execrows
+UPDATE api_tokens SET revokedat = now() WHERE tokenid = $1 AND revokedat IS NULL
+`
+
+func (q *Queries) RevokeAPIToken(ctx context.Context, tokenid string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeAPIToken, tokenid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const saveUser = `-- name: SaveUser :exec
 INSERT INTO users (userid, username, isactive)
 VALUES ($1, $2, $3)
@@ -331,16 +1336,17 @@ func (q *Queries) SaveUser(ctx context.Context, arg SaveUserParams) error {
 }
 
 const saveUserTeam = `-- name: SaveUserTeam :exec
-INSERT INTO users_team (teamname, userid) VALUES ($1, $2)
+INSERT INTO users_team (teamname, userid, role) VALUES ($1, $2, $3)
 `
 
 type SaveUserTeamParams struct {
 	Teamname string `db:"teamname" json:"teamname"`
 	Userid   string `db:"userid" json:"userid"`
+	Role     string `db:"role" json:"role"`
 }
 
 func (q *Queries) SaveUserTeam(ctx context.Context, arg SaveUserTeamParams) error {
-	_, err := q.db.ExecContext(ctx, saveUserTeam, arg.Teamname, arg.Userid)
+	_, err := q.db.ExecContext(ctx, saveUserTeam, arg.Teamname, arg.Userid, arg.Role)
 	return err
 }
 
@@ -373,3 +1379,35 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	_, err := q.db.ExecContext(ctx, updateUser, arg.Username, arg.Isactive, arg.Userid)
 	return err
 }
+
+const upsertTeamSettings = `-- name: UpsertTeamSettings :exec
+INSERT INTO team_settings (teamname, minreviewers, maxreviewers, requiredapprovals, reviewslahours, reassignafterhours)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (teamname) DO UPDATE SET minreviewers       = EXCLUDED.minreviewers,
+                                     maxreviewers       = EXCLUDED.maxreviewers,
+                                     requiredapprovals  = EXCLUDED.requiredapprovals,
+                                     reviewslahours     = EXCLUDED.reviewslahours,
+                                     reassignafterhours = EXCLUDED.reassignafterhours,
+                                     updatedat          = now()
+`
+
+type UpsertTeamSettingsParams struct {
+	Teamname           string `db:"teamname" json:"teamname"`
+	Minreviewers       int32  `db:"minreviewers" json:"minreviewers"`
+	Maxreviewers       int32  `db:"maxreviewers" json:"maxreviewers"`
+	Requiredapprovals  int32  `db:"requiredapprovals" json:"requiredapprovals"`
+	Reviewslahours     int32  `db:"reviewslahours" json:"reviewslahours"`
+	Reassignafterhours int32  `db:"reassignafterhours" json:"reassignafterhours"`
+}
+
+func (q *Queries) UpsertTeamSettings(ctx context.Context, arg UpsertTeamSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTeamSettings,
+		arg.Teamname,
+		arg.Minreviewers,
+		arg.Maxreviewers,
+		arg.Requiredapprovals,
+		arg.Reviewslahours,
+		arg.Reassignafterhours,
+	)
+	return err
+}
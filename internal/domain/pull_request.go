@@ -1,15 +1,28 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type RequestStatus string
 
 const (
-	RequestStatusOpen   RequestStatus = "OPEN"
-	RequestStatusMerged RequestStatus = "MERGED"
+	RequestStatusDraft    RequestStatus = "DRAFT"
+	RequestStatusOpen     RequestStatus = "OPEN"
+	RequestStatusMerged   RequestStatus = "MERGED"
+	RequestStatusClosed   RequestStatus = "CLOSED"
+	RequestStatusReopened RequestStatus = "REOPENED"
 )
 
-// PullRequest - сущность с идентификатором, названием, автором, статусом `OPEN|MERGED`и списком назначенных ревьюверов (до 2).
+// AcceptsReviews - PR ждет ревью: открыт или переоткрыт после закрытия. Черновикам и закрытым PR ревьюверы не назначаются.
+func (s RequestStatus) AcceptsReviews() bool {
+	return s == RequestStatusOpen || s == RequestStatusReopened
+}
+
+// PullRequest - сущность с идентификатором, названием, автором, статусом `DRAFT|OPEN|MERGED|CLOSED|REOPENED`
+// и списком назначенных ревьюверов (количество задается настройками команды автора).
 type PullRequest struct {
 	// ID - id реквеста
 	ID string `json:"id" db:"PullRequestID"`
@@ -21,8 +34,274 @@ type PullRequest struct {
 	Status RequestStatus `json:"status" db:"Status"`
 	// AssignedReviewersID - прикрепленные проверяющие
 	AssignedReviewersID []string `json:"assigned_reviewers"`
+	// MinReviewers - минимум ревьюверов по настройкам команды автора на момент создания
+	MinReviewers int `json:"min_reviewers"`
+	// Reviews - последнее решение каждого назначенного ревьювера, если оно есть
+	Reviews []ReviewDecision `json:"reviews"`
 	// CreatedAt - время создания
 	CreatedAt time.Time `json:"created_at"`
 	// MergedAt - время слияние
 	MergedAt time.Time `json:"merged_at"`
 }
+
+// UnderReviewed - ревьюверов меньше минимума, заданного командой автора.
+func (p *PullRequest) UnderReviewed() bool {
+	return len(p.AssignedReviewersID) < p.MinReviewers
+}
+
+// LatestReview - последнее решение ревьювера userID по PR.
+func (p *PullRequest) LatestReview(userID string) (ReviewDecision, bool) {
+	for _, review := range p.Reviews {
+		if review.UserID == userID {
+			return review, true
+		}
+	}
+	return ReviewDecision{}, false
+}
+
+// AwaitingReviewFrom - PR ждет ревью, а ревьювер userID еще не одобрил его и не запросил изменения.
+// Комментарий ревью не завершает.
+func (p *PullRequest) AwaitingReviewFrom(userID string) bool {
+	if !p.Status.AcceptsReviews() {
+		return false
+	}
+	review, ok := p.LatestReview(userID)
+	return !ok || review.State == ReviewStateCommented
+}
+
+// MergeBlockers - невыполненные условия слияния: меньше requiredApprovals одобрений
+// или хотя бы один ревьювер запросил изменения. Пустой список - PR можно сливать.
+func (p *PullRequest) MergeBlockers(requiredApprovals int) []string {
+	approvals := 0
+	changesRequested := make([]string, 0)
+	for _, review := range p.Reviews {
+		switch review.State {
+		case ReviewStateApproved:
+			approvals++
+		case ReviewStateChangesRequested:
+			changesRequested = append(changesRequested, review.UserID)
+		}
+	}
+
+	blockers := make([]string, 0, 2)
+	if approvals < requiredApprovals {
+		blockers = append(blockers, fmt.Sprintf("approvals: %d of %d required", approvals, requiredApprovals))
+	}
+	if len(changesRequested) > 0 {
+		blockers = append(blockers, "changes requested by "+strings.Join(changesRequested, ", "))
+	}
+	return blockers
+}
+
+// ReviewState - решение ревьювера по PR.
+type ReviewState string
+
+const (
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCommented        ReviewState = "COMMENTED"
+)
+
+// Valid - решение из поддерживаемого набора.
+func (s ReviewState) Valid() bool {
+	switch s {
+	case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateCommented:
+		return true
+	}
+	return false
+}
+
+// ReviewDecision - решение ревьювера с комментарием и временем отправки.
+type ReviewDecision struct {
+	// PullRequestID - id пул реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// UserID - id ревьювера
+	UserID string `json:"user_id"`
+	// State - решение
+	State ReviewState `json:"decision"`
+	// Comment - комментарий ревьювера
+	Comment string `json:"comment"`
+	// CreatedAt - время отправки решения
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MergeOverride - запись аудита слияния PR админом в обход невыполненных условий.
+type MergeOverride struct {
+	// PullRequestID - id пул реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// ActorUserID - пользователь админского токена, пусто для токена без пользователя
+	ActorUserID string `json:"actor_user_id"`
+	// ActorTokenID - id токена, которым выполнено слияние
+	ActorTokenID string `json:"actor_token_id"`
+	// Conditions - условия слияния, которые были проигнорированы
+	Conditions []string `json:"conditions"`
+}
+
+// ReviewAssignment - назначение ревьювера на открытый PR, по которому он еще не одобрил PR и не запросил изменения.
+type ReviewAssignment struct {
+	// PullRequestID - id пул реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// AuthorID - id автора, по его команде определяются сроки ответа
+	AuthorID string `json:"author_id"`
+	// ReviewerID - id ревьювера
+	ReviewerID string `json:"reviewer_id"`
+	// AssignedAt - время назначения
+	AssignedAt time.Time `json:"assigned_at"`
+	// NotifiedAt - время напоминания о просроченном ревью, пусто если напоминания не было
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+// ReviewSLAReport - итог одной проверки сроков ревью.
+type ReviewSLAReport struct {
+	// Notified - назначения, по которым ревьюверу отправлено напоминание
+	Notified []ReviewAssignment `json:"notified"`
+	// Reassigned - ревьюверы, замененные после второго срока
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+}
+
+// AssignmentEventType - тип события истории назначений ревьюверов.
+type AssignmentEventType string
+
+const (
+	AssignmentEventAssigned      AssignmentEventType = "ASSIGNED"
+	AssignmentEventUnassigned    AssignmentEventType = "UNASSIGNED"
+	AssignmentEventReassigned    AssignmentEventType = "REASSIGNED"
+	AssignmentEventMerged        AssignmentEventType = "MERGED"
+	AssignmentEventStatusChanged AssignmentEventType = "STATUS_CHANGED"
+)
+
+// Причины событий истории назначений - операции, в ходе которых они произошли.
+const (
+	AssignmentReasonCreate         = "create"
+	AssignmentReasonReady          = "ready"
+	AssignmentReasonReopen         = "reopen"
+	AssignmentReasonClose          = "close"
+	AssignmentReasonMerge          = "merge"
+	AssignmentReasonMergeOverride  = "merge_override"
+	AssignmentReasonReassign       = "reassign"
+	AssignmentReasonReviewSLA      = "review_sla"
+	AssignmentReasonAddReviewer    = "add_reviewer"
+	AssignmentReasonRemoveReviewer = "remove_reviewer"
+	AssignmentReasonDeactivate     = "deactivate"
+	AssignmentReasonLeaveTeam      = "leave_team"
+	AssignmentReasonSyncTeam       = "sync_team"
+	AssignmentReasonDeleteTeam     = "delete_team"
+)
+
+// Способы выбора ревьювера, не связанные со стратегией выбора команды.
+const (
+	// AssignmentStrategyRequested - ревьювера запросил автор PR
+	AssignmentStrategyRequested = "requested"
+	// AssignmentStrategyManual - ревьювера назначил админ или лид
+	AssignmentStrategyManual = "manual"
+)
+
+// AssignmentEvent - запись истории назначений ревьюверов PR. История только дополняется.
+type AssignmentEvent struct {
+	// ID - порядковый номер события
+	ID int64 `json:"id"`
+	// PullRequestID - id пул реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// Type - тип события
+	Type AssignmentEventType `json:"type"`
+	// UserID - ревьювер, которого касается событие, для REASSIGNED - новый; пусто для MERGED и STATUS_CHANGED
+	UserID string `json:"user_id"`
+	// PreviousUserID - замененный ревьювер для REASSIGNED
+	PreviousUserID string `json:"previous_user_id"`
+	// Status - статус PR после события для MERGED и STATUS_CHANGED
+	Status RequestStatus `json:"status"`
+	// ActorUserID - пользователь токена, выполнившего операцию; пусто для админских токенов без пользователя и фоновых задач
+	ActorUserID string `json:"actor_user_id"`
+	// ActorTokenID - id токена, выполнившего операцию; пусто для фоновых задач
+	ActorTokenID string `json:"actor_token_id"`
+	// Reason - операция, в ходе которой произошло событие
+	Reason string `json:"reason"`
+	// Strategy - способ выбора ревьювера для ASSIGNED и REASSIGNED
+	Strategy string `json:"strategy"`
+	// CreatedAt - время события
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Причины, по которым пользователь из команд не попал в кандидаты в ревьюверы.
+const (
+	CandidateExcludedAuthor          = "author"
+	CandidateExcludedInactive        = "inactive"
+	CandidateExcludedAlreadyAssigned = "already_assigned"
+	// CandidateExcludedByAuthor - пользователя исключил автор PR
+	CandidateExcludedByAuthor = "excluded_by_author"
+	// CandidateExcludedOverCapacity - стратегия не выбирает пользователя: вес weighted <= 0
+	CandidateExcludedOverCapacity = "over_capacity"
+)
+
+// CandidateDecision - решение по одному пользователю из команд при выборе ревьюверов.
+type CandidateDecision struct {
+	// UserID - id пользователя
+	UserID string `json:"user_id"`
+	// Eligible - пользователь мог быть назначен: прошел фильтры или запрошен автором
+	Eligible bool `json:"eligible"`
+	// ExcludedReason - причина исключения для неподходящих пользователей
+	ExcludedReason string `json:"excluded_reason,omitempty"`
+	// Requested - ревьювера запросил автор, он назначен без участия стратегии
+	Requested bool `json:"requested,omitempty"`
+	// Selected - пользователь назначен ревьювером
+	Selected bool `json:"selected"`
+	// Score - оценка стратегии: вес для weighted, число OPEN PR на ревью для least_loaded
+	Score *int `json:"score,omitempty"`
+	// Draw - случайный розыгрыш стратегии: позиция после перемешивания для random и least_loaded,
+	// позиция в очереди для round_robin, выпавшее значение для weighted
+	Draw *int `json:"draw,omitempty"`
+}
+
+// AssignmentExplanation - объяснение одного выбора ревьюверов PR: кто был в пуле, кто и почему исключен,
+// какой стратегией и с какими оценками выбраны ревьюверы.
+type AssignmentExplanation struct {
+	// ID - порядковый номер объяснения
+	ID int64 `json:"id"`
+	// PullRequestID - id пул реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// Reason - операция, в ходе которой выбирались ревьюверы
+	Reason string `json:"reason"`
+	// Strategy - стратегия выбора команды
+	Strategy string `json:"strategy"`
+	// TeamName - команда, по которой выбрана стратегия
+	TeamName string `json:"team_name"`
+	// ReplacedUserID - замененный ревьювер при переназначении
+	ReplacedUserID string `json:"replaced_user_id"`
+	// Slots - количество ревьюверов, которое нужно было выбрать стратегии
+	Slots int `json:"slots"`
+	// Selected - назначенные ревьюверы
+	Selected []string `json:"selected"`
+	// Candidates - решения по всем пользователям пула
+	Candidates []CandidateDecision `json:"candidates"`
+	// CreatedAt - время выбора
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AssignmentPreview - предварительный выбор ревьюверов PR автора без сохранения.
+type AssignmentPreview struct {
+	// AuthorID - автор PR
+	AuthorID string `json:"author_id"`
+	// Strategy - стратегия выбора команды
+	Strategy string `json:"strategy"`
+	// TeamName - команда, по которой выбрана стратегия и настройки
+	TeamName string `json:"team_name"`
+	// MinReviewers - минимальное число ревьюверов по настройкам команды
+	MinReviewers int `json:"min_reviewers"`
+	// Slots - количество ревьюверов, которое нужно выбрать стратегии
+	Slots int `json:"slots"`
+	// Proposed - предлагаемые ревьюверы: запрошенные автором, затем выбранные стратегией
+	Proposed []string `json:"proposed"`
+	// Candidates - решения по всем пользователям команд автора
+	Candidates []CandidateDecision `json:"candidates"`
+	// Deterministic - стратегия детерминирована, и создание PR назначит предложенных ревьюверов,
+	// если до него команда не получит других назначений и не изменится ее состав
+	Deterministic bool `json:"deterministic"`
+}
+
+// ReviewerPreferences - пожелания автора к ревьюверам при создании PR.
+type ReviewerPreferences struct {
+	// Requested - user_id ревьюверов, которые занимают слоты первыми
+	Requested []string
+	// Excluded - user_id пользователей, которые не выбираются ревьюверами
+	Excluded []string
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// StatsFilter - фильтр статистики. Пустые поля не ограничивают выборку.
+type StatsFilter struct {
+	// TeamName - учитываются только PR, автор которых состоит в команде
+	TeamName string `json:"team_name"`
+	// From - нижняя граница времени создания PR (включительно)
+	From time.Time `json:"from"`
+	// To - верхняя граница времени создания PR (не включительно)
+	To time.Time `json:"to"`
+}
+
+// UserAssignmentStats - количество назначений пользователя ревьювером.
+type UserAssignmentStats struct {
+	// UserID - id пользователя
+	UserID string `json:"user_id"`
+	// Open - назначения в открытых PR
+	Open int `json:"open"`
+	// Merged - назначения в слитых PR
+	Merged int `json:"merged"`
+}
+
+// PullRequestReviewerStats - количество ревьюверов пул реквеста.
+type PullRequestReviewerStats struct {
+	// PullRequestID - id реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// Status - текущий статус реквеста
+	Status RequestStatus `json:"status"`
+	// Reviewers - количество назначенных ревьюверов
+	Reviewers int `json:"reviewers"`
+}
+
+// TeamStats - итоги по PR, авторы которых состоят в команде.
+type TeamStats struct {
+	// TeamName - название команды
+	TeamName string `json:"team_name"`
+	// OpenPullRequests - количество открытых PR
+	OpenPullRequests int `json:"open_pull_requests"`
+	// MergedPullRequests - количество слитых PR
+	MergedPullRequests int `json:"merged_pull_requests"`
+	// Assignments - количество назначений ревьюверов
+	Assignments int `json:"assignments"`
+}
+
+// MergeTimeStats - время от создания до слияния PR.
+type MergeTimeStats struct {
+	// MergedPullRequests - количество слитых PR в выборке
+	MergedPullRequests int `json:"merged_pull_requests"`
+	// Median - медиана времени от CreatedAt до MergedAt, 0 если слитых PR нет
+	Median time.Duration `json:"median"`
+}
+
+// Stats - статистика назначений ревьюверов.
+type Stats struct {
+	Users        []UserAssignmentStats      `json:"users"`
+	PullRequests []PullRequestReviewerStats `json:"pull_requests"`
+	Teams        []TeamStats                `json:"teams"`
+	MergeTime    MergeTimeStats             `json:"merge_time"`
+}
@@ -1,9 +1,178 @@
 package domain
 
+import "time"
+
+// TeamRole - роль участника внутри команды.
+type TeamRole string
+
+const (
+	// TeamRoleLead - лид: управляет составом и активностью участников, переназначает ревью внутри команды
+	TeamRoleLead TeamRole = "lead"
+	// TeamRoleMember - обычный участник: переназначает только свои ревью
+	TeamRoleMember TeamRole = "member"
+)
+
 // Team - Команда с уникальным именем.
 type Team struct {
 	// Name - название команды
 	Name string `json:"team_name"`
 	// Members - участники команды
 	Members []User `json:"members"`
+	// Roles - роли участников по id, участник без записи - member
+	Roles map[string]TeamRole `json:"roles,omitempty"`
+}
+
+// RoleOf возвращает роль пользователя в команде.
+func (t *Team) RoleOf(userID string) TeamRole {
+	if role, ok := t.Roles[userID]; ok {
+		return role
+	}
+	return TeamRoleMember
+}
+
+// HasMember проверяет, состоит ли пользователь в команде.
+func (t *Team) HasMember(userID string) bool {
+	for _, m := range t.Members {
+		if m.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamSettings - настройки назначения ревьюверов на PR авторов команды.
+type TeamSettings struct {
+	// TeamName - название команды
+	TeamName string `json:"team_name"`
+	// MinReviewers - минимум ревьюверов, PR с меньшим числом помечается как недостаточно проверенный
+	MinReviewers int `json:"min_reviewers"`
+	// MaxReviewers - сколько ревьюверов назначается при создании PR
+	MaxReviewers int `json:"max_reviewers"`
+	// RequiredApprovals - сколько одобрений нужно для слияния PR, 0 - без проверки
+	RequiredApprovals int `json:"required_approvals"`
+	// ReviewSLAHours - сколько рабочих часов у ревьювера на ответ, после этого он получает напоминание; 0 - без срока
+	ReviewSLAHours int `json:"review_sla_hours"`
+	// ReassignAfterHours - через сколько рабочих часов без ответа ревьювер заменяется автоматически; 0 - без замены
+	ReassignAfterHours int `json:"reassign_after_hours"`
+	// UpdatedAt - время последнего изменения, пусто для настроек по умолчанию
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultTeamSettings - настройки команды без сохраненной записи: до двух ревьюверов без обязательного минимума,
+// слияние без одобрений и один рабочий день на ответ ревьювера без автоматической замены.
+func DefaultTeamSettings(teamName string) TeamSettings {
+	return TeamSettings{TeamName: teamName, MinReviewers: 0, MaxReviewers: 2, RequiredApprovals: 0, ReviewSLAHours: DefaultReviewSLAHours}
+}
+
+// DefaultReviewSLAHours - срок ответа ревьювера по умолчанию, один рабочий день.
+const DefaultReviewSLAHours = 24
+
+// ReviewDueAt - срок ответа ревьювера, назначенного в assignedAt. false, если у команды нет срока.
+func (s TeamSettings) ReviewDueAt(assignedAt time.Time) (time.Time, bool) {
+	if s.ReviewSLAHours <= 0 {
+		return time.Time{}, false
+	}
+	return AddBusinessHours(assignedAt, s.ReviewSLAHours), true
+}
+
+// ReassignAt - момент автоматической замены ревьювера, назначенного в assignedAt. false, если замена отключена.
+func (s TeamSettings) ReassignAt(assignedAt time.Time) (time.Time, bool) {
+	if s.ReassignAfterHours <= 0 {
+		return time.Time{}, false
+	}
+	return AddBusinessHours(assignedAt, s.ReassignAfterHours), true
+}
+
+// AddBusinessHours - момент, когда с from пройдет hours рабочих часов. Суббота и воскресенье (UTC) не считаются,
+// назначение в выходной начинает отсчет с понедельника.
+func AddBusinessHours(from time.Time, hours int) time.Time {
+	t := from.UTC()
+	remaining := time.Duration(hours) * time.Hour
+	for remaining > 0 {
+		nextDay := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+		if weekday := t.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			t = nextDay
+			continue
+		}
+		step := min(remaining, nextDay.Sub(t))
+		t = t.Add(step)
+		remaining -= step
+	}
+	return t
+}
+
+// ReviewerReplacement - замена ревьювера открытого PR после деактивации участника.
+type ReviewerReplacement struct {
+	// PullRequestID - id пул реквеста
+	PullRequestID string `json:"pull_request_id"`
+	// OldReviewerID - id деактивированного ревьювера
+	OldReviewerID string `json:"old_reviewer_id"`
+	// NewReviewerID - id нового ревьювера, пусто если кандидата не нашлось и слот освобожден
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+// TeamDeactivation - итог массовой деактивации участников команды.
+type TeamDeactivation struct {
+	// TeamName - название команды
+	TeamName string `json:"team_name"`
+	// DeactivatedUserIDs - id деактивированных участников
+	DeactivatedUserIDs []string `json:"deactivated_user_ids"`
+	// Reassigned - слоты ревьюверов, переданные другим участникам
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	// Unfilled - слоты ревьюверов, оставшиеся без замены
+	Unfilled []ReviewerReplacement `json:"unfilled"`
+}
+
+// TeamMemberChange - итог выхода участника из команды: исключения или перевода в другую команду.
+type TeamMemberChange struct {
+	// UserID - id участника
+	UserID string `json:"user_id"`
+	// FromTeam - команда, из которой вышел участник
+	FromTeam string `json:"from_team"`
+	// ToTeam - команда, в которую переведен участник, пусто при исключении
+	ToTeam string `json:"to_team,omitempty"`
+	// Reassigned - слоты ревьюверов в PR команды FromTeam, переданные другим участникам
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	// Unfilled - слоты ревьюверов, оставшиеся без замены
+	Unfilled []ReviewerReplacement `json:"unfilled"`
+}
+
+// UsernameChange - смена имени пользователя при синхронизации состава команды.
+type UsernameChange struct {
+	// UserID - id пользователя
+	UserID string `json:"user_id"`
+	// From - текущее имя
+	From string `json:"from"`
+	// To - имя из внешнего источника
+	To string `json:"to"`
+}
+
+// ActivityChange - смена флага активности пользователя при синхронизации состава команды.
+type ActivityChange struct {
+	// UserID - id пользователя
+	UserID string `json:"user_id"`
+	// IsActive - новое значение флага
+	IsActive bool `json:"is_active"`
+}
+
+// TeamSync - разница между сохраненным составом команды и составом из внешнего источника.
+type TeamSync struct {
+	// TeamName - название команды
+	TeamName string `json:"team_name"`
+	// DryRun - разница только рассчитана, изменения не применялись
+	DryRun bool `json:"dry_run"`
+	// Created - команды не было, она создается
+	Created bool `json:"created"`
+	// Added - id пользователей, добавляемых в команду
+	Added []string `json:"added"`
+	// Removed - id участников, исключаемых из команды
+	Removed []string `json:"removed"`
+	// Renamed - смены имен пользователей
+	Renamed []UsernameChange `json:"renamed"`
+	// ActivityChanged - смены флага активности
+	ActivityChanged []ActivityChange `json:"activity_changed"`
+	// Reassigned - слоты ревьюверов исключенных и деактивированных участников, переданные другим участникам
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	// Unfilled - слоты ревьюверов, оставшиеся без замены
+	Unfilled []ReviewerReplacement `json:"unfilled"`
 }
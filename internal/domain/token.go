@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// TokenScope - уровень доступа API токена.
+type TokenScope string
+
+const (
+	// TokenScopeAdmin - управление командами, пользователями, токенами и слияние PR
+	TokenScopeAdmin TokenScope = "admin"
+	// TokenScopeUser - просмотр своих ревью и переназначение своих назначений
+	TokenScopeUser TokenScope = "user"
+)
+
+// APIToken - токен доступа к API. Сам токен не хранится, только его хеш.
+type APIToken struct {
+	// ID - id токена, по нему токен отзывается
+	ID string `json:"token_id"`
+	// Name - описание токена, например имя интеграции
+	Name string `json:"name"`
+	// Hash - sha256 от токена в hex
+	Hash string `json:"-"`
+	// Scope - уровень доступа
+	Scope TokenScope `json:"scope"`
+	// UserID - пользователь, от имени которого действует токен со scope user
+	UserID string `json:"user_id"`
+	// CreatedAt - время выпуска
+	CreatedAt time.Time `json:"created_at"`
+	// RevokedAt - время отзыва, нулевое для действующего токена
+	RevokedAt time.Time `json:"revoked_at"`
+}
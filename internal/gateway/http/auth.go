@@ -0,0 +1,92 @@
+package http
+
+import (
+	"avito-test/internal/domain"
+	openapi "avito-test/internal/gen/go/go"
+	"avito-test/internal/usecase"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticator - проверка bearer токена, реализуется usecase.Auth.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*domain.APIToken, error)
+}
+
+// routeScopes - scope, которым доступен маршрут из getRoutes. Маршруты без записи доступны только admin.
+// Для токенов со scope user роль в команде (лид или участник) проверяют usecase-ы.
+var routeScopes = map[string][]domain.TokenScope{
+	"UsersGetReviewGet":             {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"UsersHistoryGet":               {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestHistoryGet":         {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestExplanationGet":     {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestReassignPost":       {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestAddReviewerPost":    {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestRemoveReviewerPost": {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestReviewPost":         {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestReadyPost":          {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestClosePost":          {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"PullRequestReopenPost":         {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"UsersSetIsActivePost":          {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamDeactivateMembersPost":     {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamAddMemberPost":             {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamRemoveMemberPost":          {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamMoveMemberPost":            {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamSyncPut":                   {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamSettingsGet":               {domain.TokenScopeAdmin, domain.TokenScopeUser},
+	"TeamSettingsPut":               {domain.TokenScopeAdmin, domain.TokenScopeUser},
+}
+
+// authMiddleware проверяет заголовок "Authorization: Bearer <token>" и scope токена для маршрута.
+// Без токена или с недействительным токеном - 401, с недостаточным scope - 403.
+func authMiddleware(auth Authenticator) openapi.RouteMiddleware {
+	return func(routeName string) gin.HandlerFunc {
+		allowed, ok := routeScopes[routeName]
+		if !ok {
+			allowed = []domain.TokenScope{domain.TokenScopeAdmin}
+		}
+
+		return func(c *gin.Context) {
+			raw, ok := bearerToken(c.GetHeader("Authorization"))
+			if !ok {
+				openapi.AbortUnauthorized(c, "missing bearer token")
+				return
+			}
+
+			token, err := auth.Authenticate(c.Request.Context(), raw)
+			switch {
+			case errors.Is(err, usecase.ErrInvalidToken):
+				openapi.AbortUnauthorized(c, err.Error())
+				return
+			case err != nil:
+				openapi.AbortInternalError(c, err)
+				return
+			}
+
+			if !slices.Contains(allowed, token.Scope) {
+				openapi.AbortForbidden(c, "token scope "+string(token.Scope)+" is not allowed for this operation")
+				return
+			}
+			c.Set(openapi.PrincipalKey, token)
+			c.Request = c.Request.WithContext(usecase.WithActor(c.Request.Context(), usecase.Actor{
+				UserID:  token.UserID,
+				Admin:   token.Scope == domain.TokenScopeAdmin,
+				TokenID: token.ID,
+			}))
+			c.Next()
+		}
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
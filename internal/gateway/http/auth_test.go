@@ -0,0 +1,110 @@
+package http
+
+import (
+	"avito-test/internal/domain"
+	openapi "avito-test/internal/gen/go/go"
+	"avito-test/internal/usecase"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeAuthenticator map[string]*domain.APIToken
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, token string) (*domain.APIToken, error) {
+	if token == "broken" {
+		return nil, errors.New("db down")
+	}
+	if t, ok := f[token]; ok {
+		return t, nil
+	}
+	return nil, usecase.ErrInvalidToken
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auth := fakeAuthenticator{
+		"admin": {ID: "tok_admin", Scope: domain.TokenScopeAdmin},
+		"alice": {ID: "tok_alice", Scope: domain.TokenScopeUser, UserID: "u1"},
+	}
+
+	r := gin.New()
+	mw := authMiddleware(auth)
+	ok := func(c *gin.Context) {
+		c.String(http.StatusOK, openapi.Principal(c).ID)
+	}
+	r.POST("/team/add", openapi.WithRouteName("TeamAddPost"), mw("TeamAddPost"), ok)
+	r.GET("/users/getReview", openapi.WithRouteName("UsersGetReviewGet"), mw("UsersGetReviewGet"), ok)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "no header", method: http.MethodPost, path: "/team/add", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "not bearer", method: http.MethodPost, path: "/team/add", header: "Basic YWRtaW4=", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "unknown token", method: http.MethodPost, path: "/team/add", header: "Bearer nope", wantStatus: http.StatusUnauthorized, wantCode: "UNAUTHORIZED"},
+		{name: "user on admin route", method: http.MethodPost, path: "/team/add", header: "Bearer alice", wantStatus: http.StatusForbidden, wantCode: "FORBIDDEN"},
+		{name: "admin on admin route", method: http.MethodPost, path: "/team/add", header: "Bearer admin", wantStatus: http.StatusOK},
+		{name: "user on user route", method: http.MethodGet, path: "/users/getReview?user_id=u1", header: "bearer alice", wantStatus: http.StatusOK},
+		{name: "authenticator error", method: http.MethodGet, path: "/users/getReview", header: "Bearer broken", wantStatus: http.StatusInternalServerError, wantCode: "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var body struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not errorResponse: %s", rec.Body)
+			}
+			if body.Error.Code != tt.wantCode {
+				t.Fatalf("error code = %s, want %s", body.Error.Code, tt.wantCode)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Fatalf("expected WWW-Authenticate header on 401")
+			}
+		})
+	}
+}
+
+func TestServer_HealthAndMetricsWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := NewServer(UseCases{})
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/health/live status = %d, want 200", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=a", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("/team/get without token status = %d, want 401", rec.Code)
+	}
+}
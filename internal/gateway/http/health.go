@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// HealthDB - зависимости проверки готовности, *sql.DB удовлетворяет интерфейсу.
+type HealthDB interface {
+	PingContext(ctx context.Context) error
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	Stats() sql.DBStats
+}
+
+// health обслуживает /health/live и /health/ready. ready выставляется в Server.Run
+// и сбрасывается в начале остановки, чтобы оркестратор перестал слать трафик.
+type health struct {
+	db    HealthDB
+	ready atomic.Bool
+}
+
+type poolStatsResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+}
+
+type migrationResponse struct {
+	Version int64 `json:"version"`
+	Dirty   bool  `json:"dirty"`
+}
+
+type readinessResponse struct {
+	Status    string             `json:"status"`
+	Error     string             `json:"error,omitempty"`
+	Migration *migrationResponse `json:"migration,omitempty"`
+	Pool      *poolStatsResponse `json:"pool,omitempty"`
+}
+
+func (h *health) live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *health) readiness(c *gin.Context) {
+	if !h.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, readinessResponse{Status: "shutting_down"})
+		return
+	}
+	if h.db == nil {
+		c.JSON(http.StatusServiceUnavailable, readinessResponse{Status: "unavailable", Error: "database is not configured"})
+		return
+	}
+
+	stats := h.db.Stats()
+	resp := readinessResponse{
+		Status: "ok",
+		Pool: &poolStatsResponse{
+			MaxOpenConnections: stats.MaxOpenConnections,
+			OpenConnections:    stats.OpenConnections,
+			InUse:              stats.InUse,
+			Idle:               stats.Idle,
+			WaitCount:          stats.WaitCount,
+			WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		resp.Status, resp.Error = "unavailable", "database ping failed: "+err.Error()
+		c.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	// таблица ведется golang-migrate, поэтому запрос не генерируется sqlc
+	var migration migrationResponse
+	err := h.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").
+		Scan(&migration.Version, &migration.Dirty)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		resp.Status, resp.Error = "unavailable", "migrations are not applied"
+	case err != nil:
+		resp.Status, resp.Error = "unavailable", "can't read migration version: "+err.Error()
+	case migration.Dirty:
+		resp.Migration = &migration
+		resp.Status, resp.Error = "unavailable", "migration is dirty"
+	default:
+		resp.Migration = &migration
+	}
+
+	if resp.Status != "ok" {
+		c.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
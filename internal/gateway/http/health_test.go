@@ -0,0 +1,125 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func newHealthRouter(h *health) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/health/live", h.live)
+	r.GET("/health/ready", h.readiness)
+	return r
+}
+
+func TestHealth_Live(t *testing.T) {
+	r := newHealthRouter(&health{})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestHealth_Ready(t *testing.T) {
+	migrationQuery := regexp.QuoteMeta("SELECT version, dirty FROM schema_migrations")
+
+	tests := []struct {
+		name       string
+		ready      bool
+		mock       func(sqlmock.Sqlmock)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "ready",
+			ready: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectPing()
+				m.ExpectQuery(migrationQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(int64(3), false))
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+		{
+			name:       "shutting down",
+			ready:      false,
+			mock:       func(sqlmock.Sqlmock) {},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "shutting_down",
+		},
+		{
+			name:  "ping failed",
+			ready: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectPing().WillReturnError(errors.New("connection refused"))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "unavailable",
+		},
+		{
+			name:  "dirty migration",
+			ready: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectPing()
+				m.ExpectQuery(migrationQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(int64(3), true))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "unavailable",
+		},
+		{
+			name:  "migrations not applied",
+			ready: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectPing()
+				m.ExpectQuery(migrationQuery).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}))
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			if err != nil {
+				t.Fatalf("sqlmock.New(): %v", err)
+			}
+			defer conn.Close()
+			tt.mock(mock)
+
+			h := &health{db: conn}
+			h.ready.Store(tt.ready)
+
+			rec := httptest.NewRecorder()
+			newHealthRouter(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			var body readinessResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("can't decode body: %v", err)
+			}
+			if body.Status != tt.wantBody {
+				t.Fatalf("expected status %q, got %q", tt.wantBody, body.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	openapi "avito-test/internal/gen/go/go"
+	"avito-test/internal/logger"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength - более длинный входящий id заменяется сгенерированным
+	maxRequestIDLength = 128
+)
+
+// requestIDMiddleware берет X-Request-ID из запроса или генерирует новый,
+// кладет его в context.Context запроса и возвращает в ответе.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), id))
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// accessLogMiddleware пишет по записи на запрос вместо текстового логгера gin.
+func accessLogMiddleware(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		log.LogAttrs(c.Request.Context(), level, "http request",
+			slog.String("route", c.GetString(openapi.RouteNameKey)),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+// recoveryMiddleware логирует панику через slog и отвечает 500.
+func recoveryMiddleware(log *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		log.ErrorContext(c.Request.Context(), "panic recovered", slog.Any("panic", recovered))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"avito-test/internal/logger"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "keeps incoming id", incoming: "req-123", keep: true},
+		{name: "generates when missing", incoming: "", keep: false},
+		{name: "replaces invalid id", incoming: "bad id\n", keep: false},
+		{name: "replaces too long id", incoming: strings.Repeat("a", maxRequestIDLength+1), keep: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(requestIDMiddleware())
+
+			var fromCtx string
+			r.GET("/", func(c *gin.Context) {
+				fromCtx = logger.RequestID(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(requestIDHeader)
+			if got == "" || got != fromCtx {
+				t.Fatalf("response id %q must match context id %q", got, fromCtx)
+			}
+			if tt.keep != (got == tt.incoming) {
+				t.Fatalf("incoming id %q, got %q, keep=%v", tt.incoming, got, tt.keep)
+			}
+		})
+	}
+}
+
+func TestAccessLogAndRecovery_IncludeRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := logger.New(&buf, "info")
+	if err != nil {
+		t.Fatalf("logger.New(): %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(requestIDMiddleware(), accessLogMiddleware(log), recoveryMiddleware(log))
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(requestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected panic and access log records, got %q", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"request_id":"req-42"`) {
+			t.Fatalf("log record without request id: %s", line)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	openapi "avito-test/internal/gen/go/go"
+	"avito-test/internal/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute - метка для запросов, не попавших ни в один маршрут.
+const unmatchedRoute = "unmatched"
+
+// metricsMiddleware учитывает длительность и код ответа по имени маршрута из getRoutes.
+// Имя маршрута, а не путь, держит кардинальность меток ограниченной.
+func metricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.GetString(openapi.RouteNameKey)
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.ObserveHTTP(route, c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
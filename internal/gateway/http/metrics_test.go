@@ -0,0 +1,42 @@
+package http
+
+import (
+	openapi "avito-test/internal/gen/go/go"
+	"avito-test/internal/metrics"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetricsMiddleware_LabelsByRouteName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+
+	r := gin.New()
+	r.Use(metricsMiddleware(m))
+	r.GET("/team/get", openapi.WithRouteName("TeamGetGet"), func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+	r.GET("/metrics", gin.WrapH(m.Handler()))
+
+	for _, path := range []string{"/team/get?team_name=a", "/team/get?team_name=b", "/missing"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		`pr_reviewer_http_requests_total{method="GET",route="TeamGetGet",status="404"} 2`,
+		`pr_reviewer_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("metrics output does not contain %q:\n%s", want, body)
+		}
+	}
+}
@@ -22,10 +22,32 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 		{"PullRequestCreatePost", http.MethodPost, "/pullRequest/create", handleFunctions.PullRequestsAPI.PullRequestCreatePost},
 		{"PullRequestMergePost", http.MethodPost, "/pullRequest/merge", handleFunctions.PullRequestsAPI.PullRequestMergePost},
 		{"PullRequestReassignPost", http.MethodPost, "/pullRequest/reassign", handleFunctions.PullRequestsAPI.PullRequestReassignPost},
+		{"PullRequestAddReviewerPost", http.MethodPost, "/pullRequest/addReviewer", handleFunctions.PullRequestsAPI.PullRequestAddReviewerPost},
+		{"PullRequestRemoveReviewerPost", http.MethodPost, "/pullRequest/removeReviewer", handleFunctions.PullRequestsAPI.PullRequestRemoveReviewerPost},
+		{"PullRequestReviewPost", http.MethodPost, "/pullRequest/review", handleFunctions.PullRequestsAPI.PullRequestReviewPost},
+		{"PullRequestReadyPost", http.MethodPost, "/pullRequest/ready", handleFunctions.PullRequestsAPI.PullRequestReadyPost},
+		{"PullRequestClosePost", http.MethodPost, "/pullRequest/close", handleFunctions.PullRequestsAPI.PullRequestClosePost},
+		{"PullRequestReopenPost", http.MethodPost, "/pullRequest/reopen", handleFunctions.PullRequestsAPI.PullRequestReopenPost},
+		{"PullRequestHistoryGet", http.MethodGet, "/pullRequest/history", handleFunctions.PullRequestsAPI.PullRequestHistoryGet},
+		{"PullRequestExplanationGet", http.MethodGet, "/pullRequest/explanation", handleFunctions.PullRequestsAPI.PullRequestExplanationGet},
+		{"PullRequestPreviewAssignmentPost", http.MethodPost, "/pullRequest/previewAssignment", handleFunctions.PullRequestsAPI.PullRequestPreviewAssignmentPost},
 		{"TeamAddPost", http.MethodPost, "/team/add", handleFunctions.TeamsAPI.TeamAddPost},
 		{"TeamGetGet", http.MethodGet, "/team/get", handleFunctions.TeamsAPI.TeamGetGet},
+		{"TeamDeactivateMembersPost", http.MethodPost, "/team/deactivateMembers", handleFunctions.TeamsAPI.TeamDeactivateMembersPost},
+		{"TeamAddMemberPost", http.MethodPost, "/team/addMember", handleFunctions.TeamsAPI.TeamAddMemberPost},
+		{"TeamRemoveMemberPost", http.MethodPost, "/team/removeMember", handleFunctions.TeamsAPI.TeamRemoveMemberPost},
+		{"TeamMoveMemberPost", http.MethodPost, "/team/moveMember", handleFunctions.TeamsAPI.TeamMoveMemberPost},
+		{"TeamDelete", http.MethodDelete, "/team", handleFunctions.TeamsAPI.TeamDelete},
+		{"TeamSyncPut", http.MethodPut, "/team/sync", handleFunctions.TeamsAPI.TeamSyncPut},
+		{"TeamSettingsGet", http.MethodGet, "/team/settings", handleFunctions.TeamsAPI.TeamSettingsGet},
+		{"TeamSettingsPut", http.MethodPut, "/team/settings", handleFunctions.TeamsAPI.TeamSettingsPut},
 		{"UsersGetReviewGet", http.MethodGet, "/users/getReview", handleFunctions.UsersAPI.UsersGetReviewGet},
 		{"UsersSetIsActivePost", http.MethodPost, "/users/setIsActive", handleFunctions.UsersAPI.UsersSetIsActivePost},
+		{"UsersHistoryGet", http.MethodGet, "/users/history", handleFunctions.UsersAPI.UsersHistoryGet},
+		{"StatsGet", http.MethodGet, "/stats", handleFunctions.StatsAPI.StatsGet},
+		{"TokensCreatePost", http.MethodPost, "/tokens/create", handleFunctions.TokensAPI.TokensCreatePost},
+		{"TokensListGet", http.MethodGet, "/tokens/list", handleFunctions.TokensAPI.TokensListGet},
+		{"TokensRevokePost", http.MethodPost, "/tokens/revoke", handleFunctions.TokensAPI.TokensRevokePost},
 	}
 }
 
@@ -39,6 +61,10 @@ func NewRouterWithGinEngine(router *gin.Engine, handleFunctions ApiHandleFunctio
 			router.GET(route.Pattern, route.HandlerFunc)
 		case http.MethodPost:
 			router.POST(route.Pattern, route.HandlerFunc)
+		case http.MethodPut:
+			router.PUT(route.Pattern, route.HandlerFunc)
+		case http.MethodDelete:
+			router.DELETE(route.Pattern, route.HandlerFunc)
 			// ...
 		}
 	}
@@ -49,4 +75,6 @@ type ApiHandleFunctions struct {
 	PullRequestsAPI handlers.PullRequestsAPI
 	TeamsAPI        handlers.TeamsAPI
 	UsersAPI        handlers.UsersAPI
+	StatsAPI        handlers.StatsAPI
+	TokensAPI       handlers.TokensAPI
 }
@@ -2,10 +2,12 @@ package http
 
 import (
 	openapi "avito-test/internal/gen/go/go"
+	"avito-test/internal/metrics"
 	"avito-test/internal/usecase"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -15,27 +17,33 @@ import (
 )
 
 type Server struct {
-	host   string
-	port   uint16
-	router *gin.Engine
+	host       string
+	port       uint16
+	router     *gin.Engine
+	health     *health
+	metrics    *metrics.Metrics
+	logger     *slog.Logger
+	drainDelay time.Duration
 }
 
 type UseCases struct {
 	User        usecase.User
 	Team        usecase.Team
 	PullRequest usecase.PullRequest
+	Stats       usecase.Stats
+	Auth        usecase.Auth
 }
 
 func NewServer(useCases UseCases, options ...func(*Server)) *Server {
-	r := gin.Default()
+	r := gin.New()
 
-	setupRouter(r, useCases)
-
-	s := &Server{router: r, host: "localhost", port: 8080}
+	s := &Server{router: r, host: "localhost", port: 8080, health: &health{}, logger: slog.Default()}
 	for _, o := range options {
 		o(s)
 	}
 
+	setupRouter(r, useCases, s)
+
 	return s
 }
 
@@ -51,6 +59,35 @@ func WithPort(port uint16) func(*Server) {
 	}
 }
 
+// WithHealthDB - база, которую пингует /health/ready.
+func WithHealthDB(db HealthDB) func(*Server) {
+	return func(s *Server) {
+		s.health.db = db
+	}
+}
+
+// WithMetrics - метрики HTTP запросов и эндпоинт /metrics.
+func WithMetrics(m *metrics.Metrics) func(*Server) {
+	return func(s *Server) {
+		s.metrics = m
+	}
+}
+
+// WithLogger - логгер запросов, по умолчанию slog.Default().
+func WithLogger(l *slog.Logger) func(*Server) {
+	return func(s *Server) {
+		s.logger = l
+	}
+}
+
+// WithDrainDelay - пауза между переходом в not ready и остановкой сервера,
+// за которую оркестратор успевает снять инстанс с балансировки.
+func WithDrainDelay(d time.Duration) func(*Server) {
+	return func(s *Server) {
+		s.drainDelay = d
+	}
+}
+
 func (s *Server) Run(ctx context.Context) error {
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", s.host, s.port),
@@ -59,6 +96,8 @@ func (s *Server) Run(ctx context.Context) error {
 
 	eg, ctx := errgroup.WithContext(ctx)
 
+	s.health.ready.Store(true)
+
 	eg.Go(func() error {
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			return err
@@ -68,6 +107,11 @@ func (s *Server) Run(ctx context.Context) error {
 
 	eg.Go(func() error {
 		<-ctx.Done()
+		s.health.ready.Store(false)
+		if s.drainDelay > 0 {
+			time.Sleep(s.drainDelay)
+		}
+
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
 		return srv.Shutdown(shutdownCtx)
@@ -76,12 +120,30 @@ func (s *Server) Run(ctx context.Context) error {
 	return eg.Wait()
 }
 
-func setupRouter(r *gin.Engine, uc UseCases) {
+func setupRouter(r *gin.Engine, uc UseCases, s *Server) {
+	// трассировка первой, чтобы trace_id был в контексте access лога
+	r.Use(tracingMiddleware(), requestIDMiddleware(), accessLogMiddleware(s.logger))
+	if s.metrics != nil {
+		r.Use(metricsMiddleware(s.metrics))
+	}
+	// recovery последним, чтобы запросы с паникой тоже попали в лог и метрики как 500
+	r.Use(recoveryMiddleware(s.logger))
+
+	if s.metrics != nil {
+		r.GET("/metrics", openapi.WithRouteName("Metrics"), gin.WrapH(s.metrics.Handler()))
+	}
+
+	r.GET("/health/live", openapi.WithRouteName("HealthLive"), s.health.live)
+	r.GET("/health/ready", openapi.WithRouteName("HealthReady"), s.health.readiness)
+
 	handlers := openapi.ApiHandleFunctions{
 		PullRequestsAPI: openapi.NewPullRequestsAPI(uc.PullRequest),
 		TeamsAPI:        openapi.NewTeamsAPI(uc.Team),
 		UsersAPI:        openapi.NewUsersAPI(uc.User),
+		StatsAPI:        openapi.NewStatsAPI(uc.Stats),
+		TokensAPI:       openapi.NewTokensAPI(uc.Auth),
 	}
 
-	openapi.NewRouterWithGinEngine(r, handlers)
+	// /metrics и /health/* остаются без аутентификации для Prometheus и оркестратора
+	openapi.NewRouterWithGinEngine(r, handlers, authMiddleware(&uc.Auth))
 }
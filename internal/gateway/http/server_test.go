@@ -0,0 +1,34 @@
+package http
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestServer_Run_ReadinessFollowsLifecycle(t *testing.T) {
+	s := NewServer(UseCases{}, WithPort(0))
+	if s.health.ready.Load() {
+		t.Fatalf("server must not be ready before Run")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for !s.health.ready.Load() {
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not become ready")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if s.health.ready.Load() {
+		t.Fatalf("server must not be ready after shutdown began")
+	}
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	openapi "avito-test/internal/gen/go/go"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const httpInstrumentationName = "avito-test/internal/gateway/http"
+
+// tracingMiddleware продолжает трейс из W3C заголовков traceparent/tracestate
+// и открывает серверный спан на запрос. Спан называется по имени маршрута из getRoutes,
+// которое становится известно только после выполнения цепочки.
+func tracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer(httpInstrumentationName)
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracer.Start(ctx, fmt.Sprintf("HTTP %s", c.Request.Method),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.URLPath(c.Request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		route := c.GetString(openapi.RouteNameKey)
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := c.Writer.Status()
+		span.SetName(route)
+		span.SetAttributes(semconv.HTTPRoute(c.FullPath()), semconv.HTTPResponseStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+		}
+	}
+}
@@ -1,21 +1,39 @@
 package openapi
 
 import (
+	"avito-test/internal/domain"
+	"log/slog"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 )
 
 // коды ошибок из openapi.yaml
 const (
-	errCodeTeamExists  = "TEAM_EXISTS"
-	errCodePRExists    = "PR_EXISTS"
-	errCodePRMerged    = "PR_MERGED"
-	errCodeNotAssigned = "NOT_ASSIGNED"
-	errCodeNoCandidate = "NO_CANDIDATE"
-	errCodeNotFound    = "NOT_FOUND"
-	errCodeInternal    = "INTERNAL_ERROR"
-	errCodeBadRequest  = "BAD_REQUEST"
+	errCodeTeamExists        = "TEAM_EXISTS"
+	errCodePRExists          = "PR_EXISTS"
+	errCodePRMerged          = "PR_MERGED"
+	errCodeNotAssigned       = "NOT_ASSIGNED"
+	errCodeNoCandidate       = "NO_CANDIDATE"
+	errCodeNotFound          = "NOT_FOUND"
+	errCodeInternal          = "INTERNAL_ERROR"
+	errCodeBadRequest        = "BAD_REQUEST"
+	errCodeUnauthorized      = "UNAUTHORIZED"
+	errCodeForbidden         = "FORBIDDEN"
+	errCodeMemberExists      = "MEMBER_EXISTS"
+	errCodeReviewerNotFound  = "REVIEWER_NOT_FOUND"
+	errCodeReviewerInactive  = "REVIEWER_INACTIVE"
+	errCodeReviewerNotInTeam = "REVIEWER_NOT_IN_TEAM"
+	errCodeAlreadyAssigned   = "ALREADY_ASSIGNED"
+	errCodeReviewerLimit     = "REVIEWER_LIMIT"
+	errCodeMergeBlocked      = "MERGE_BLOCKED"
+	errCodeInvalidTransition = "INVALID_TRANSITION"
+	errCodePRNotOpen         = "PR_NOT_OPEN"
 )
 
+// PrincipalKey - ключ gin.Context, под которым middleware аутентификации кладет *domain.APIToken.
+const PrincipalKey = "openapi.principal"
+
 // error.response
 type errorBody struct {
 	Code    string `json:"code"`
@@ -34,3 +52,53 @@ func writeError(c *gin.Context, status int, code, msg string) {
 		},
 	})
 }
+
+// writeInternalError логирует ошибку с request_id из контекста запроса и отвечает 500.
+// Текст ошибки может содержать детали БД, поэтому клиенту он не возвращается.
+func writeInternalError(c *gin.Context, err error) {
+	slog.ErrorContext(c.Request.Context(), "request failed",
+		slog.String("route", c.GetString(RouteNameKey)),
+		slog.Any("error", err),
+	)
+	writeError(c, http.StatusInternalServerError, errCodeInternal, http.StatusText(http.StatusInternalServerError))
+}
+
+// AbortUnauthorized отвечает 401 и прерывает цепочку обработчиков.
+func AbortUnauthorized(c *gin.Context, msg string) {
+	c.Header("WWW-Authenticate", `Bearer realm="pr-reviewer"`)
+	writeError(c, http.StatusUnauthorized, errCodeUnauthorized, msg)
+	c.Abort()
+}
+
+// AbortForbidden отвечает 403 и прерывает цепочку обработчиков.
+func AbortForbidden(c *gin.Context, msg string) {
+	writeError(c, http.StatusForbidden, errCodeForbidden, msg)
+	c.Abort()
+}
+
+// AbortInternalError - writeInternalError для middleware вне пакета.
+func AbortInternalError(c *gin.Context, err error) {
+	writeInternalError(c, err)
+	c.Abort()
+}
+
+// Principal возвращает токен, которым аутентифицирован запрос, или nil.
+func Principal(c *gin.Context) *domain.APIToken {
+	token, _ := c.Value(PrincipalKey).(*domain.APIToken)
+	return token
+}
+
+// authorizeUser проверяет, что запрос может действовать от имени userID:
+// админ - от имени любого пользователя, токен со scope user - только от имени своего.
+func authorizeUser(c *gin.Context, userID string) bool {
+	token := Principal(c)
+	if token == nil {
+		AbortUnauthorized(c, "authentication required")
+		return false
+	}
+	if token.Scope != domain.TokenScopeAdmin && token.UserID != userID {
+		AbortForbidden(c, "token can act only on behalf of "+token.UserID)
+		return false
+	}
+	return true
+}
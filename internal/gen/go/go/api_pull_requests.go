@@ -18,6 +18,7 @@ import (
 	"avito-test/internal/usecase"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,26 +37,185 @@ type pullRequestResponse struct {
 	AuthorID          string   `json:"author_id"`
 	Status            string   `json:"status"`
 	AssignedReviewers []string `json:"assigned_reviewers"`
+	MinReviewers      int      `json:"min_reviewers"`
+	UnderReviewed     bool     `json:"under_reviewed"`
+	// Reviews - последнее решение каждого назначенного ревьювера
+	Reviews   []reviewDecisionResponse `json:"reviews"`
+	CreatedAt *time.Time               `json:"createdAt"`
+	MergedAt  *time.Time               `json:"mergedAt"`
+}
+
+type reviewDecisionResponse struct {
+	UserID    string    `json:"user_id"`
+	Decision  string    `json:"decision"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type assignmentEventResponse struct {
+	EventID        int64     `json:"event_id"`
+	PullRequestID  string    `json:"pull_request_id"`
+	Type           string    `json:"type"`
+	UserID         string    `json:"user_id"`
+	PreviousUserID string    `json:"previous_user_id"`
+	Status         string    `json:"status"`
+	ActorUserID    string    `json:"actor_user_id"`
+	ActorTokenID   string    `json:"actor_token_id"`
+	Reason         string    `json:"reason"`
+	Strategy       string    `json:"strategy"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func mapAssignmentEventsToResponse(events []domain.AssignmentEvent) []assignmentEventResponse {
+	resp := make([]assignmentEventResponse, 0, len(events))
+	for _, event := range events {
+		resp = append(resp, assignmentEventResponse{
+			EventID:        event.ID,
+			PullRequestID:  event.PullRequestID,
+			Type:           string(event.Type),
+			UserID:         event.UserID,
+			PreviousUserID: event.PreviousUserID,
+			Status:         string(event.Status),
+			ActorUserID:    event.ActorUserID,
+			ActorTokenID:   event.ActorTokenID,
+			Reason:         event.Reason,
+			Strategy:       event.Strategy,
+			CreatedAt:      event.CreatedAt,
+		})
+	}
+	return resp
+}
+
+type candidateDecisionResponse struct {
+	UserID         string `json:"user_id"`
+	Eligible       bool   `json:"eligible"`
+	ExcludedReason string `json:"excluded_reason,omitempty"`
+	Requested      bool   `json:"requested"`
+	Selected       bool   `json:"selected"`
+	Score          *int   `json:"score,omitempty"`
+	Draw           *int   `json:"draw,omitempty"`
+}
+
+type assignmentExplanationResponse struct {
+	ExplanationID  int64                       `json:"explanation_id"`
+	PullRequestID  string                      `json:"pull_request_id"`
+	Reason         string                      `json:"reason"`
+	Strategy       string                      `json:"strategy"`
+	TeamName       string                      `json:"team_name"`
+	ReplacedUserID string                      `json:"replaced_user_id,omitempty"`
+	Slots          int                         `json:"slots"`
+	Selected       []string                    `json:"selected"`
+	Candidates     []candidateDecisionResponse `json:"candidates"`
+	CreatedAt      time.Time                   `json:"created_at"`
+}
+
+func mapCandidateDecisionsToResponse(decisions []domain.CandidateDecision) []candidateDecisionResponse {
+	resp := make([]candidateDecisionResponse, 0, len(decisions))
+	for _, decision := range decisions {
+		resp = append(resp, candidateDecisionResponse{
+			UserID:         decision.UserID,
+			Eligible:       decision.Eligible,
+			ExcludedReason: decision.ExcludedReason,
+			Requested:      decision.Requested,
+			Selected:       decision.Selected,
+			Score:          decision.Score,
+			Draw:           decision.Draw,
+		})
+	}
+	return resp
+}
+
+func mapAssignmentExplanationsToResponse(explanations []domain.AssignmentExplanation) []assignmentExplanationResponse {
+	resp := make([]assignmentExplanationResponse, 0, len(explanations))
+	for _, explanation := range explanations {
+		selected := explanation.Selected
+		if selected == nil {
+			selected = []string{}
+		}
+		resp = append(resp, assignmentExplanationResponse{
+			ExplanationID:  explanation.ID,
+			PullRequestID:  explanation.PullRequestID,
+			Reason:         explanation.Reason,
+			Strategy:       explanation.Strategy,
+			TeamName:       explanation.TeamName,
+			ReplacedUserID: explanation.ReplacedUserID,
+			Slots:          explanation.Slots,
+			Selected:       selected,
+			Candidates:     mapCandidateDecisionsToResponse(explanation.Candidates),
+			CreatedAt:      explanation.CreatedAt,
+		})
+	}
+	return resp
+}
+
+type assignmentPreviewResponse struct {
+	AuthorID      string                      `json:"author_id"`
+	Strategy      string                      `json:"strategy"`
+	TeamName      string                      `json:"team_name"`
+	MinReviewers  int                         `json:"min_reviewers"`
+	Slots         int                         `json:"slots"`
+	Proposed      []string                    `json:"proposed_reviewers"`
+	Candidates    []candidateDecisionResponse `json:"candidates"`
+	Deterministic bool                        `json:"deterministic"`
+}
+
+func mapAssignmentPreviewToResponse(preview *domain.AssignmentPreview) assignmentPreviewResponse {
+	return assignmentPreviewResponse{
+		AuthorID:      preview.AuthorID,
+		Strategy:      preview.Strategy,
+		TeamName:      preview.TeamName,
+		MinReviewers:  preview.MinReviewers,
+		Slots:         preview.Slots,
+		Proposed:      preview.Proposed,
+		Candidates:    mapCandidateDecisionsToResponse(preview.Candidates),
+		Deterministic: preview.Deterministic,
+	}
 }
 
 func mapPullRequestToResponse(pr *domain.PullRequest) pullRequestResponse {
-	return pullRequestResponse{
+	resp := pullRequestResponse{
 		PullRequestID:     pr.ID,
 		PullRequestName:   pr.Name,
 		AuthorID:          pr.AuthorID,
 		Status:            string(pr.Status),
 		AssignedReviewers: pr.AssignedReviewersID,
+		MinReviewers:      pr.MinReviewers,
+		UnderReviewed:     pr.UnderReviewed(),
+		Reviews:           make([]reviewDecisionResponse, 0, len(pr.Reviews)),
+	}
+	for _, review := range pr.Reviews {
+		resp.Reviews = append(resp.Reviews, reviewDecisionResponse{
+			UserID:    review.UserID,
+			Decision:  string(review.State),
+			Comment:   review.Comment,
+			CreatedAt: review.CreatedAt,
+		})
 	}
+	if resp.AssignedReviewers == nil {
+		resp.AssignedReviewers = []string{}
+	}
+	if !pr.CreatedAt.IsZero() {
+		resp.CreatedAt = &pr.CreatedAt
+	}
+	if !pr.MergedAt.IsZero() {
+		resp.MergedAt = &pr.MergedAt
+	}
+	return resp
 }
 
 // POST /pullRequest/create
-// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// Создать PR и назначить ревьюверов из команды автора: сначала запрошенных, остальных автоматически
 
 func (api *PullRequestsAPI) PullRequestCreatePost(c *gin.Context) {
 	var body struct {
 		PullRequestID   string `json:"pull_request_id" binding:"required"`
 		PullRequestName string `json:"pull_request_name" binding:"required"`
 		AuthorID        string `json:"author_id" binding:"required"`
+		// необязательные пожелания автора к ревьюверам
+		RequestedReviewers []string `json:"requested_reviewers"`
+		ExcludedReviewers  []string `json:"excluded_reviewers"`
+		// Draft - создать черновик без ревьюверов
+		Draft bool `json:"draft"`
 	}
 	if err := c.ShouldBindJSON(&body); err != nil {
 		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
@@ -66,11 +226,35 @@ func (api *PullRequestsAPI) PullRequestCreatePost(c *gin.Context) {
 		ID:       body.PullRequestID,
 		Name:     body.PullRequestName,
 		AuthorID: body.AuthorID,
+		Status:   domain.RequestStatusOpen,
+	}
+	if body.Draft {
+		pr.Status = domain.RequestStatusDraft
+	}
+	preferences := domain.ReviewerPreferences{
+		Requested: body.RequestedReviewers,
+		Excluded:  body.ExcludedReviewers,
 	}
 
-	created, err := api.prUC.CreatePullRequest(c.Request.Context(), pr)
+	created, err := api.prUC.CreatePullRequest(c.Request.Context(), pr, preferences)
 
 	switch {
+	case errors.Is(err, usecase.ErrInvalidReviewerRequest):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerNotFound):
+		writeError(c, http.StatusNotFound, errCodeReviewerNotFound, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerInactive):
+		writeError(c, http.StatusBadRequest, errCodeReviewerInactive, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerNotInTeam):
+		writeError(c, http.StatusBadRequest, errCodeReviewerNotInTeam, err.Error())
+		return
+
 	case errors.Is(err, usecase.ErrAuthorNotFound),
 		errors.Is(err, usecase.ErrTeamNotFound):
 		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
@@ -81,7 +265,7 @@ func (api *PullRequestsAPI) PullRequestCreatePost(c *gin.Context) {
 		return
 
 	case err != nil:
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 
@@ -95,11 +279,13 @@ func (api *PullRequestsAPI) PullRequestCreatePost(c *gin.Context) {
 }
 
 // POST /pullRequest/merge
-// Пометить PR как MERGED (идемпотентная операция)
+// Пометить PR как MERGED (идемпотентная операция), если выполнены условия слияния команды автора
 
 func (api *PullRequestsAPI) PullRequestMergePost(c *gin.Context) {
 	var body struct {
 		PullRequestID string `json:"pull_request_id" binding:"required"`
+		// Override - слить в обход required_approvals и запрошенных изменений, только для админа
+		Override bool `json:"override"`
 	}
 	if err := c.ShouldBindJSON(&body); err != nil {
 		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
@@ -107,15 +293,24 @@ func (api *PullRequestsAPI) PullRequestMergePost(c *gin.Context) {
 	}
 
 	// Act
-	pr, err := api.prUC.MergePullRequest(c.Request.Context(), body.PullRequestID)
+	pr, err := api.prUC.MergePullRequest(c.Request.Context(), body.PullRequestID, body.Override)
 
 	// Assert
 	switch {
 	case errors.Is(err, usecase.ErrPullRequestNotFound):
 		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
 		return
+	case errors.Is(err, usecase.ErrMergeBlocked):
+		writeError(c, http.StatusConflict, errCodeMergeBlocked, err.Error())
+		return
+	case errors.Is(err, usecase.ErrInvalidStatusTransition):
+		writeError(c, http.StatusConflict, errCodeInvalidTransition, err.Error())
+		return
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
 	case err != nil:
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 
@@ -156,17 +351,29 @@ func (api *PullRequestsAPI) PullRequestReassignPost(c *gin.Context) {
 		writeError(c, http.StatusConflict, errCodePRMerged, err.Error())
 		return
 
+	case errors.Is(err, usecase.ErrPullRequestNotOpen):
+		writeError(c, http.StatusConflict, errCodePRNotOpen, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerNotAssigned):
+		writeError(c, http.StatusConflict, errCodeNotAssigned, err.Error())
+		return
+
 	case errors.Is(err, usecase.ErrCannotFindActiveMembers):
 		writeError(c, http.StatusConflict, errCodeNoCandidate, err.Error())
 		return
 
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+
 	case err != nil:
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 
 	resp := struct {
-		PR         pullRequestResponse `json:"requestOwner"`
+		PR         pullRequestResponse `json:"pr"`
 		ReplacedBy string              `json:"replaced_by"`
 	}{
 		PR:         mapPullRequestToResponse(requestOwner),
@@ -175,3 +382,327 @@ func (api *PullRequestsAPI) PullRequestReassignPost(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// writeReviewerChangeError - ответы для ошибок ручного добавления и снятия ревьюверов
+func writeReviewerChangeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrPullRequestNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+	case errors.Is(err, usecase.ErrReviewerNotFound):
+		writeError(c, http.StatusNotFound, errCodeReviewerNotFound, err.Error())
+	case errors.Is(err, usecase.ErrInvalidReviewerRequest):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrReviewerInactive):
+		writeError(c, http.StatusBadRequest, errCodeReviewerInactive, err.Error())
+	case errors.Is(err, usecase.ErrPullRequestIsMerged):
+		writeError(c, http.StatusConflict, errCodePRMerged, err.Error())
+	case errors.Is(err, usecase.ErrPullRequestNotOpen):
+		writeError(c, http.StatusConflict, errCodePRNotOpen, err.Error())
+	case errors.Is(err, usecase.ErrReviewerNotAssigned):
+		writeError(c, http.StatusConflict, errCodeNotAssigned, err.Error())
+	case errors.Is(err, usecase.ErrReviewerAlreadyAssigned):
+		writeError(c, http.StatusConflict, errCodeAlreadyAssigned, err.Error())
+	case errors.Is(err, usecase.ErrReviewerLimitReached):
+		writeError(c, http.StatusConflict, errCodeReviewerLimit, err.Error())
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+	default:
+		writeInternalError(c, err)
+	}
+}
+
+// POST /pullRequest/addReviewer
+// Назначить дополнительного ревьювера на открытый PR
+
+func (api *PullRequestsAPI) PullRequestAddReviewerPost(c *gin.Context) {
+	var body struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		UserID        string `json:"user_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	pr, err := api.prUC.AddReviewer(c.Request.Context(), body.PullRequestID, body.UserID)
+	if err != nil {
+		writeReviewerChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		PR pullRequestResponse `json:"pr"`
+	}{
+		PR: mapPullRequestToResponse(pr),
+	})
+}
+
+// POST /pullRequest/removeReviewer
+// Снять ревьювера с открытого PR без замены
+
+func (api *PullRequestsAPI) PullRequestRemoveReviewerPost(c *gin.Context) {
+	var body struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		UserID        string `json:"user_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	pr, err := api.prUC.RemoveReviewer(c.Request.Context(), body.PullRequestID, body.UserID)
+	if err != nil {
+		writeReviewerChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		PR pullRequestResponse `json:"pr"`
+	}{
+		PR: mapPullRequestToResponse(pr),
+	})
+}
+
+// POST /pullRequest/review
+// Отправить решение ревьювера: APPROVED, CHANGES_REQUESTED или COMMENTED
+
+func (api *PullRequestsAPI) PullRequestReviewPost(c *gin.Context) {
+	var body struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+		UserID        string `json:"user_id" binding:"required"`
+		Decision      string `json:"decision" binding:"required"`
+		Comment       string `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	pr, err := api.prUC.SubmitReview(c.Request.Context(), &domain.ReviewDecision{
+		PullRequestID: body.PullRequestID,
+		UserID:        body.UserID,
+		State:         domain.ReviewState(body.Decision),
+		Comment:       body.Comment,
+	})
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidReviewState):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrPullRequestNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrPullRequestIsMerged):
+		writeError(c, http.StatusConflict, errCodePRMerged, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrPullRequestNotOpen):
+		writeError(c, http.StatusConflict, errCodePRNotOpen, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerNotAssigned):
+		writeError(c, http.StatusConflict, errCodeNotAssigned, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		PR pullRequestResponse `json:"pr"`
+	}{
+		PR: mapPullRequestToResponse(pr),
+	})
+}
+
+// POST /pullRequest/ready
+// Перевести черновик в OPEN и назначить ревьюверов
+
+func (api *PullRequestsAPI) PullRequestReadyPost(c *gin.Context) {
+	api.changeStatus(c, domain.RequestStatusOpen)
+}
+
+// POST /pullRequest/close
+// Закрыть PR без слияния и снять ревьюверов
+
+func (api *PullRequestsAPI) PullRequestClosePost(c *gin.Context) {
+	api.changeStatus(c, domain.RequestStatusClosed)
+}
+
+// POST /pullRequest/reopen
+// Переоткрыть закрытый PR и заново назначить ревьюверов
+
+func (api *PullRequestsAPI) PullRequestReopenPost(c *gin.Context) {
+	api.changeStatus(c, domain.RequestStatusReopened)
+}
+
+func (api *PullRequestsAPI) changeStatus(c *gin.Context, status domain.RequestStatus) {
+	var body struct {
+		PullRequestID string `json:"pull_request_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	pr, err := api.prUC.ChangeStatus(c.Request.Context(), body.PullRequestID, status)
+
+	switch {
+	case errors.Is(err, usecase.ErrPullRequestNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrInvalidStatusTransition):
+		writeError(c, http.StatusConflict, errCodeInvalidTransition, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		PR pullRequestResponse `json:"pr"`
+	}{
+		PR: mapPullRequestToResponse(pr),
+	})
+}
+
+// GET /pullRequest/history
+// Получить историю назначений ревьюверов PR
+
+func (api *PullRequestsAPI) PullRequestHistoryGet(c *gin.Context) {
+	pullRequestID := c.Query("pull_request_id")
+	if pullRequestID == "" {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "pull_request_id is required")
+		return
+	}
+
+	events, err := api.prUC.GetHistory(c.Request.Context(), pullRequestID)
+
+	switch {
+	case errors.Is(err, usecase.ErrPullRequestNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		PullRequestID string                    `json:"pull_request_id"`
+		Events        []assignmentEventResponse `json:"events"`
+	}{
+		PullRequestID: pullRequestID,
+		Events:        mapAssignmentEventsToResponse(events),
+	})
+}
+
+// GET /pullRequest/explanation
+// Получить объяснения выбора ревьюверов PR
+
+func (api *PullRequestsAPI) PullRequestExplanationGet(c *gin.Context) {
+	pullRequestID := c.Query("pull_request_id")
+	if pullRequestID == "" {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "pull_request_id is required")
+		return
+	}
+
+	explanations, err := api.prUC.GetExplanations(c.Request.Context(), pullRequestID)
+
+	switch {
+	case errors.Is(err, usecase.ErrPullRequestNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		PullRequestID string                          `json:"pull_request_id"`
+		Explanations  []assignmentExplanationResponse `json:"explanations"`
+	}{
+		PullRequestID: pullRequestID,
+		Explanations:  mapAssignmentExplanationsToResponse(explanations),
+	})
+}
+
+// POST /pullRequest/previewAssignment
+// Показать, кто был бы назначен ревьюверами PR, ничего не создавая
+
+func (api *PullRequestsAPI) PullRequestPreviewAssignmentPost(c *gin.Context) {
+	var body struct {
+		// PullRequestID - необязательный id будущего PR, с ним проверяется, что PR еще не создан
+		PullRequestID      string   `json:"pull_request_id"`
+		AuthorID           string   `json:"author_id" binding:"required"`
+		RequestedReviewers []string `json:"requested_reviewers"`
+		ExcludedReviewers  []string `json:"excluded_reviewers"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	pr := &domain.PullRequest{ID: body.PullRequestID, AuthorID: body.AuthorID}
+	preferences := domain.ReviewerPreferences{
+		Requested: body.RequestedReviewers,
+		Excluded:  body.ExcludedReviewers,
+	}
+
+	preview, err := api.prUC.PreviewAssignment(c.Request.Context(), pr, preferences)
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidReviewerRequest):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerNotFound):
+		writeError(c, http.StatusNotFound, errCodeReviewerNotFound, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerInactive):
+		writeError(c, http.StatusBadRequest, errCodeReviewerInactive, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrReviewerNotInTeam):
+		writeError(c, http.StatusBadRequest, errCodeReviewerNotInTeam, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrAuthorNotFound),
+		errors.Is(err, usecase.ErrTeamNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrPullRequestAlreadyExists):
+		writeError(c, http.StatusConflict, errCodePRExists, err.Error())
+		return
+
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		Preview assignmentPreviewResponse `json:"preview"`
+	}{
+		Preview: mapAssignmentPreviewToResponse(preview),
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ * PR Reviewer Assignment Service (Test Task, Fall 2025)
+ *
+ * No description provided (generated by Openapi Generator https://github.com/openapitools/openapi-generator)
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package openapi
+
+import (
+	"avito-test/internal/domain"
+	"avito-test/internal/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsAPI struct {
+	statsUC usecase.Stats
+}
+
+func NewStatsAPI(statsUC usecase.Stats) StatsAPI {
+	return StatsAPI{statsUC: statsUC}
+}
+
+type userStatsResponse struct {
+	UserID string `json:"user_id"`
+	Open   int    `json:"open"`
+	Merged int    `json:"merged"`
+	Total  int    `json:"total"`
+}
+
+type pullRequestStatsResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	Status        string `json:"status"`
+	Reviewers     int    `json:"reviewers"`
+}
+
+type teamStatsResponse struct {
+	TeamName           string `json:"team_name"`
+	OpenPullRequests   int    `json:"open_pull_requests"`
+	MergedPullRequests int    `json:"merged_pull_requests"`
+	Assignments        int    `json:"assignments"`
+}
+
+type mergeTimeResponse struct {
+	MergedPullRequests int `json:"merged_pull_requests"`
+	// MedianSeconds - null, если в выборке нет слитых PR
+	MedianSeconds *float64 `json:"median_seconds"`
+}
+
+type statsResponse struct {
+	Users        []userStatsResponse        `json:"users"`
+	PullRequests []pullRequestStatsResponse `json:"pull_requests"`
+	Teams        []teamStatsResponse        `json:"teams"`
+	MergeTime    mergeTimeResponse          `json:"merge_time"`
+}
+
+func mapStatsToResponse(stats *domain.Stats) statsResponse {
+	resp := statsResponse{
+		Users:        make([]userStatsResponse, 0, len(stats.Users)),
+		PullRequests: make([]pullRequestStatsResponse, 0, len(stats.PullRequests)),
+		Teams:        make([]teamStatsResponse, 0, len(stats.Teams)),
+		MergeTime:    mergeTimeResponse{MergedPullRequests: stats.MergeTime.MergedPullRequests},
+	}
+	for _, u := range stats.Users {
+		resp.Users = append(resp.Users, userStatsResponse{
+			UserID: u.UserID,
+			Open:   u.Open,
+			Merged: u.Merged,
+			Total:  u.Open + u.Merged,
+		})
+	}
+	for _, pr := range stats.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, pullRequestStatsResponse{
+			PullRequestID: pr.PullRequestID,
+			Status:        string(pr.Status),
+			Reviewers:     pr.Reviewers,
+		})
+	}
+	for _, t := range stats.Teams {
+		resp.Teams = append(resp.Teams, teamStatsResponse{
+			TeamName:           t.TeamName,
+			OpenPullRequests:   t.OpenPullRequests,
+			MergedPullRequests: t.MergedPullRequests,
+			Assignments:        t.Assignments,
+		})
+	}
+	if stats.MergeTime.MergedPullRequests > 0 {
+		median := stats.MergeTime.Median.Seconds()
+		resp.MergeTime.MedianSeconds = &median
+	}
+	return resp
+}
+
+// parseTimeQuery разбирает необязательный параметр в формате RFC 3339.
+func parseTimeQuery(c *gin.Context, name string) (time.Time, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+// GET /stats
+// Статистика назначений ревьюверов по пользователям, PR и командам
+func (api *StatsAPI) StatsGet(c *gin.Context) {
+	from, err := parseTimeQuery(c, "from")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "from must be RFC 3339 date-time")
+		return
+	}
+	to, err := parseTimeQuery(c, "to")
+	if err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "to must be RFC 3339 date-time")
+		return
+	}
+
+	stats, err := api.statsUC.GetStats(c.Request.Context(), domain.StatsFilter{
+		TeamName: c.Query("team_name"),
+		From:     from,
+		To:       to,
+	})
+
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, mapStatsToResponse(stats))
+		return
+
+	case errors.Is(err, usecase.ErrInvalidStatsRange):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrTeamNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+
+	default:
+		writeInternalError(c, err)
+		return
+	}
+}
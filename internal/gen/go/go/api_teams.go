@@ -13,7 +13,6 @@ import (
 	"avito-test/internal/domain"
 	"avito-test/internal/usecase"
 	"errors"
-	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +32,7 @@ type teamMemberResponse struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role"`
 }
 
 type teamResponse struct {
@@ -51,6 +51,7 @@ func mapTeamToResponse(team *domain.Team) teamResponse {
 				UserID:   m.ID,
 				Username: m.Username,
 				IsActive: m.IsActive,
+				Role:     string(team.RoleOf(m.ID)),
 			})
 		}
 	}
@@ -66,6 +67,7 @@ func (api *TeamsAPI) TeamAddPost(c *gin.Context) {
 			UserID   string `json:"user_id" binding:"required"`
 			Username string `json:"username" binding:"required"`
 			IsActive bool   `json:"is_active"`
+			Role     string `json:"role"`
 		} `json:"members" binding:"required"`
 	}
 
@@ -79,7 +81,8 @@ func (api *TeamsAPI) TeamAddPost(c *gin.Context) {
 	}
 
 	team := &domain.Team{
-		Name: body.TeamName,
+		Name:  body.TeamName,
+		Roles: make(map[string]domain.TeamRole, len(body.Members)),
 	}
 
 	members := make([]domain.User, 0, len(body.Members))
@@ -89,6 +92,9 @@ func (api *TeamsAPI) TeamAddPost(c *gin.Context) {
 			Username: m.Username,
 			IsActive: m.IsActive,
 		})
+		if m.Role != "" {
+			team.Roles[m.UserID] = domain.TeamRole(m.Role)
+		}
 	}
 
 	team, err := api.teamUC.CreateTeam(c.Request.Context(), team, members)
@@ -109,9 +115,16 @@ func (api *TeamsAPI) TeamAddPost(c *gin.Context) {
 		writeError(c, http.StatusBadRequest, errCodeTeamExists, err.Error())
 		return
 
+	case errors.Is(err, usecase.ErrInvalidTeamRole):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+
 	default:
-		log.Printf("%+v", err)
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 }
@@ -132,9 +145,349 @@ func (api *TeamsAPI) TeamGetGet(c *gin.Context) {
 		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
 		return
 	case err != nil:
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, mapTeamToResponse(team))
 }
+
+type reviewerReplacementResponse struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+type teamDeactivationResponse struct {
+	TeamName           string                        `json:"team_name"`
+	DeactivatedUserIDs []string                      `json:"deactivated_user_ids"`
+	Reassigned         []reviewerReplacementResponse `json:"reassigned"`
+	Unfilled           []reviewerReplacementResponse `json:"unfilled"`
+}
+
+func mapReplacements(replacements []domain.ReviewerReplacement) []reviewerReplacementResponse {
+	resp := make([]reviewerReplacementResponse, 0, len(replacements))
+	for _, r := range replacements {
+		resp = append(resp, reviewerReplacementResponse{
+			PullRequestID: r.PullRequestID,
+			OldReviewerID: r.OldReviewerID,
+			NewReviewerID: r.NewReviewerID,
+		})
+	}
+	return resp
+}
+
+// POST /team/deactivateMembers
+// Массово деактивировать участников команды и переназначить их открытые ревью
+func (api *TeamsAPI) TeamDeactivateMembersPost(c *gin.Context) {
+	var body struct {
+		TeamName   string   `json:"team_name" binding:"required"`
+		UserIDs    []string `json:"user_ids"`
+		AllMembers bool     `json:"all_members"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+	// пустой список в usecase означает всю команду, поэтому требуем явного all_members
+	if body.AllMembers == (len(body.UserIDs) > 0) {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "either user_ids or all_members must be set")
+		return
+	}
+
+	result, err := api.teamUC.DeactivateMembers(c.Request.Context(), body.TeamName, body.UserIDs)
+
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, teamDeactivationResponse{
+			TeamName:           result.TeamName,
+			DeactivatedUserIDs: result.DeactivatedUserIDs,
+			Reassigned:         mapReplacements(result.Reassigned),
+			Unfilled:           mapReplacements(result.Unfilled),
+		})
+		return
+
+	case errors.Is(err, usecase.ErrTeamNotFound),
+		errors.Is(err, usecase.ErrMemberNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+
+	default:
+		writeInternalError(c, err)
+		return
+	}
+}
+
+type teamMemberChangeResponse struct {
+	UserID     string                        `json:"user_id"`
+	FromTeam   string                        `json:"from_team"`
+	ToTeam     string                        `json:"to_team,omitempty"`
+	Reassigned []reviewerReplacementResponse `json:"reassigned"`
+	Unfilled   []reviewerReplacementResponse `json:"unfilled"`
+}
+
+func mapMemberChange(change *domain.TeamMemberChange) teamMemberChangeResponse {
+	return teamMemberChangeResponse{
+		UserID:     change.UserID,
+		FromTeam:   change.FromTeam,
+		ToTeam:     change.ToTeam,
+		Reassigned: mapReplacements(change.Reassigned),
+		Unfilled:   mapReplacements(change.Unfilled),
+	}
+}
+
+// writeMembershipError - общие ответы для ошибок операций над составом команды
+func writeMembershipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidTeamName),
+		errors.Is(err, usecase.ErrInvalidTeamRole),
+		errors.Is(err, usecase.ErrInvalidTeamSettings):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrTeamNotFound),
+		errors.Is(err, usecase.ErrMemberNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+	case errors.Is(err, usecase.ErrMemberAlreadyInTeam):
+		writeError(c, http.StatusConflict, errCodeMemberExists, err.Error())
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+	default:
+		writeInternalError(c, err)
+	}
+}
+
+// POST /team/addMember
+// Добавить пользователя в существующую команду (создаёт/обновляет пользователя)
+func (api *TeamsAPI) TeamAddMemberPost(c *gin.Context) {
+	var body struct {
+		TeamName string `json:"team_name" binding:"required"`
+		UserID   string `json:"user_id" binding:"required"`
+		Username string `json:"username" binding:"required"`
+		IsActive *bool  `json:"is_active" binding:"required"`
+		Role     string `json:"role"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	member := domain.User{ID: body.UserID, Username: body.Username, IsActive: *body.IsActive}
+	team, err := api.teamUC.AddMember(c.Request.Context(), body.TeamName, member, domain.TeamRole(body.Role))
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		Team teamResponse `json:"team"`
+	}{
+		Team: mapTeamToResponse(team),
+	})
+}
+
+// POST /team/removeMember
+// Исключить участника из команды и переназначить его открытые ревью в PR команды
+func (api *TeamsAPI) TeamRemoveMemberPost(c *gin.Context) {
+	var body struct {
+		TeamName string `json:"team_name" binding:"required"`
+		UserID   string `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	result, err := api.teamUC.RemoveMember(c.Request.Context(), body.TeamName, body.UserID)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMemberChange(result))
+}
+
+// POST /team/moveMember
+// Перевести участника в другую команду и переназначить его открытые ревью в PR прежней команды
+func (api *TeamsAPI) TeamMoveMemberPost(c *gin.Context) {
+	var body struct {
+		UserID   string `json:"user_id" binding:"required"`
+		FromTeam string `json:"from_team" binding:"required"`
+		ToTeam   string `json:"to_team" binding:"required"`
+		Role     string `json:"role"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	result, err := api.teamUC.MoveMember(c.Request.Context(), body.FromTeam, body.ToTeam, body.UserID, domain.TeamRole(body.Role))
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMemberChange(result))
+}
+
+// DELETE /team
+// Удалить команду и исключить из нее всех участников
+func (api *TeamsAPI) TeamDelete(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "team_name is required")
+		return
+	}
+
+	removed, err := api.teamUC.DeleteTeam(c.Request.Context(), teamName)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		TeamName       string   `json:"team_name"`
+		RemovedUserIDs []string `json:"removed_user_ids"`
+	}{
+		TeamName:       teamName,
+		RemovedUserIDs: removed,
+	})
+}
+
+type teamSyncResponse struct {
+	TeamName        string                        `json:"team_name"`
+	DryRun          bool                          `json:"dry_run"`
+	Created         bool                          `json:"created"`
+	Added           []string                      `json:"added"`
+	Removed         []string                      `json:"removed"`
+	Renamed         []domain.UsernameChange       `json:"renamed"`
+	ActivityChanged []domain.ActivityChange       `json:"activity_changed"`
+	Reassigned      []reviewerReplacementResponse `json:"reassigned"`
+	Unfilled        []reviewerReplacementResponse `json:"unfilled"`
+}
+
+// PUT /team/sync
+// Синхронизировать состав команды с внешним источником (создаёт команду, если ее нет)
+func (api *TeamsAPI) TeamSyncPut(c *gin.Context) {
+	var body struct {
+		TeamName string `json:"team_name" binding:"required"`
+		Members  []struct {
+			UserID   string `json:"user_id" binding:"required"`
+			Username string `json:"username" binding:"required"`
+			IsActive bool   `json:"is_active"`
+			Role     string `json:"role"`
+		} `json:"members" binding:"required"`
+		DryRun bool `json:"dry_run"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	team := &domain.Team{
+		Name:  body.TeamName,
+		Roles: make(map[string]domain.TeamRole, len(body.Members)),
+	}
+	members := make([]domain.User, 0, len(body.Members))
+	for _, m := range body.Members {
+		members = append(members, domain.User{
+			ID:       m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		})
+		if m.Role != "" {
+			team.Roles[m.UserID] = domain.TeamRole(m.Role)
+		}
+	}
+
+	result, err := api.teamUC.SyncTeam(c.Request.Context(), team, members, body.DryRun)
+
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, teamSyncResponse{
+			TeamName:        result.TeamName,
+			DryRun:          result.DryRun,
+			Created:         result.Created,
+			Added:           result.Added,
+			Removed:         result.Removed,
+			Renamed:         result.Renamed,
+			ActivityChanged: result.ActivityChanged,
+			Reassigned:      mapReplacements(result.Reassigned),
+			Unfilled:        mapReplacements(result.Unfilled),
+		})
+		return
+
+	case errors.Is(err, usecase.ErrMemberNotFound),
+		errors.Is(err, usecase.ErrDuplicateMember):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+
+	default:
+		writeMembershipError(c, err)
+		return
+	}
+}
+
+// GET /team/settings
+// Получить настройки назначения ревьюверов команды
+func (api *TeamsAPI) TeamSettingsGet(c *gin.Context) {
+	teamName := c.Query("team_name")
+	if teamName == "" {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "team_name is required")
+		return
+	}
+
+	settings, err := api.teamUC.GetSettings(c.Request.Context(), teamName)
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// PUT /team/settings
+// Изменить число ревьюверов, назначаемых на PR авторов команды, число одобрений для слияния и сроки ревью
+func (api *TeamsAPI) TeamSettingsPut(c *gin.Context) {
+	var body struct {
+		TeamName           string `json:"team_name" binding:"required"`
+		MinReviewers       *int   `json:"min_reviewers" binding:"required"`
+		MaxReviewers       *int   `json:"max_reviewers" binding:"required"`
+		RequiredApprovals  int    `json:"required_approvals"`
+		ReviewSLAHours     *int   `json:"review_sla_hours"`
+		ReassignAfterHours int    `json:"reassign_after_hours"`
+	}
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	// без review_sla_hours сохраняется срок по умолчанию, 0 отключает напоминания
+	reviewSLAHours := domain.DefaultReviewSLAHours
+	if body.ReviewSLAHours != nil {
+		reviewSLAHours = *body.ReviewSLAHours
+	}
+
+	settings, err := api.teamUC.UpdateSettings(c.Request.Context(), &domain.TeamSettings{
+		TeamName:           body.TeamName,
+		MinReviewers:       *body.MinReviewers,
+		MaxReviewers:       *body.MaxReviewers,
+		RequiredApprovals:  body.RequiredApprovals,
+		ReviewSLAHours:     reviewSLAHours,
+		ReassignAfterHours: body.ReassignAfterHours,
+	})
+	if err != nil {
+		writeMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
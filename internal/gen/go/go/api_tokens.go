@@ -0,0 +1,151 @@
+/*
+ * PR Reviewer Assignment Service (Test Task, Fall 2025)
+ *
+ * No description provided (generated by Openapi Generator https://github.com/openapitools/openapi-generator)
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package openapi
+
+import (
+	"avito-test/internal/domain"
+	"avito-test/internal/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TokensAPI struct {
+	authUC usecase.Auth
+}
+
+func NewTokensAPI(authUC usecase.Auth) TokensAPI {
+	return TokensAPI{authUC: authUC}
+}
+
+type tokenResponse struct {
+	TokenID   string     `json:"token_id"`
+	Name      string     `json:"name"`
+	Scope     string     `json:"scope"`
+	UserID    *string    `json:"user_id"`
+	CreatedAt *time.Time `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+func mapTokenToResponse(t *domain.APIToken) tokenResponse {
+	resp := tokenResponse{
+		TokenID: t.ID,
+		Name:    t.Name,
+		Scope:   string(t.Scope),
+	}
+	if t.UserID != "" {
+		resp.UserID = &t.UserID
+	}
+	if !t.CreatedAt.IsZero() {
+		resp.CreatedAt = &t.CreatedAt
+	}
+	if !t.RevokedAt.IsZero() {
+		resp.RevokedAt = &t.RevokedAt
+	}
+	return resp
+}
+
+// POST /tokens/create
+// Выпустить API токен. Значение токена возвращается только в этом ответе
+
+func (api *TokensAPI) TokensCreatePost(c *gin.Context) {
+	var body struct {
+		Name   string `json:"name" binding:"required"`
+		Scope  string `json:"scope" binding:"required"`
+		UserID string `json:"user_id"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	token, secret, err := api.authUC.CreateToken(c.Request.Context(), body.Name, domain.TokenScope(body.Scope), body.UserID)
+
+	switch {
+	case errors.Is(err, usecase.ErrInvalidTokenScope),
+		errors.Is(err, usecase.ErrTokenUserRequired):
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	case errors.Is(err, usecase.ErrMemberNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	resp := struct {
+		Token  tokenResponse `json:"token"`
+		Secret string        `json:"secret"`
+	}{
+		Token:  mapTokenToResponse(token),
+		Secret: secret,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GET /tokens/list
+// Получить все выпущенные токены без их значений
+
+func (api *TokensAPI) TokensListGet(c *gin.Context) {
+	tokens, err := api.authUC.GetTokens(c.Request.Context())
+	if err != nil {
+		writeInternalError(c, err)
+		return
+	}
+
+	resp := struct {
+		Tokens []tokenResponse `json:"tokens"`
+	}{
+		Tokens: make([]tokenResponse, 0, len(tokens)),
+	}
+	for i := range tokens {
+		resp.Tokens = append(resp.Tokens, mapTokenToResponse(&tokens[i]))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// POST /tokens/revoke
+// Отозвать токен
+
+func (api *TokensAPI) TokensRevokePost(c *gin.Context) {
+	var body struct {
+		TokenID string `json:"token_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, err.Error())
+		return
+	}
+
+	err := api.authUC.RevokeToken(c.Request.Context(), body.TokenID)
+
+	switch {
+	case errors.Is(err, usecase.ErrTokenNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	resp := struct {
+		TokenID string `json:"token_id"`
+		Revoked bool   `json:"revoked"`
+	}{
+		TokenID: body.TokenID,
+		Revoked: true,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
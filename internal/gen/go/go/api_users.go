@@ -14,6 +14,7 @@ import (
 	"avito-test/internal/usecase"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -42,7 +43,7 @@ func mapUserToResponse(u *domain.User) userResponse {
 }
 
 // GET /users/getReview
-// Получить PR'ы, где пользователь назначен ревьювером
+// Получить PR'ы, где пользователь назначен ревьювером (при awaiting=true - только ожидающие его решения)
 
 func (api *UsersAPI) UsersGetReviewGet(c *gin.Context) {
 	userID := c.Query("user_id")
@@ -50,15 +51,26 @@ func (api *UsersAPI) UsersGetReviewGet(c *gin.Context) {
 		writeError(c, http.StatusBadRequest, errCodeBadRequest, "user_id is required")
 		return
 	}
+	awaiting := false
+	if raw := c.Query("awaiting"); raw != "" {
+		var err error
+		if awaiting, err = strconv.ParseBool(raw); err != nil {
+			writeError(c, http.StatusBadRequest, errCodeBadRequest, "awaiting must be a boolean")
+			return
+		}
+	}
+	if !authorizeUser(c, userID) {
+		return
+	}
 
-	prs, err := api.userUC.GetUserPullRequests(c.Request.Context(), userID)
+	prs, err := api.userUC.GetUserPullRequests(c.Request.Context(), userID, awaiting)
 
 	switch {
 	case errors.Is(err, usecase.ErrMemberNotFound):
 		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
 		return
 	case err != nil:
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 
@@ -108,8 +120,11 @@ func (api *UsersAPI) UsersSetIsActivePost(c *gin.Context) {
 	case errors.Is(err, usecase.ErrMemberNotFound):
 		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
 		return
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
 	case err != nil:
-		writeError(c, http.StatusInternalServerError, errCodeInternal, err.Error())
+		writeInternalError(c, err)
 		return
 	}
 
@@ -121,3 +136,36 @@ func (api *UsersAPI) UsersSetIsActivePost(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// GET /users/history
+// Получить историю назначений, где пользователь был назначен, снят или заменен
+
+func (api *UsersAPI) UsersHistoryGet(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		writeError(c, http.StatusBadRequest, errCodeBadRequest, "user_id is required")
+		return
+	}
+
+	events, err := api.userUC.GetHistory(c.Request.Context(), userID)
+
+	switch {
+	case errors.Is(err, usecase.ErrMemberNotFound):
+		writeError(c, http.StatusNotFound, errCodeNotFound, err.Error())
+		return
+	case errors.Is(err, usecase.ErrForbidden):
+		writeError(c, http.StatusForbidden, errCodeForbidden, err.Error())
+		return
+	case err != nil:
+		writeInternalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		UserID string                    `json:"user_id"`
+		Events []assignmentEventResponse `json:"events"`
+	}{
+		UserID: userID,
+		Events: mapAssignmentEventsToResponse(events),
+	})
+}
@@ -27,28 +27,47 @@ type Route struct {
 	HandlerFunc gin.HandlerFunc
 }
 
+// RouteNameKey is the gin.Context key holding the Name of the matched Route.
+const RouteNameKey = "openapi.route_name"
+
+// WithRouteName stores the route name in gin.Context for middlewares such as metrics and tracing.
+func WithRouteName(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(RouteNameKey, name)
+	}
+}
+
 // NewRouter returns a new router.
 func NewRouter(handleFunctions ApiHandleFunctions) *gin.Engine {
 	return NewRouterWithGinEngine(gin.Default(), handleFunctions)
 }
 
+// RouteMiddleware builds a handler that runs before the route handler and knows the route name,
+// e.g. authorization by route.
+type RouteMiddleware func(routeName string) gin.HandlerFunc
+
 // NewRouter add routes to existing gin engine.
-func NewRouterWithGinEngine(router *gin.Engine, handleFunctions ApiHandleFunctions) *gin.Engine {
+func NewRouterWithGinEngine(router *gin.Engine, handleFunctions ApiHandleFunctions, middlewares ...RouteMiddleware) *gin.Engine {
 	for _, route := range getRoutes(handleFunctions) {
 		if route.HandlerFunc == nil {
 			route.HandlerFunc = DefaultHandleFunc
 		}
+		handlers := []gin.HandlerFunc{WithRouteName(route.Name)}
+		for _, m := range middlewares {
+			handlers = append(handlers, m(route.Name))
+		}
+		handlers = append(handlers, route.HandlerFunc)
 		switch route.Method {
 		case http.MethodGet:
-			router.GET(route.Pattern, route.HandlerFunc)
+			router.GET(route.Pattern, handlers...)
 		case http.MethodPost:
-			router.POST(route.Pattern, route.HandlerFunc)
+			router.POST(route.Pattern, handlers...)
 		case http.MethodPut:
-			router.PUT(route.Pattern, route.HandlerFunc)
+			router.PUT(route.Pattern, handlers...)
 		case http.MethodPatch:
-			router.PATCH(route.Pattern, route.HandlerFunc)
+			router.PATCH(route.Pattern, handlers...)
 		case http.MethodDelete:
-			router.DELETE(route.Pattern, route.HandlerFunc)
+			router.DELETE(route.Pattern, handlers...)
 		}
 	}
 
@@ -68,6 +87,10 @@ type ApiHandleFunctions struct {
 	TeamsAPI TeamsAPI
 	// Routes for the UsersAPI part of the API
 	UsersAPI UsersAPI
+	// Routes for the StatsAPI part of the API
+	StatsAPI StatsAPI
+	// Routes for the TokensAPI part of the API
+	TokensAPI TokensAPI
 }
 
 func getRoutes(handleFunctions ApiHandleFunctions) []Route {
@@ -90,6 +113,60 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 			"/pullRequest/reassign",
 			handleFunctions.PullRequestsAPI.PullRequestReassignPost,
 		},
+		{
+			"PullRequestAddReviewerPost",
+			http.MethodPost,
+			"/pullRequest/addReviewer",
+			handleFunctions.PullRequestsAPI.PullRequestAddReviewerPost,
+		},
+		{
+			"PullRequestRemoveReviewerPost",
+			http.MethodPost,
+			"/pullRequest/removeReviewer",
+			handleFunctions.PullRequestsAPI.PullRequestRemoveReviewerPost,
+		},
+		{
+			"PullRequestReviewPost",
+			http.MethodPost,
+			"/pullRequest/review",
+			handleFunctions.PullRequestsAPI.PullRequestReviewPost,
+		},
+		{
+			"PullRequestReadyPost",
+			http.MethodPost,
+			"/pullRequest/ready",
+			handleFunctions.PullRequestsAPI.PullRequestReadyPost,
+		},
+		{
+			"PullRequestClosePost",
+			http.MethodPost,
+			"/pullRequest/close",
+			handleFunctions.PullRequestsAPI.PullRequestClosePost,
+		},
+		{
+			"PullRequestReopenPost",
+			http.MethodPost,
+			"/pullRequest/reopen",
+			handleFunctions.PullRequestsAPI.PullRequestReopenPost,
+		},
+		{
+			"PullRequestHistoryGet",
+			http.MethodGet,
+			"/pullRequest/history",
+			handleFunctions.PullRequestsAPI.PullRequestHistoryGet,
+		},
+		{
+			"PullRequestExplanationGet",
+			http.MethodGet,
+			"/pullRequest/explanation",
+			handleFunctions.PullRequestsAPI.PullRequestExplanationGet,
+		},
+		{
+			"PullRequestPreviewAssignmentPost",
+			http.MethodPost,
+			"/pullRequest/previewAssignment",
+			handleFunctions.PullRequestsAPI.PullRequestPreviewAssignmentPost,
+		},
 		{
 			"TeamAddPost",
 			http.MethodPost,
@@ -102,6 +179,54 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 			"/team/get",
 			handleFunctions.TeamsAPI.TeamGetGet,
 		},
+		{
+			"TeamDeactivateMembersPost",
+			http.MethodPost,
+			"/team/deactivateMembers",
+			handleFunctions.TeamsAPI.TeamDeactivateMembersPost,
+		},
+		{
+			"TeamAddMemberPost",
+			http.MethodPost,
+			"/team/addMember",
+			handleFunctions.TeamsAPI.TeamAddMemberPost,
+		},
+		{
+			"TeamRemoveMemberPost",
+			http.MethodPost,
+			"/team/removeMember",
+			handleFunctions.TeamsAPI.TeamRemoveMemberPost,
+		},
+		{
+			"TeamMoveMemberPost",
+			http.MethodPost,
+			"/team/moveMember",
+			handleFunctions.TeamsAPI.TeamMoveMemberPost,
+		},
+		{
+			"TeamDelete",
+			http.MethodDelete,
+			"/team",
+			handleFunctions.TeamsAPI.TeamDelete,
+		},
+		{
+			"TeamSyncPut",
+			http.MethodPut,
+			"/team/sync",
+			handleFunctions.TeamsAPI.TeamSyncPut,
+		},
+		{
+			"TeamSettingsGet",
+			http.MethodGet,
+			"/team/settings",
+			handleFunctions.TeamsAPI.TeamSettingsGet,
+		},
+		{
+			"TeamSettingsPut",
+			http.MethodPut,
+			"/team/settings",
+			handleFunctions.TeamsAPI.TeamSettingsPut,
+		},
 		{
 			"UsersGetReviewGet",
 			http.MethodGet,
@@ -114,5 +239,35 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 			"/users/setIsActive",
 			handleFunctions.UsersAPI.UsersSetIsActivePost,
 		},
+		{
+			"UsersHistoryGet",
+			http.MethodGet,
+			"/users/history",
+			handleFunctions.UsersAPI.UsersHistoryGet,
+		},
+		{
+			"StatsGet",
+			http.MethodGet,
+			"/stats",
+			handleFunctions.StatsAPI.StatsGet,
+		},
+		{
+			"TokensCreatePost",
+			http.MethodPost,
+			"/tokens/create",
+			handleFunctions.TokensAPI.TokensCreatePost,
+		},
+		{
+			"TokensListGet",
+			http.MethodGet,
+			"/tokens/list",
+			handleFunctions.TokensAPI.TokensListGet,
+		},
+		{
+			"TokensRevokePost",
+			http.MethodPost,
+			"/tokens/revoke",
+			handleFunctions.TokensAPI.TokensRevokePost,
+		},
 	}
 }
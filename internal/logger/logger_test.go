@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestLogger_AddsRequestIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, "info")
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	log.InfoContext(WithRequestID(context.Background(), "req-1"), "with id")
+	log.InfoContext(context.Background(), "without id")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0]["request_id"] != "req-1" {
+		t.Fatalf("expected request_id req-1, got %v", records[0]["request_id"])
+	}
+	if _, ok := records[1]["request_id"]; ok {
+		t.Fatalf("unexpected request_id in record without id: %v", records[1])
+	}
+}
+
+func TestLogger_AddsTraceIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, "info")
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	})
+	log.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "traced")
+
+	records := decodeLines(t, &buf)
+	if records[0]["trace_id"] != sc.TraceID().String() || records[0]["span_id"] != sc.SpanID().String() {
+		t.Fatalf("expected trace_id and span_id from context, got %v", records[0])
+	}
+}
+
+func TestLogger_RedactsSensitiveValues(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, "info")
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	log.With(slog.String("api_token", "secret-token")).Info("connected",
+		slog.String("dsn", "postgres://postgres:hunter2@db:5432/avito?sslmode=disable"),
+		slog.String("DB_PASSWORD", "hunter2"),
+		slog.Group("request", slog.String("Authorization", "Bearer abc")),
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"hunter2", "secret-token", "Bearer abc"} {
+		if strings.Contains(out, leaked) {
+			t.Fatalf("log contains sensitive value %q: %s", leaked, out)
+		}
+	}
+	if !strings.Contains(out, "postgres://postgres:"+Redacted+"@db:5432/avito?sslmode=disable") {
+		t.Fatalf("expected DSN with redacted password, got %s", out)
+	}
+}
+
+func TestLogger_Level(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, "warn")
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+
+	log.Info("skipped")
+	log.Warn("written")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "written" {
+		t.Fatalf("expected only warn record, got %v", records)
+	}
+
+	if _, err := New(&buf, "verbose"); err == nil {
+		t.Fatalf("expected error for unknown level")
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		dsn  string
+		want string
+	}{
+		{name: "with password", dsn: "postgres://u:p@h:5432/db", want: "postgres://u:" + Redacted + "@h:5432/db"},
+		{name: "without password", dsn: "postgres://u@h:5432/db", want: "postgres://u@h:5432/db"},
+		{name: "keyword value", dsn: "host=h user=u password=p sslmode=disable", want: "host=h user=u password=" + Redacted + " sslmode=disable"},
+		{name: "keyword value without password", dsn: "host=h user=u", want: "host=h user=u"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactDSN(tt.dsn); got != tt.want {
+				t.Fatalf("RedactDSN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
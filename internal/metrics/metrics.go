@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pr_reviewer"
+
+// durationBuckets - границы гистограммы длительности запросов, 0.3 с совпадает с SLI.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.3, 0.5, 1, 2.5, 5}
+
+// Metrics - метрики сервиса в собственном реестре, поэтому тесты не зависят от глобального состояния.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpDuration *prometheus.HistogramVec
+	httpRequests *prometheus.CounterVec
+
+	reviewersAssigned *prometheus.CounterVec
+	reassignments     *prometheus.CounterVec
+	noCandidate       *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Длительность обработки HTTP запроса по маршруту.",
+			Buckets:   durationBuckets,
+		}, []string{"route", "method"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Количество HTTP запросов по маршруту и коду ответа.",
+		}, []string{"route", "method", "status"}),
+		reviewersAssigned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewers_assigned_total",
+			Help:      "Количество назначенных ревьюверов по операции.",
+		}, []string{"operation"}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reviewer_reassignments_total",
+			Help:      "Количество замен ревьюверов по операции.",
+		}, []string{"operation"}),
+		noCandidate: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Количество слотов ревьюверов, для которых не нашлось кандидата, по операции.",
+		}, []string{"operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.httpRequests,
+		m.reviewersAssigned,
+		m.reassignments,
+		m.noCandidate,
+	)
+	return m
+}
+
+// RegisterDB добавляет gauges из sql.DBStats пула соединений.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler отдает метрики в текстовом формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry - реестр метрик, используется в тестах для чтения значений.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// ObserveHTTP учитывает обработанный HTTP запрос.
+func (m *Metrics) ObserveHTTP(route, method string, status int, duration time.Duration) {
+	m.httpDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+	m.httpRequests.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+}
+
+func (m *Metrics) ReviewersAssigned(operation string, n int) {
+	m.reviewersAssigned.WithLabelValues(operation).Add(float64(n))
+}
+
+func (m *Metrics) Reassigned(operation string, n int) {
+	m.reassignments.WithLabelValues(operation).Add(float64(n))
+}
+
+func (m *Metrics) NoCandidate(operation string, n int) {
+	m.noCandidate.WithLabelValues(operation).Add(float64(n))
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics_UsecaseCounters(t *testing.T) {
+	m := New()
+
+	m.ReviewersAssigned("create", 2)
+	m.ReviewersAssigned("create", 1)
+	m.Reassigned("reassign", 1)
+	m.NoCandidate("create", 1)
+	m.NoCandidate("deactivate", 3)
+
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "reviewers assigned on create", got: testutil.ToFloat64(m.reviewersAssigned.WithLabelValues("create")), want: 3},
+		{name: "reassignments", got: testutil.ToFloat64(m.reassignments.WithLabelValues("reassign")), want: 1},
+		{name: "no candidate on create", got: testutil.ToFloat64(m.noCandidate.WithLabelValues("create")), want: 1},
+		{name: "no candidate on deactivate", got: testutil.ToFloat64(m.noCandidate.WithLabelValues("deactivate")), want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetrics_ObserveHTTP(t *testing.T) {
+	m := New()
+
+	m.ObserveHTTP("PullRequestCreatePost", http.MethodPost, http.StatusCreated, 120*time.Millisecond)
+	m.ObserveHTTP("PullRequestCreatePost", http.MethodPost, http.StatusConflict, 10*time.Millisecond)
+
+	if got := testutil.ToFloat64(m.httpRequests.WithLabelValues("PullRequestCreatePost", http.MethodPost, "201")); got != 1 {
+		t.Fatalf("expected one 201 request, got %v", got)
+	}
+	if got := testutil.CollectAndCount(m.httpDuration); got != 1 {
+		t.Fatalf("expected one duration series, got %d", got)
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	conn, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	defer conn.Close()
+
+	m := New()
+	if err := m.RegisterDB(conn, "avito"); err != nil {
+		t.Fatalf("RegisterDB() unexpected error: %v", err)
+	}
+	m.ReviewersAssigned("create", 2)
+	m.ObserveHTTP("TeamGetGet", http.MethodGet, http.StatusOK, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`pr_reviewer_reviewers_assigned_total{operation="create"} 2`,
+		`pr_reviewer_http_requests_total{method="GET",route="TeamGetGet",status="200"} 1`,
+		`pr_reviewer_http_request_duration_seconds_bucket{method="GET",route="TeamGetGet",le="0.3"} 1`,
+		`go_sql_open_connections{db_name="avito"}`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Fatalf("metrics output does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// LogNotifier - напоминания ревьюверам в виде записей лога уровня warn, из которых их забирает сборщик логов.
+type LogNotifier struct {
+	log *slog.Logger
+}
+
+// NewLogNotifier создает уведомления через log, nil - логгер по умолчанию.
+func NewLogNotifier(log *slog.Logger) *LogNotifier {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) NotifyOverdueReview(ctx context.Context, assignment domain.ReviewAssignment, dueAt time.Time) error {
+	n.log.WarnContext(ctx, "review is overdue",
+		slog.String("pull_request_id", assignment.PullRequestID),
+		slog.String("reviewer_id", assignment.ReviewerID),
+		slog.String("author_id", assignment.AuthorID),
+		slog.Time("assigned_at", assignment.AssignedAt),
+		slog.Time("due_at", dueAt),
+	)
+	return nil
+}
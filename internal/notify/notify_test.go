@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"avito-test/internal/domain"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestLogNotifier_NotifyOverdueReview(t *testing.T) {
+	var buf bytes.Buffer
+	notifier := NewLogNotifier(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	assignedAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	dueAt := assignedAt.Add(24 * time.Hour)
+	err := notifier.NotifyOverdueReview(context.Background(), domain.ReviewAssignment{
+		PullRequestID: "pr-1",
+		AuthorID:      "author-1",
+		ReviewerID:    "u1",
+		AssignedAt:    assignedAt,
+	}, dueAt)
+	if err != nil {
+		t.Fatalf("NotifyOverdueReview() unexpected error: %v", err)
+	}
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("log line is not JSON: %q", buf.String())
+	}
+	want := map[string]any{
+		"level":           "WARN",
+		"msg":             "review is overdue",
+		"pull_request_id": "pr-1",
+		"reviewer_id":     "u1",
+		"author_id":       "author-1",
+		"due_at":          dueAt.Format(time.RFC3339),
+	}
+	for key, value := range want {
+		if rec[key] != value {
+			t.Fatalf("expected %s = %v, got %v", key, value, rec[key])
+		}
+	}
+}
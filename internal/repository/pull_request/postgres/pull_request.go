@@ -3,11 +3,15 @@ package postgres
 import (
 	"avito-test/internal/db"
 	"avito-test/internal/domain"
+	transaction "avito-test/internal/repository/transaction/postgres"
 	"avito-test/internal/usecase"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 type PullRequestRepository struct {
@@ -18,8 +22,18 @@ func NewPullRequestRepository(db *db.Queries) *PullRequestRepository {
 	return &PullRequestRepository{db: db}
 }
 
+func (p *PullRequestRepository) queries(ctx context.Context) *db.Queries {
+	return transaction.Queries(ctx, p.db)
+}
+
 func (p *PullRequestRepository) SavePullRequest(ctx context.Context, pull *domain.PullRequest) error {
-	err := p.db.CreatePullRequest(ctx, db.CreatePullRequestParams{Pullrequestid: pull.ID, Name: sql.NullString{String: pull.Name, Valid: true}, Status: string(pull.Status)})
+	err := p.queries(ctx).CreatePullRequest(ctx, db.CreatePullRequestParams{
+		Pullrequestid: pull.ID,
+		Name:          sql.NullString{String: pull.Name, Valid: true},
+		Authorid:      sql.NullString{String: pull.AuthorID, Valid: pull.AuthorID != ""},
+		Status:        string(pull.Status),
+		Minreviewers:  int32(pull.MinReviewers),
+	})
 	if err != nil {
 		return fmt.Errorf("save pull request: %w", err)
 	}
@@ -27,7 +41,7 @@ func (p *PullRequestRepository) SavePullRequest(ctx context.Context, pull *domai
 }
 
 func (p *PullRequestRepository) UpdatePullRequest(ctx context.Context, pull *domain.PullRequest) error {
-	err := p.db.UpdatePullRequestStatus(ctx, db.UpdatePullRequestStatusParams{Pullrequestid: pull.ID, Status: string(pull.Status), Mergedat: sql.NullTime{Time: pull.MergedAt, Valid: true}})
+	err := p.queries(ctx).UpdatePullRequestStatus(ctx, db.UpdatePullRequestStatusParams{Pullrequestid: pull.ID, Status: string(pull.Status), Mergedat: sql.NullTime{Time: pull.MergedAt, Valid: !pull.MergedAt.IsZero()}})
 	if err != nil {
 		return fmt.Errorf("update pull request: %w", err)
 	}
@@ -35,17 +49,17 @@ func (p *PullRequestRepository) UpdatePullRequest(ctx context.Context, pull *dom
 }
 
 func (p *PullRequestRepository) GetPullRequestByID(ctx context.Context, id string) (*domain.PullRequest, error) {
-	pr, err := p.db.GetPullRequestByID(ctx, id)
+	pr, err := p.queries(ctx).GetPullRequestByID(ctx, id)
 	if errors.Is(err, sql.ErrNoRows) {
-		return nil, usecase.ErrPullRequestAlreadyExists
+		return nil, usecase.ErrPullRequestNotFound
 	} else if err != nil {
 		return nil, fmt.Errorf("can't get pull request by id: %w", err)
 	}
-	return &domain.PullRequest{ID: pr.Pullrequestid, Name: pr.Name.String, Status: domain.RequestStatus(pr.Status), CreatedAt: pr.Createdat}, nil
+	return mapPullRequest(pr)
 }
 
 func (p *PullRequestRepository) GetPullRequests(ctx context.Context) ([]domain.PullRequest, error) {
-	prs, err := p.db.GetPullRequests(ctx)
+	prs, err := p.queries(ctx).GetPullRequests(ctx)
 	if errors.Is(err, sql.ErrNoRows) {
 		return []domain.PullRequest{}, nil
 	} else if err != nil {
@@ -53,7 +67,85 @@ func (p *PullRequestRepository) GetPullRequests(ctx context.Context) ([]domain.P
 	}
 	result := make([]domain.PullRequest, len(prs))
 	for i, pr := range prs {
-		result[i] = domain.PullRequest{ID: pr.Pullrequestid, Name: pr.Name.String, Status: domain.RequestStatus(pr.Status), CreatedAt: pr.Createdat, MergedAt: pr.Mergedat.Time}
+		mapped, err := mapPullRequest(db.GetPullRequestByIDRow(pr))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *mapped
 	}
 	return result, nil
 }
+
+func (p *PullRequestRepository) SaveReviewDecision(ctx context.Context, review *domain.ReviewDecision) error {
+	err := p.queries(ctx).CreateReviewDecision(ctx, db.CreateReviewDecisionParams{
+		Pullrequestid: review.PullRequestID,
+		Userid:        review.UserID,
+		Decision:      string(review.State),
+		Comment:       review.Comment,
+	})
+	if err != nil {
+		return fmt.Errorf("save review decision: %w", err)
+	}
+	return nil
+}
+
+func (p *PullRequestRepository) SaveMergeOverride(ctx context.Context, override *domain.MergeOverride) error {
+	err := p.queries(ctx).CreateMergeOverride(ctx, db.CreateMergeOverrideParams{
+		Pullrequestid: override.PullRequestID,
+		Actoruserid:   override.ActorUserID,
+		Actortokenid:  override.ActorTokenID,
+		Conditions:    strings.Join(override.Conditions, "; "),
+	})
+	if err != nil {
+		return fmt.Errorf("save merge override: %w", err)
+	}
+	return nil
+}
+
+// reviewRow - элемент json-массива reviews из GetPullRequestByID, created_at приходит с часовым поясом сессии БД.
+type reviewRow struct {
+	UserID    string `json:"user_id"`
+	Decision  string `json:"decision"`
+	Comment   string `json:"comment"`
+	CreatedAt string `json:"created_at"`
+}
+
+func mapPullRequest(pr db.GetPullRequestByIDRow) (*domain.PullRequest, error) {
+	reviewers := make([]string, 0, 2)
+	if len(pr.Reviewers) > 0 {
+		if err := json.Unmarshal(pr.Reviewers, &reviewers); err != nil {
+			return nil, fmt.Errorf("can't decode reviewers of pull request %s: %w", pr.Pullrequestid, err)
+		}
+	}
+	var rows []reviewRow
+	if len(pr.Reviews) > 0 {
+		if err := json.Unmarshal(pr.Reviews, &rows); err != nil {
+			return nil, fmt.Errorf("can't decode reviews of pull request %s: %w", pr.Pullrequestid, err)
+		}
+	}
+	reviews := make([]domain.ReviewDecision, 0, len(rows))
+	for _, row := range rows {
+		createdAt, err := time.Parse(time.RFC3339Nano, row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("can't decode review time of pull request %s: %w", pr.Pullrequestid, err)
+		}
+		reviews = append(reviews, domain.ReviewDecision{
+			PullRequestID: pr.Pullrequestid,
+			UserID:        row.UserID,
+			State:         domain.ReviewState(row.Decision),
+			Comment:       row.Comment,
+			CreatedAt:     createdAt.UTC(),
+		})
+	}
+	return &domain.PullRequest{
+		ID:                  pr.Pullrequestid,
+		Name:                pr.Name.String,
+		AuthorID:            pr.Authorid.String,
+		Status:              domain.RequestStatus(pr.Status),
+		AssignedReviewersID: reviewers,
+		MinReviewers:        int(pr.Minreviewers),
+		Reviews:             reviews,
+		CreatedAt:           pr.Createdat,
+		MergedAt:            pr.Mergedat.Time,
+	}, nil
+}
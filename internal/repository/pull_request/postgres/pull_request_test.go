@@ -5,9 +5,12 @@ import (
 	"avito-test/internal/usecase"
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
@@ -143,21 +146,21 @@ func TestPullRequestRepository_GetPullRequestByID_Errors(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "not found returns nil",
+			name: "not found returns ErrPullRequestNotFound",
 			args: args{id: "missing"},
 			mock: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(regexp.QuoteMeta("FROM pull_requests WHERE pullrequestid =")).
+				m.ExpectQuery(regexp.QuoteMeta("WHERE pr.pullrequestid = $1")).
 					WithArgs("missing").
 					WillReturnError(sql.ErrNoRows)
 			},
 			wantNil: true,
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name: "db error",
 			args: args{id: "pr-1"},
 			mock: func(m sqlmock.Sqlmock) {
-				m.ExpectQuery(regexp.QuoteMeta("FROM pull_requests WHERE pullrequestid =")).
+				m.ExpectQuery(regexp.QuoteMeta("WHERE pr.pullrequestid = $1")).
 					WithArgs("pr-1").
 					WillReturnError(errors.New("select failed"))
 			},
@@ -235,3 +238,209 @@ func TestPullRequestRepository_GetPullRequests_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestPullRequestRepository_GetPullRequestByID_Found(t *testing.T) {
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	mergedAt := time.Date(2025, 10, 24, 12, 34, 56, 0, time.UTC)
+	reviewedAt := time.Date(2025, 10, 24, 11, 15, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		name string
+		row  []driver.Value
+		want *domain.PullRequest
+	}{
+		{
+			name: "open with reviewers",
+			row: []driver.Value{"pr-1", "Add search", "u1", "OPEN", createdAt, nil, int64(1), []byte(`["u2","u3"]`),
+				[]byte(`[{"user_id":"u2","decision":"APPROVED","comment":"lgtm","created_at":"2025-10-24T11:15:00.123456+00:00"}]`)},
+			want: &domain.PullRequest{
+				ID:                  "pr-1",
+				Name:                "Add search",
+				AuthorID:            "u1",
+				Status:              domain.RequestStatusOpen,
+				AssignedReviewersID: []string{"u2", "u3"},
+				CreatedAt:           createdAt,
+				MinReviewers:        1,
+				Reviews: []domain.ReviewDecision{
+					{PullRequestID: "pr-1", UserID: "u2", State: domain.ReviewStateApproved, Comment: "lgtm", CreatedAt: reviewedAt},
+				},
+			},
+		},
+		{
+			name: "merged without reviewers",
+			row:  []driver.Value{"pr-1", "Add search", "u1", "MERGED", createdAt, mergedAt, int64(0), []byte(`[]`), []byte(`[]`)},
+			want: &domain.PullRequest{
+				ID:                  "pr-1",
+				Name:                "Add search",
+				AuthorID:            "u1",
+				Status:              domain.RequestStatusMerged,
+				AssignedReviewersID: []string{},
+				CreatedAt:           createdAt,
+				MergedAt:            mergedAt,
+				Reviews:             []domain.ReviewDecision{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			rows := sqlmock.NewRows([]string{"pullrequestid", "name", "authorid", "status", "createdat", "mergedat", "minreviewers", "reviewers", "reviews"}).
+				AddRow(tt.row...)
+			mock.ExpectQuery(regexp.QuoteMeta("WHERE pr.pullrequestid = $1")).
+				WithArgs("pr-1").
+				WillReturnRows(rows)
+
+			repo := &PullRequestRepository{db: queries}
+
+			got, err := repo.GetPullRequestByID(context.Background(), "pr-1")
+			if err != nil {
+				t.Fatalf("GetPullRequestByID() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetPullRequestByID() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestRepository_Reviews_AfterReassignment(t *testing.T) {
+	createdAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+	reviewedAt := time.Date(2025, 10, 24, 11, 15, 0, 0, time.UTC)
+
+	// u2 переназначен после своего решения, u3 назначен вместо выбывшего ревьювера:
+	// решения до назначения в выборку не попадают, остается только свежий ответ u3
+	row := []driver.Value{"pr-1", "Add search", "u1", "OPEN", createdAt, nil, int64(2), []byte(`["u2","u3"]`),
+		[]byte(`[{"user_id":"u3","decision":"APPROVED","comment":"","created_at":"2025-10-24T14:15:00+03:00"}]`)}
+	want := &domain.PullRequest{
+		ID:                  "pr-1",
+		Name:                "Add search",
+		AuthorID:            "u1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"u2", "u3"},
+		CreatedAt:           createdAt,
+		MinReviewers:        2,
+		Reviews: []domain.ReviewDecision{
+			{PullRequestID: "pr-1", UserID: "u3", State: domain.ReviewStateApproved, CreatedAt: reviewedAt},
+		},
+	}
+
+	tests := []struct {
+		name string
+		call func(*PullRequestRepository) (*domain.PullRequest, error)
+	}{
+		{
+			name: "by id",
+			call: func(repo *PullRequestRepository) (*domain.PullRequest, error) {
+				return repo.GetPullRequestByID(context.Background(), "pr-1")
+			},
+		},
+		{
+			name: "list",
+			call: func(repo *PullRequestRepository) (*domain.PullRequest, error) {
+				got, err := repo.GetPullRequests(context.Background())
+				if err != nil || len(got) != 1 {
+					return nil, err
+				}
+				return &got[0], nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			rows := sqlmock.NewRows([]string{"pullrequestid", "name", "authorid", "status", "createdat", "mergedat", "minreviewers", "reviewers", "reviews"}).
+				AddRow(row...)
+			mock.ExpectQuery(regexp.QuoteMeta("AND d.createdat >= r.assignedat")).
+				WillReturnRows(rows)
+
+			repo := &PullRequestRepository{db: queries}
+
+			got, err := tt.call(repo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestPullRequestRepository_SaveReviewDecision(t *testing.T) {
+	review := &domain.ReviewDecision{
+		PullRequestID: "pr-1",
+		UserID:        "u2",
+		State:         domain.ReviewStateChangesRequested,
+		Comment:       "fix tests",
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO review_decisions")).
+					WithArgs("pr-1", "u2", "CHANGES_REQUESTED", "fix tests").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO review_decisions")).
+					WillReturnError(errors.New("insert failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &PullRequestRepository{db: queries}
+
+			err := repo.SaveReviewDecision(context.Background(), review)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveReviewDecision() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPullRequestRepository_SaveMergeOverride(t *testing.T) {
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO merge_overrides")).
+		WithArgs("pr-1", "admin-1", "tok-1", "approvals: 0 of 1 required; changes requested by u2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := &PullRequestRepository{db: queries}
+
+	err := repo.SaveMergeOverride(context.Background(), &domain.MergeOverride{
+		PullRequestID: "pr-1",
+		ActorUserID:   "admin-1",
+		ActorTokenID:  "tok-1",
+		Conditions:    []string{"approvals: 0 of 1 required", "changes requested by u2"},
+	})
+	if err != nil {
+		t.Fatalf("SaveMergeOverride() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"avito-test/internal/db"
+	"avito-test/internal/domain"
+	transaction "avito-test/internal/repository/transaction/postgres"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type StatsRepository struct {
+	db *db.Queries
+}
+
+func NewStatsRepository(db *db.Queries) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+func (s *StatsRepository) queries(ctx context.Context) *db.Queries {
+	return transaction.Queries(ctx, s.db)
+}
+
+// filterArgs переводит фильтр в nullable-параметры запросов: пустое поле - NULL, т.е. без ограничения.
+func filterArgs(filter domain.StatsFilter) (sql.NullString, sql.NullTime, sql.NullTime) {
+	return sql.NullString{String: filter.TeamName, Valid: filter.TeamName != ""},
+		sql.NullTime{Time: filter.From.UTC(), Valid: !filter.From.IsZero()},
+		sql.NullTime{Time: filter.To.UTC(), Valid: !filter.To.IsZero()}
+}
+
+func (s *StatsRepository) GetUserAssignmentStats(ctx context.Context, filter domain.StatsFilter) ([]domain.UserAssignmentStats, error) {
+	team, from, to := filterArgs(filter)
+	rows, err := s.queries(ctx).GetReviewerAssignmentStats(ctx, db.GetReviewerAssignmentStatsParams{Teamname: team, CreatedFrom: from, CreatedTo: to})
+	if err != nil {
+		return nil, fmt.Errorf("can't get user assignment stats: %w", err)
+	}
+	result := make([]domain.UserAssignmentStats, len(rows))
+	for i, row := range rows {
+		result[i] = domain.UserAssignmentStats{UserID: row.Userid, Open: int(row.OpenReviews), Merged: int(row.MergedReviews)}
+	}
+	return result, nil
+}
+
+func (s *StatsRepository) GetPullRequestReviewerStats(ctx context.Context, filter domain.StatsFilter) ([]domain.PullRequestReviewerStats, error) {
+	team, from, to := filterArgs(filter)
+	rows, err := s.queries(ctx).GetPullRequestReviewerStats(ctx, db.GetPullRequestReviewerStatsParams{Teamname: team, CreatedFrom: from, CreatedTo: to})
+	if err != nil {
+		return nil, fmt.Errorf("can't get pull request reviewer stats: %w", err)
+	}
+	result := make([]domain.PullRequestReviewerStats, len(rows))
+	for i, row := range rows {
+		result[i] = domain.PullRequestReviewerStats{
+			PullRequestID: row.Pullrequestid,
+			Status:        domain.RequestStatus(row.Status),
+			Reviewers:     int(row.Reviewers),
+		}
+	}
+	return result, nil
+}
+
+func (s *StatsRepository) GetTeamStats(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamStats, error) {
+	team, from, to := filterArgs(filter)
+	rows, err := s.queries(ctx).GetTeamStats(ctx, db.GetTeamStatsParams{Teamname: team, CreatedFrom: from, CreatedTo: to})
+	if err != nil {
+		return nil, fmt.Errorf("can't get team stats: %w", err)
+	}
+	result := make([]domain.TeamStats, len(rows))
+	for i, row := range rows {
+		result[i] = domain.TeamStats{
+			TeamName:           row.Teamname,
+			OpenPullRequests:   int(row.OpenPullRequests),
+			MergedPullRequests: int(row.MergedPullRequests),
+			Assignments:        int(row.Assignments),
+		}
+	}
+	return result, nil
+}
+
+func (s *StatsRepository) GetMergeTimeStats(ctx context.Context, filter domain.StatsFilter) (*domain.MergeTimeStats, error) {
+	team, from, to := filterArgs(filter)
+	row, err := s.queries(ctx).GetMergeTimeStats(ctx, db.GetMergeTimeStatsParams{Teamname: team, CreatedFrom: from, CreatedTo: to})
+	if err != nil {
+		return nil, fmt.Errorf("can't get merge time stats: %w", err)
+	}
+	return &domain.MergeTimeStats{
+		MergedPullRequests: int(row.MergedPullRequests),
+		Median:             time.Duration(row.MedianMergeSeconds * float64(time.Second)),
+	}, nil
+}
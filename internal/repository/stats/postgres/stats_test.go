@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"avito-test/internal/domain"
+	"avito-test/internal/usecase"
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestStatsRepository_GetUserAssignmentStats(t *testing.T) {
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  domain.StatsFilter
+		mock    func(sqlmock.Sqlmock)
+		want    []domain.UserAssignmentStats
+		wantErr bool
+	}{
+		{
+			name:   "without filter",
+			filter: domain.StatsFilter{},
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"userid", "open_reviews", "merged_reviews"}).
+					AddRow("user-1", int64(2), int64(1)).
+					AddRow("user-2", int64(0), int64(3))
+				m.ExpectQuery(regexp.QuoteMeta("-- name: GetReviewerAssignmentStats :many")).
+					WithArgs(nil, nil, nil).
+					WillReturnRows(rows)
+			},
+			want: []domain.UserAssignmentStats{
+				{UserID: "user-1", Open: 2, Merged: 1},
+				{UserID: "user-2", Open: 0, Merged: 3},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "team and from",
+			filter: domain.StatsFilter{TeamName: "team-1", From: from},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: GetReviewerAssignmentStats :many")).
+					WithArgs("team-1", from, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"userid", "open_reviews", "merged_reviews"}))
+			},
+			want:    []domain.UserAssignmentStats{},
+			wantErr: false,
+		},
+		{
+			name:   "db error",
+			filter: domain.StatsFilter{},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: GetReviewerAssignmentStats :many")).
+					WillReturnError(errors.New("select failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &StatsRepository{db: queries}
+
+			got, err := repo.GetUserAssignmentStats(context.Background(), tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetUserAssignmentStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetUserAssignmentStats() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsRepository_GetPullRequestReviewerStats(t *testing.T) {
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"pullrequestid", "status", "reviewers"}).
+		AddRow("pr-1", "OPEN", int64(2)).
+		AddRow("pr-2", "MERGED", int64(0))
+	mock.ExpectQuery(regexp.QuoteMeta("-- name: GetPullRequestReviewerStats :many")).
+		WillReturnRows(rows)
+
+	repo := &StatsRepository{db: queries}
+
+	got, err := repo.GetPullRequestReviewerStats(context.Background(), domain.StatsFilter{})
+	if err != nil {
+		t.Fatalf("GetPullRequestReviewerStats() unexpected error: %v", err)
+	}
+	want := []domain.PullRequestReviewerStats{
+		{PullRequestID: "pr-1", Status: domain.RequestStatusOpen, Reviewers: 2},
+		{PullRequestID: "pr-2", Status: domain.RequestStatusMerged, Reviewers: 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetPullRequestReviewerStats() got = %#v, want %#v", got, want)
+	}
+}
+
+func TestStatsRepository_GetTeamStats(t *testing.T) {
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"teamname", "open_pull_requests", "merged_pull_requests", "assignments"}).
+		AddRow("team-1", int64(1), int64(2), int64(5))
+	mock.ExpectQuery(regexp.QuoteMeta("-- name: GetTeamStats :many")).
+		WithArgs("team-1", nil, nil).
+		WillReturnRows(rows)
+
+	repo := &StatsRepository{db: queries}
+
+	got, err := repo.GetTeamStats(context.Background(), domain.StatsFilter{TeamName: "team-1"})
+	if err != nil {
+		t.Fatalf("GetTeamStats() unexpected error: %v", err)
+	}
+	want := []domain.TeamStats{{TeamName: "team-1", OpenPullRequests: 1, MergedPullRequests: 2, Assignments: 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetTeamStats() got = %#v, want %#v", got, want)
+	}
+}
+
+func TestStatsRepository_GetMergeTimeStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    *domain.MergeTimeStats
+		wantErr bool
+	}{
+		{
+			name: "median",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: GetMergeTimeStats :one")).
+					WillReturnRows(sqlmock.NewRows([]string{"merged_pull_requests", "median_merge_seconds"}).
+						AddRow(int64(3), float64(5400.5)))
+			},
+			want:    &domain.MergeTimeStats{MergedPullRequests: 3, Median: 90*time.Minute + 500*time.Millisecond},
+			wantErr: false,
+		},
+		{
+			name: "no merged pull requests",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: GetMergeTimeStats :one")).
+					WillReturnRows(sqlmock.NewRows([]string{"merged_pull_requests", "median_merge_seconds"}).
+						AddRow(int64(0), float64(0)))
+			},
+			want:    &domain.MergeTimeStats{},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: GetMergeTimeStats :one")).
+					WillReturnError(errors.New("select failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &StatsRepository{db: queries}
+
+			got, err := repo.GetMergeTimeStats(context.Background(), domain.StatsFilter{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMergeTimeStats() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetMergeTimeStats() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package postgres
 import (
 	"avito-test/internal/db"
 	"avito-test/internal/domain"
+	transaction "avito-test/internal/repository/transaction/postgres"
+	"avito-test/internal/usecase"
 	"context"
 	"database/sql"
 	"errors"
@@ -17,16 +19,35 @@ func NewTeamRepository(db *db.Queries) *TeamRepository {
 	return &TeamRepository{db: db}
 }
 
+func (t *TeamRepository) queries(ctx context.Context) *db.Queries {
+	return transaction.Queries(ctx, t.db)
+}
+
 func (t *TeamRepository) LinkUserToTeam(ctx context.Context, team *domain.Team, user *domain.User) error {
-	err := t.db.SaveUserTeam(ctx, db.SaveUserTeamParams{Teamname: team.Name, Userid: user.ID})
+	err := t.queries(ctx).SaveUserTeam(ctx, db.SaveUserTeamParams{
+		Teamname: team.Name,
+		Userid:   user.ID,
+		Role:     string(team.RoleOf(user.ID)),
+	})
 	if err != nil {
 		return fmt.Errorf("error saving user team: %w", err)
 	}
 	return nil
 }
 
+func (t *TeamRepository) UnlinkUserFromTeam(ctx context.Context, teamName, userID string) error {
+	deleted, err := t.queries(ctx).DeleteUserTeam(ctx, db.DeleteUserTeamParams{Teamname: teamName, Userid: userID})
+	if err != nil {
+		return fmt.Errorf("can't unlink user from team: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %s is not in team %s", usecase.ErrMemberNotFound, userID, teamName)
+	}
+	return nil
+}
+
 func (t *TeamRepository) SaveTeam(ctx context.Context, team *domain.Team) error {
-	err := t.db.CreateTeam(ctx, team.Name)
+	err := t.queries(ctx).CreateTeam(ctx, team.Name)
 	if err != nil {
 		return fmt.Errorf("can't save new team: %w", err)
 	}
@@ -34,25 +55,27 @@ func (t *TeamRepository) SaveTeam(ctx context.Context, team *domain.Team) error
 }
 
 func (t *TeamRepository) GetTeamByName(ctx context.Context, name string) (*domain.Team, error) {
-	team, err := t.db.GetTeamByName(ctx, name)
+	team, err := t.queries(ctx).GetTeamByName(ctx, name)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	} else if err != nil {
 		return nil, fmt.Errorf("can't get team by name: %w", err)
 	}
-	members, err := t.db.GetUsersByTeamName(ctx, name)
+	members, err := t.queries(ctx).GetTeamMembers(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("can't get users by team name: %w", err)
 	}
 	result := make([]domain.User, len(members))
+	roles := make(map[string]domain.TeamRole, len(members))
 	for i, member := range members {
 		result[i] = domain.User{ID: member.Userid, Username: member.Username, IsActive: member.Isactive}
+		roles[member.Userid] = domain.TeamRole(member.Role)
 	}
-	return &domain.Team{Name: team, Members: result}, nil
+	return &domain.Team{Name: team, Members: result, Roles: roles}, nil
 }
 
 func (t *TeamRepository) GetTeams(ctx context.Context) ([]domain.Team, error) {
-	teams, err := t.db.GetTeams(ctx)
+	teams, err := t.queries(ctx).GetTeams(ctx)
 	if errors.Is(err, sql.ErrNoRows) {
 		return []domain.Team{}, nil
 	} else if err != nil {
@@ -60,11 +83,66 @@ func (t *TeamRepository) GetTeams(ctx context.Context) ([]domain.Team, error) {
 	}
 	result := make([]domain.Team, len(teams))
 	for i, team := range teams {
-		resTeam, err := t.GetTeamByName(ctx, team)
-		if err != nil {
-			return nil, fmt.Errorf("can't get team by name: %w", err)
-		}
-		result[i] = *resTeam
+		result[i] = domain.Team{Name: team}
 	}
 	return result, nil
 }
+
+func (t *TeamRepository) IsTeamLead(ctx context.Context, leadID, memberID string) (bool, error) {
+	isLead, err := t.queries(ctx).IsTeamLead(ctx, db.IsTeamLeadParams{LeadID: leadID, MemberID: memberID})
+	if err != nil {
+		return false, fmt.Errorf("can't check team lead: %w", err)
+	}
+	return isLead, nil
+}
+
+func (t *TeamRepository) DeleteTeam(ctx context.Context, teamName string) ([]string, error) {
+	members, err := t.queries(ctx).DeleteTeamMembers(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("can't unlink team members: %w", err)
+	}
+	deleted, err := t.queries(ctx).DeleteTeam(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("can't delete team: %w", err)
+	}
+	if deleted == 0 {
+		return nil, usecase.ErrTeamNotFound
+	}
+	if members == nil {
+		members = []string{}
+	}
+	return members, nil
+}
+
+func (t *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	settings, err := t.queries(ctx).GetTeamSettings(ctx, teamName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("can't get team settings: %w", err)
+	}
+	return &domain.TeamSettings{
+		TeamName:           settings.Teamname,
+		MinReviewers:       int(settings.Minreviewers),
+		MaxReviewers:       int(settings.Maxreviewers),
+		RequiredApprovals:  int(settings.Requiredapprovals),
+		ReviewSLAHours:     int(settings.Reviewslahours),
+		ReassignAfterHours: int(settings.Reassignafterhours),
+		UpdatedAt:          settings.Updatedat,
+	}, nil
+}
+
+func (t *TeamRepository) SaveTeamSettings(ctx context.Context, settings *domain.TeamSettings) error {
+	err := t.queries(ctx).UpsertTeamSettings(ctx, db.UpsertTeamSettingsParams{
+		Teamname:           settings.TeamName,
+		Minreviewers:       int32(settings.MinReviewers),
+		Maxreviewers:       int32(settings.MaxReviewers),
+		Requiredapprovals:  int32(settings.RequiredApprovals),
+		Reviewslahours:     int32(settings.ReviewSLAHours),
+		Reassignafterhours: int32(settings.ReassignAfterHours),
+	})
+	if err != nil {
+		return fmt.Errorf("can't save team settings: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
@@ -101,6 +102,29 @@ func TestTeamRepository_GetTeamByName(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "members with roles",
+			args: args{name: "team-1"},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM teams WHERE teamname =")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"teamname"}).AddRow("team-1"))
+				m.ExpectQuery(regexp.QuoteMeta("JOIN users_team ut ON ut.userid = u.userid")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"userid", "username", "isactive", "role"}).
+						AddRow("u1", "Alice", true, "lead").
+						AddRow("u2", "Bob", false, "member"))
+			},
+			want: &domain.Team{
+				Name: "team-1",
+				Members: []domain.User{
+					{ID: "u1", Username: "Alice", IsActive: true},
+					{ID: "u2", Username: "Bob", IsActive: false},
+				},
+				Roles: map[string]domain.TeamRole{"u1": domain.TeamRoleLead, "u2": domain.TeamRoleMember},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -176,3 +200,275 @@ func TestTeamRepository_GetTeams(t *testing.T) {
 		})
 	}
 }
+
+func TestTeamRepository_LinkUserToTeam(t *testing.T) {
+	team := &domain.Team{Name: "team-1", Roles: map[string]domain.TeamRole{"u1": domain.TeamRoleLead}}
+
+	tests := []struct {
+		name     string
+		user     *domain.User
+		wantRole string
+	}{
+		{name: "lead", user: &domain.User{ID: "u1"}, wantRole: "lead"},
+		{name: "member by default", user: &domain.User{ID: "u2"}, wantRole: "member"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users_team (teamname, userid, role)")).
+				WithArgs("team-1", tt.user.ID, tt.wantRole).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			repo := &TeamRepository{db: queries}
+
+			if err := repo.LinkUserToTeam(context.Background(), team, tt.user); err != nil {
+				t.Fatalf("LinkUserToTeam() unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestTeamRepository_IsTeamLead(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "lead",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("AND lead.role = 'lead'")).
+					WithArgs("u1", "u2").
+					WillReturnRows(sqlmock.NewRows([]string{"is_lead"}).AddRow(true))
+			},
+			want: true,
+		},
+		{
+			name: "not lead",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("AND lead.role = 'lead'")).
+					WithArgs("u1", "u2").
+					WillReturnRows(sqlmock.NewRows([]string{"is_lead"}).AddRow(false))
+			},
+			want: false,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("AND lead.role = 'lead'")).
+					WithArgs("u1", "u2").
+					WillReturnError(errors.New("select failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TeamRepository{db: queries}
+
+			got, err := repo.IsTeamLead(context.Background(), "u1", "u2")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IsTeamLead() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("IsTeamLead() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamRepository_UnlinkUserFromTeam(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "ok",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("DELETE FROM users_team WHERE teamname = $1 AND userid = $2")).
+					WithArgs("team-1", "u1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not a member",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("DELETE FROM users_team WHERE teamname = $1 AND userid = $2")).
+					WithArgs("team-1", "u1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: usecase.ErrMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TeamRepository{db: queries}
+
+			err := repo.UnlinkUserFromTeam(context.Background(), "team-1", "u1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UnlinkUserFromTeam() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTeamRepository_DeleteTeam(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "members unlinked before team is deleted",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("DELETE FROM users_team WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}).AddRow("u1").AddRow("u2"))
+				m.ExpectExec(regexp.QuoteMeta("DELETE FROM teams WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: []string{"u1", "u2"},
+		},
+		{
+			name: "empty team",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("DELETE FROM users_team WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}))
+				m.ExpectExec(regexp.QuoteMeta("DELETE FROM teams WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: []string{},
+		},
+		{
+			name: "not found",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("DELETE FROM users_team WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}))
+				m.ExpectExec(regexp.QuoteMeta("DELETE FROM teams WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: usecase.ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TeamRepository{db: queries}
+
+			got, err := repo.DeleteTeam(context.Background(), "team-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteTeam() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DeleteTeam() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamRepository_GetTeamSettings(t *testing.T) {
+	updatedAt := time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    *domain.TeamSettings
+		wantErr bool
+	}{
+		{
+			name: "saved settings",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM team_settings WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"teamname", "minreviewers", "maxreviewers", "updatedat", "requiredapprovals", "reviewslahours", "reassignafterhours"}).
+						AddRow("team-1", int64(1), int64(3), updatedAt, int64(2), int64(16), int64(40)))
+			},
+			want: &domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 3, RequiredApprovals: 2, ReviewSLAHours: 16, ReassignAfterHours: 40, UpdatedAt: updatedAt},
+		},
+		{
+			name: "no settings",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM team_settings WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnRows(sqlmock.NewRows([]string{"teamname", "minreviewers", "maxreviewers", "updatedat", "requiredapprovals", "reviewslahours", "reassignafterhours"}))
+			},
+			want: nil,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM team_settings WHERE teamname = $1")).
+					WithArgs("team-1").
+					WillReturnError(errors.New("select failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TeamRepository{db: queries}
+
+			got, err := repo.GetTeamSettings(context.Background(), "team-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetTeamSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetTeamSettings() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamRepository_SaveTeamSettings(t *testing.T) {
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO team_settings (teamname, minreviewers, maxreviewers, requiredapprovals, reviewslahours, reassignafterhours)")).
+		WithArgs("team-1", int32(1), int32(3), int32(2), int32(24), int32(48)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := &TeamRepository{db: queries}
+
+	err := repo.SaveTeamSettings(context.Background(), &domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 3, RequiredApprovals: 2, ReviewSLAHours: 24, ReassignAfterHours: 48})
+	if err != nil {
+		t.Fatalf("SaveTeamSettings() unexpected error: %v", err)
+	}
+}
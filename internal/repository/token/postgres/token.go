@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"avito-test/internal/db"
+	"avito-test/internal/domain"
+	transaction "avito-test/internal/repository/transaction/postgres"
+	"avito-test/internal/usecase"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type TokenRepository struct {
+	db *db.Queries
+}
+
+func NewTokenRepository(db *db.Queries) *TokenRepository {
+	return &TokenRepository{db: db}
+}
+
+func (t *TokenRepository) queries(ctx context.Context) *db.Queries {
+	return transaction.Queries(ctx, t.db)
+}
+
+func (t *TokenRepository) SaveToken(ctx context.Context, token *domain.APIToken) error {
+	if token == nil {
+		return errors.New("token is nil")
+	}
+	err := t.queries(ctx).CreateAPIToken(ctx, db.CreateAPITokenParams{
+		Tokenid:   token.ID,
+		Name:      token.Name,
+		Tokenhash: token.Hash,
+		Scope:     string(token.Scope),
+		Userid:    sql.NullString{String: token.UserID, Valid: token.UserID != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("can't save token: %w", err)
+	}
+	return nil
+}
+
+func (t *TokenRepository) GetTokenByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	token, err := t.queries(ctx).GetAPITokenByHash(ctx, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrTokenNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("can't get token by hash: %w", err)
+	}
+	result := mapToken(token)
+	return &result, nil
+}
+
+func (t *TokenRepository) GetTokens(ctx context.Context) ([]domain.APIToken, error) {
+	tokens, err := t.queries(ctx).GetAPITokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get tokens: %w", err)
+	}
+	result := make([]domain.APIToken, len(tokens))
+	for i, token := range tokens {
+		result[i] = mapToken(token)
+	}
+	return result, nil
+}
+
+func (t *TokenRepository) RevokeToken(ctx context.Context, id string) error {
+	revoked, err := t.queries(ctx).RevokeAPIToken(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't revoke token: %w", err)
+	}
+	if revoked == 0 {
+		return usecase.ErrTokenNotFound
+	}
+	return nil
+}
+
+func mapToken(token db.ApiToken) domain.APIToken {
+	return domain.APIToken{
+		ID:        token.Tokenid,
+		Name:      token.Name,
+		Hash:      token.Tokenhash,
+		Scope:     domain.TokenScope(token.Scope),
+		UserID:    token.Userid.String,
+		CreatedAt: token.Createdat,
+		RevokedAt: token.Revokedat.Time,
+	}
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"avito-test/internal/domain"
+	"avito-test/internal/usecase"
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var tokenColumns = []string{"tokenid", "name", "tokenhash", "scope", "userid", "createdat", "revokedat"}
+
+func TestTokenRepository_SaveToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   *domain.APIToken
+		mock    func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name:  "admin token without user",
+			token: &domain.APIToken{ID: "tok_1", Name: "ci", Hash: "h1", Scope: domain.TokenScopeAdmin},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO api_tokens")).
+					WithArgs("tok_1", "ci", "h1", "admin", sql.NullString{}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:  "user token",
+			token: &domain.APIToken{ID: "tok_2", Name: "alice", Hash: "h2", Scope: domain.TokenScopeUser, UserID: "u1"},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO api_tokens")).
+					WithArgs("tok_2", "alice", "h2", "user", sql.NullString{String: "u1", Valid: true}).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:    "nil token",
+			token:   nil,
+			mock:    func(m sqlmock.Sqlmock) {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TokenRepository{db: queries}
+
+			err := repo.SaveToken(context.Background(), tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestTokenRepository_GetTokenByHash(t *testing.T) {
+	createdAt := time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    *domain.APIToken
+		wantErr error
+	}{
+		{
+			name: "found",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(tokenColumns).
+					AddRow("tok_1", "alice", "hash", "user", "u1", createdAt, nil)
+				m.ExpectQuery(regexp.QuoteMeta("FROM api_tokens")).
+					WithArgs("hash").
+					WillReturnRows(rows)
+			},
+			want: &domain.APIToken{
+				ID: "tok_1", Name: "alice", Hash: "hash", Scope: domain.TokenScopeUser, UserID: "u1", CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "not found or revoked",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM api_tokens")).
+					WithArgs("hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: usecase.ErrTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TokenRepository{db: queries}
+
+			got, err := repo.GetTokenByHash(context.Background(), "hash")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTokenByHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetTokenByHash() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenRepository_GetTokens(t *testing.T) {
+	createdAt := time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC)
+	revokedAt := createdAt.Add(time.Hour)
+
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows(tokenColumns).
+		AddRow("tok_1", "ci", "h1", "admin", nil, createdAt, revokedAt).
+		AddRow("tok_2", "alice", "h2", "user", "u1", createdAt, nil)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM api_tokens")).WillReturnRows(rows)
+
+	repo := &TokenRepository{db: queries}
+
+	got, err := repo.GetTokens(context.Background())
+	if err != nil {
+		t.Fatalf("GetTokens() unexpected error: %v", err)
+	}
+	want := []domain.APIToken{
+		{ID: "tok_1", Name: "ci", Hash: "h1", Scope: domain.TokenScopeAdmin, CreatedAt: createdAt, RevokedAt: revokedAt},
+		{ID: "tok_2", Name: "alice", Hash: "h2", Scope: domain.TokenScopeUser, UserID: "u1", CreatedAt: createdAt},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetTokens() got = %#v, want %#v", got, want)
+	}
+}
+
+func TestTokenRepository_RevokeToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "revoked",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("UPDATE api_tokens SET revokedat = now()")).
+					WithArgs("tok_1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "missing or already revoked",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("UPDATE api_tokens SET revokedat = now()")).
+					WithArgs("tok_1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: usecase.ErrTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &TokenRepository{db: queries}
+
+			err := repo.RevokeToken(context.Background(), "tok_1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RevokeToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"avito-test/internal/db"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type txKey struct{}
+
+type Beginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+type Transactor struct {
+	conn Beginner
+	db   *db.Queries
+	wrap func(db.DBTX) db.DBTX
+}
+
+type Option func(*Transactor)
+
+// WithTxWrapper - обертка над *sql.Tx, например для трассировки запросов внутри транзакции.
+func WithTxWrapper(wrap func(db.DBTX) db.DBTX) Option {
+	return func(t *Transactor) {
+		t.wrap = wrap
+	}
+}
+
+func NewTransactor(conn Beginner, db *db.Queries, opts ...Option) *Transactor {
+	t := &Transactor{conn: conn, db: db}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// WithinTransaction открывает транзакцию и кладет в контекст *db.Queries, привязанный к ней.
+// Если транзакция уже открыта выше по стеку, fn выполняется в ней же.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*db.Queries); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, t.txQueries(tx))); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("can't rollback transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (t *Transactor) txQueries(tx *sql.Tx) *db.Queries {
+	if t.wrap != nil {
+		return db.New(t.wrap(tx))
+	}
+	return t.db.WithTx(tx)
+}
+
+// Queries возвращает *db.Queries текущей транзакции из контекста или fallback, если транзакции нет.
+func Queries(ctx context.Context, fallback *db.Queries) *db.Queries {
+	if q, ok := ctx.Value(txKey{}).(*db.Queries); ok {
+		return q
+	}
+	return fallback
+}
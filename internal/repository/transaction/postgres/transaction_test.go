@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"avito-test/internal/db"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestTransactor_WithinTransaction(t *testing.T) {
+	errFn := errors.New("fn failed")
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		fn      func(ctx context.Context, fallback *db.Queries) error
+		wantErr error
+	}{
+		{
+			name: "commit on success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO teams (teamname)")).
+					WithArgs("team-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+			},
+			fn: func(ctx context.Context, fallback *db.Queries) error {
+				return Queries(ctx, fallback).CreateTeam(ctx, "team-1")
+			},
+			wantErr: nil,
+		},
+		{
+			name: "rollback on fn error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO teams (teamname)")).
+					WithArgs("team-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectRollback()
+			},
+			fn: func(ctx context.Context, fallback *db.Queries) error {
+				if err := Queries(ctx, fallback).CreateTeam(ctx, "team-1"); err != nil {
+					return err
+				}
+				return errFn
+			},
+			wantErr: errFn,
+		},
+		{
+			name: "nested call reuses transaction",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectCommit()
+			},
+			fn: func(ctx context.Context, fallback *db.Queries) error {
+				outer := Queries(ctx, fallback)
+				return NewTransactor(nil, fallback).WithinTransaction(ctx, func(ctx context.Context) error {
+					if Queries(ctx, fallback) != outer {
+						return errors.New("nested call opened a new transaction")
+					}
+					return nil
+				})
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New(): %v", err)
+			}
+			defer conn.Close()
+
+			tt.mock(mock)
+
+			queries := db.New(conn)
+			transactor := NewTransactor(conn, queries)
+
+			err = transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				if Queries(ctx, queries) == queries {
+					t.Fatalf("expected transaction-bound queries in context")
+				}
+				return tt.fn(ctx, queries)
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithinTransaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatalf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestTransactor_WithinTransaction_BeginError(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	defer conn.Close()
+
+	mock.ExpectBegin().WillReturnError(errors.New("begin failed"))
+
+	called := false
+	err = NewTransactor(conn, db.New(conn)).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("expected begin error")
+	}
+	if called {
+		t.Fatalf("fn must not be called when transaction can't begin")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestTransactor_WithinTransaction_RollbackOnPanic(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	defer conn.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic to be re-raised")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatalf("unmet expectations: %v", err)
+		}
+	}()
+
+	_ = NewTransactor(conn, db.New(conn)).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	})
+}
+
+func TestTransactor_WithinTransaction_WrapsTx(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	defer conn.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO teams (teamname)")).
+		WithArgs("team-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	wrapped := 0
+	wrap := func(inner db.DBTX) db.DBTX {
+		wrapped++
+		return inner
+	}
+	queries := db.New(conn)
+
+	err = NewTransactor(conn, queries, WithTxWrapper(wrap)).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return Queries(ctx, queries).CreateTeam(ctx, "team-1")
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction() unexpected error: %v", err)
+	}
+	if wrapped != 1 {
+		t.Fatalf("expected tx to be wrapped once, got %d", wrapped)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestQueries_WithoutTransactionReturnsFallback(t *testing.T) {
+	fallback := db.New(nil)
+	if got := Queries(context.Background(), fallback); got != fallback {
+		t.Fatalf("Queries() got = %p, want fallback %p", got, fallback)
+	}
+}
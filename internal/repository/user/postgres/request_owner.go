@@ -3,10 +3,13 @@ package postgres
 import (
 	"avito-test/internal/db"
 	"avito-test/internal/domain"
+	transaction "avito-test/internal/repository/transaction/postgres"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 )
 
 type RequestOwnerRepository struct {
@@ -17,8 +20,12 @@ func NewRequestOwnerRepository(db *db.Queries) *RequestOwnerRepository {
 	return &RequestOwnerRepository{db: db}
 }
 
+func (r *RequestOwnerRepository) queries(ctx context.Context) *db.Queries {
+	return transaction.Queries(ctx, r.db)
+}
+
 func (r *RequestOwnerRepository) SaveRequestOwner(ctx context.Context, requestOwner *domain.RequestOwner) error {
-	err := r.db.AssignUserPullRequest(ctx, db.AssignUserPullRequestParams{Userid: requestOwner.UserID, Pullrequestid: requestOwner.RequestID, Role: string(requestOwner.Role)})
+	err := r.queries(ctx).AssignUserPullRequest(ctx, db.AssignUserPullRequestParams{Userid: requestOwner.UserID, Pullrequestid: requestOwner.RequestID, Role: string(requestOwner.Role)})
 	if err != nil {
 		return fmt.Errorf("can't save request owner: %w", err)
 	}
@@ -26,7 +33,7 @@ func (r *RequestOwnerRepository) SaveRequestOwner(ctx context.Context, requestOw
 }
 
 func (r *RequestOwnerRepository) DeleteRequestOwner(ctx context.Context, requestOwner *domain.RequestOwner) error {
-	err := r.db.DeletePullRequestAssignOfUser(ctx, db.DeletePullRequestAssignOfUserParams{Userid: requestOwner.UserID, Pullrequestid: requestOwner.RequestID})
+	err := r.queries(ctx).DeletePullRequestAssignOfUser(ctx, db.DeletePullRequestAssignOfUserParams{Userid: requestOwner.UserID, Pullrequestid: requestOwner.RequestID})
 	if err != nil {
 		return fmt.Errorf("can't delete request owner: %w", err)
 	}
@@ -34,7 +41,7 @@ func (r *RequestOwnerRepository) DeleteRequestOwner(ctx context.Context, request
 }
 
 func (r *RequestOwnerRepository) GetRequestsByUserID(ctx context.Context, userID string) ([]domain.RequestOwner, error) {
-	user, err := r.db.GetUsersAssignedPullRequest(ctx, userID)
+	user, err := r.queries(ctx).GetUsersAssignedPullRequest(ctx, userID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return []domain.RequestOwner{}, nil
 	} else if err != nil {
@@ -48,7 +55,7 @@ func (r *RequestOwnerRepository) GetRequestsByUserID(ctx context.Context, userID
 }
 
 func (r *RequestOwnerRepository) GetUsersByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.RequestOwner, error) {
-	users, err := r.db.GetListOfUsersByPullRequestID(ctx, pullRequestID)
+	users, err := r.queries(ctx).GetListOfUsersByPullRequestID(ctx, pullRequestID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return []domain.RequestOwner{}, nil
 	} else if err != nil {
@@ -60,3 +67,220 @@ func (r *RequestOwnerRepository) GetUsersByPullRequestID(ctx context.Context, pu
 	}
 	return requestOwners, nil
 }
+
+func (r *RequestOwnerRepository) GetOpenReviewCounts(ctx context.Context) (map[string]int, error) {
+	rows, err := r.queries(ctx).GetOpenReviewCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get open review counts: %w", err)
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Userid] = int(row.OpenReviews)
+	}
+	return counts, nil
+}
+
+func (r *RequestOwnerRepository) SaveExcludedReviewers(ctx context.Context, pullRequestID string, userIDs []string) error {
+	for _, userID := range userIDs {
+		err := r.queries(ctx).CreateExcludedReviewer(ctx, db.CreateExcludedReviewerParams{Pullrequestid: pullRequestID, Userid: userID})
+		if err != nil {
+			return fmt.Errorf("can't save excluded reviewer: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *RequestOwnerRepository) GetExcludedReviewers(ctx context.Context, pullRequestID string) ([]string, error) {
+	userIDs, err := r.queries(ctx).GetPullRequestExcludedReviewers(ctx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get excluded reviewers: %w", err)
+	}
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+	return userIDs, nil
+}
+
+func (r *RequestOwnerRepository) GetPendingReviewAssignments(ctx context.Context) ([]domain.ReviewAssignment, error) {
+	rows, err := r.queries(ctx).GetPendingReviewAssignments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get pending review assignments: %w", err)
+	}
+	assignments := make([]domain.ReviewAssignment, len(rows))
+	for i, row := range rows {
+		assignments[i] = domain.ReviewAssignment{
+			PullRequestID: row.Pullrequestid,
+			AuthorID:      row.Authorid,
+			ReviewerID:    row.Userid,
+			AssignedAt:    row.Assignedat.UTC(),
+			NotifiedAt:    utcTime(row.Notifiedat),
+		}
+	}
+	return assignments, nil
+}
+
+// utcTime приводит время из БД к UTC, пустое значение остается нулевым временем.
+func utcTime(t sql.NullTime) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time.UTC()
+}
+
+func (r *RequestOwnerRepository) MarkAssignmentNotified(ctx context.Context, pullRequestID, userID string, notifiedAt time.Time) error {
+	err := r.queries(ctx).MarkAssignmentNotified(ctx, db.MarkAssignmentNotifiedParams{
+		Notifiedat:    sql.NullTime{Time: notifiedAt, Valid: true},
+		Pullrequestid: pullRequestID,
+		Userid:        userID,
+	})
+	if err != nil {
+		return fmt.Errorf("can't mark assignment notified: %w", err)
+	}
+	return nil
+}
+
+func (r *RequestOwnerRepository) ReplaceTeamReviewers(ctx context.Context, teamName string, userIDs []string) ([]domain.ReviewerReplacement, error) {
+	return r.replaceReviewers(ctx, teamName, userIDs, false)
+}
+
+func (r *RequestOwnerRepository) ReplaceTeamPullRequestReviewers(ctx context.Context, teamName string, userIDs []string) ([]domain.ReviewerReplacement, error) {
+	return r.replaceReviewers(ctx, teamName, userIDs, true)
+}
+
+func (r *RequestOwnerRepository) replaceReviewers(ctx context.Context, teamName string, userIDs []string, teamPullRequestsOnly bool) ([]domain.ReviewerReplacement, error) {
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+	ids, err := json.Marshal(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode user ids: %w", err)
+	}
+	rows, err := r.queries(ctx).ReplaceTeamReviewers(ctx, db.ReplaceTeamReviewersParams{
+		UserIds:              ids,
+		TeamPullRequestsOnly: teamPullRequestsOnly,
+		Teamname:             teamName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't replace team reviewers: %w", err)
+	}
+	replacements := make([]domain.ReviewerReplacement, len(rows))
+	for i, row := range rows {
+		replacements[i] = domain.ReviewerReplacement{
+			PullRequestID: row.Pullrequestid,
+			OldReviewerID: row.OldUserid,
+			NewReviewerID: row.NewUserid.String,
+		}
+	}
+	return replacements, nil
+}
+
+func (r *RequestOwnerRepository) SaveAssignmentEvents(ctx context.Context, events []domain.AssignmentEvent) error {
+	for _, event := range events {
+		err := r.queries(ctx).CreateAssignmentEvent(ctx, db.CreateAssignmentEventParams{
+			Pullrequestid:  event.PullRequestID,
+			Eventtype:      string(event.Type),
+			Userid:         event.UserID,
+			Previoususerid: event.PreviousUserID,
+			Status:         string(event.Status),
+			Actoruserid:    event.ActorUserID,
+			Actortokenid:   event.ActorTokenID,
+			Reason:         event.Reason,
+			Strategy:       event.Strategy,
+		})
+		if err != nil {
+			return fmt.Errorf("can't save assignment event: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *RequestOwnerRepository) GetAssignmentEventsByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.AssignmentEvent, error) {
+	rows, err := r.queries(ctx).GetPullRequestAssignmentEvents(ctx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get pull request assignment events: %w", err)
+	}
+	return assignmentEvents(rows), nil
+}
+
+func (r *RequestOwnerRepository) GetAssignmentEventsByUserID(ctx context.Context, userID string) ([]domain.AssignmentEvent, error) {
+	rows, err := r.queries(ctx).GetUserAssignmentEvents(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get user assignment events: %w", err)
+	}
+	return assignmentEvents(rows), nil
+}
+
+func assignmentEvents(rows []db.AssignmentEvent) []domain.AssignmentEvent {
+	events := make([]domain.AssignmentEvent, len(rows))
+	for i, row := range rows {
+		events[i] = domain.AssignmentEvent{
+			ID:             row.Eventid,
+			PullRequestID:  row.Pullrequestid,
+			Type:           domain.AssignmentEventType(row.Eventtype),
+			UserID:         row.Userid,
+			PreviousUserID: row.Previoususerid,
+			Status:         domain.RequestStatus(row.Status),
+			ActorUserID:    row.Actoruserid,
+			ActorTokenID:   row.Actortokenid,
+			Reason:         row.Reason,
+			Strategy:       row.Strategy,
+			CreatedAt:      row.Createdat,
+		}
+	}
+	return events
+}
+
+func (r *RequestOwnerRepository) SaveAssignmentExplanation(ctx context.Context, explanation *domain.AssignmentExplanation) error {
+	selected, err := json.Marshal(explanation.Selected)
+	if err != nil {
+		return fmt.Errorf("can't encode selected reviewers: %w", err)
+	}
+	candidates, err := json.Marshal(explanation.Candidates)
+	if err != nil {
+		return fmt.Errorf("can't encode candidates: %w", err)
+	}
+	err = r.queries(ctx).CreateAssignmentExplanation(ctx, db.CreateAssignmentExplanationParams{
+		Pullrequestid:  explanation.PullRequestID,
+		Reason:         explanation.Reason,
+		Strategy:       explanation.Strategy,
+		Teamname:       explanation.TeamName,
+		Replaceduserid: explanation.ReplacedUserID,
+		Slots:          int32(explanation.Slots),
+		Selected:       selected,
+		Candidates:     candidates,
+	})
+	if err != nil {
+		return fmt.Errorf("can't save assignment explanation: %w", err)
+	}
+	return nil
+}
+
+func (r *RequestOwnerRepository) GetAssignmentExplanationsByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.AssignmentExplanation, error) {
+	rows, err := r.queries(ctx).GetPullRequestAssignmentExplanations(ctx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get pull request assignment explanations: %w", err)
+	}
+	explanations := make([]domain.AssignmentExplanation, len(rows))
+	for i, row := range rows {
+		explanation := domain.AssignmentExplanation{
+			ID:             row.Explanationid,
+			PullRequestID:  row.Pullrequestid,
+			Reason:         row.Reason,
+			Strategy:       row.Strategy,
+			TeamName:       row.Teamname,
+			ReplacedUserID: row.Replaceduserid,
+			Slots:          int(row.Slots),
+			Selected:       []string{},
+			Candidates:     []domain.CandidateDecision{},
+			CreatedAt:      row.Createdat,
+		}
+		if err := json.Unmarshal(row.Selected, &explanation.Selected); err != nil {
+			return nil, fmt.Errorf("can't decode selected reviewers of explanation %d: %w", row.Explanationid, err)
+		}
+		if err := json.Unmarshal(row.Candidates, &explanation.Candidates); err != nil {
+			return nil, fmt.Errorf("can't decode candidates of explanation %d: %w", row.Explanationid, err)
+		}
+		explanations[i] = explanation
+	}
+	return explanations, nil
+}
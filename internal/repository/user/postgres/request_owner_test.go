@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 )
@@ -217,3 +218,605 @@ func TestRequestOwnerRepository_GetUsersByPullRequestID(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestOwnerRepository_GetOpenReviewCounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name: "counts",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"userid", "open_reviews"}).
+					AddRow("user-1", int64(3)).
+					AddRow("user-2", int64(1))
+				m.ExpectQuery(regexp.QuoteMeta("JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid")).
+					WillReturnRows(rows)
+			},
+			want:    map[string]int{"user-1": 3, "user-2": 1},
+			wantErr: false,
+		},
+		{
+			name: "no open reviews",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid")).
+					WillReturnRows(sqlmock.NewRows([]string{"userid", "open_reviews"}))
+			},
+			want:    map[string]int{},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("JOIN pull_requests pr ON pr.pullrequestid = upr.pullrequestid")).
+					WillReturnError(errors.New("select failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			got, err := repo.GetOpenReviewCounts(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetOpenReviewCounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetOpenReviewCounts() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_GetPendingReviewAssignments(t *testing.T) {
+	assignedAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	notifiedAt := assignedAt.Add(25 * time.Hour)
+	columns := []string{"pullrequestid", "authorid", "userid", "assignedat", "notifiedat"}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []domain.ReviewAssignment
+		wantErr bool
+	}{
+		{
+			name: "assignments",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("pr-1", "author-1", "user-1", assignedAt, nil).
+					AddRow("pr-2", "author-1", "user-2", assignedAt, notifiedAt)
+				m.ExpectQuery(regexp.QuoteMeta("FROM review_decisions rd")).
+					WillReturnRows(rows)
+			},
+			want: []domain.ReviewAssignment{
+				{PullRequestID: "pr-1", AuthorID: "author-1", ReviewerID: "user-1", AssignedAt: assignedAt},
+				{PullRequestID: "pr-2", AuthorID: "author-1", ReviewerID: "user-2", AssignedAt: assignedAt, NotifiedAt: notifiedAt},
+			},
+		},
+		{
+			name: "time with offset is normalized to utc",
+			mock: func(m sqlmock.Sqlmock) {
+				msk := time.FixedZone("MSK", 3*60*60)
+				rows := sqlmock.NewRows(columns).
+					AddRow("pr-1", "author-1", "user-1", assignedAt.In(msk), notifiedAt.In(msk))
+				m.ExpectQuery(regexp.QuoteMeta("FROM review_decisions rd")).
+					WillReturnRows(rows)
+			},
+			want: []domain.ReviewAssignment{
+				{PullRequestID: "pr-1", AuthorID: "author-1", ReviewerID: "user-1", AssignedAt: assignedAt, NotifiedAt: notifiedAt},
+			},
+		},
+		{
+			name: "nothing pending",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM review_decisions rd")).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			want: []domain.ReviewAssignment{},
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM review_decisions rd")).
+					WillReturnError(errors.New("select failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			got, err := repo.GetPendingReviewAssignments(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPendingReviewAssignments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetPendingReviewAssignments() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_MarkAssignmentNotified(t *testing.T) {
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	notifiedAt := time.Date(2025, 10, 21, 9, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users_pull_requests SET notifiedat = $1")).
+		WithArgs(notifiedAt, "pr-1", "user-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := &RequestOwnerRepository{db: queries}
+
+	if err := repo.MarkAssignmentNotified(context.Background(), "pr-1", "user-1", notifiedAt); err != nil {
+		t.Fatalf("MarkAssignmentNotified() unexpected error: %v", err)
+	}
+}
+
+func TestRequestOwnerRepository_SaveExcludedReviewers(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "saved",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO pull_request_excluded_reviewers")).
+					WithArgs("pr-1", "user-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO pull_request_excluded_reviewers")).
+					WithArgs("pr-1", "user-2").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO pull_request_excluded_reviewers")).
+					WithArgs("pr-1", "user-1").
+					WillReturnError(errors.New("insert failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			err := repo.SaveExcludedReviewers(context.Background(), "pr-1", []string{"user-1", "user-2"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveExcludedReviewers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_GetExcludedReviewers(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "excluded",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM pull_request_excluded_reviewers")).
+					WithArgs("pr-1").
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}).AddRow("user-1").AddRow("user-2"))
+			},
+			want: []string{"user-1", "user-2"},
+		},
+		{
+			name: "nothing excluded",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM pull_request_excluded_reviewers")).
+					WithArgs("pr-1").
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}))
+			},
+			want: []string{},
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM pull_request_excluded_reviewers")).
+					WithArgs("pr-1").
+					WillReturnError(errors.New("select failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			got, err := repo.GetExcludedReviewers(context.Background(), "pr-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetExcludedReviewers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetExcludedReviewers() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_SaveAssignmentEvents(t *testing.T) {
+	events := []domain.AssignmentEvent{
+		{PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "user-2", PreviousUserID: "user-1", ActorUserID: "lead-1", ActorTokenID: "tok-1", Reason: domain.AssignmentReasonReassign, Strategy: "random"},
+		{PullRequestID: "pr-1", Type: domain.AssignmentEventMerged, Status: domain.RequestStatusMerged, Reason: domain.AssignmentReasonMerge},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "events saved",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO assignment_events")).
+					WithArgs("pr-1", "REASSIGNED", "user-2", "user-1", "", "lead-1", "tok-1", "reassign", "random").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO assignment_events")).
+					WithArgs("pr-1", "MERGED", "", "", "MERGED", "", "", "merge", "").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+			},
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO assignment_events")).
+					WillReturnError(errors.New("insert failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			err := repo.SaveAssignmentEvents(context.Background(), events)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveAssignmentEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_GetAssignmentEvents(t *testing.T) {
+	createdAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	columns := []string{"eventid", "pullrequestid", "eventtype", "userid", "previoususerid", "status", "actoruserid", "actortokenid", "reason", "strategy", "createdat"}
+
+	tests := []struct {
+		name    string
+		byUser  bool
+		mock    func(sqlmock.Sqlmock)
+		want    []domain.AssignmentEvent
+		wantErr bool
+	}{
+		{
+			name: "pull request history",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "pr-1", "ASSIGNED", "user-1", "", "", "", "", "create", "round_robin", createdAt).
+					AddRow(2, "pr-1", "REASSIGNED", "user-2", "user-1", "", "lead-1", "tok-1", "reassign", "random", createdAt)
+				m.ExpectQuery(regexp.QuoteMeta("WHERE pullrequestid = $1")).
+					WithArgs("pr-1").
+					WillReturnRows(rows)
+			},
+			want: []domain.AssignmentEvent{
+				{ID: 1, PullRequestID: "pr-1", Type: domain.AssignmentEventAssigned, UserID: "user-1", Reason: "create", Strategy: "round_robin", CreatedAt: createdAt},
+				{ID: 2, PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "user-2", PreviousUserID: "user-1", ActorUserID: "lead-1", ActorTokenID: "tok-1", Reason: "reassign", Strategy: "random", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:   "user history",
+			byUser: true,
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(3, "pr-2", "UNASSIGNED", "user-1", "", "", "", "", "close", "", createdAt)
+				m.ExpectQuery(regexp.QuoteMeta("OR previoususerid = $1")).
+					WithArgs("user-1").
+					WillReturnRows(rows)
+			},
+			want: []domain.AssignmentEvent{
+				{ID: 3, PullRequestID: "pr-2", Type: domain.AssignmentEventUnassigned, UserID: "user-1", Reason: "close", CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM assignment_events")).
+					WillReturnError(errors.New("select failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			var (
+				got []domain.AssignmentEvent
+				err error
+			)
+			if tt.byUser {
+				got, err = repo.GetAssignmentEventsByUserID(context.Background(), "user-1")
+			} else {
+				got, err = repo.GetAssignmentEventsByPullRequestID(context.Background(), "pr-1")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAssignmentEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetAssignmentEvents() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_SaveAssignmentExplanation(t *testing.T) {
+	weight := 3
+	explanation := &domain.AssignmentExplanation{
+		PullRequestID: "pr-1",
+		Reason:        domain.AssignmentReasonCreate,
+		Strategy:      "weighted",
+		TeamName:      "team-1",
+		Slots:         1,
+		Selected:      []string{"user-2"},
+		Candidates: []domain.CandidateDecision{
+			{UserID: "user-1", ExcludedReason: domain.CandidateExcludedAuthor},
+			{UserID: "user-2", Eligible: true, Selected: true, Score: &weight},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "explanation saved",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO assignment_explanations")).
+					WithArgs("pr-1", "create", "weighted", "team-1", "", int32(1),
+						[]byte(`["user-2"]`),
+						[]byte(`[{"user_id":"user-1","eligible":false,"excluded_reason":"author","selected":false},{"user_id":"user-2","eligible":true,"selected":true,"score":3}]`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta("INSERT INTO assignment_explanations")).
+					WillReturnError(errors.New("insert failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			err := repo.SaveAssignmentExplanation(context.Background(), explanation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SaveAssignmentExplanation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_GetAssignmentExplanationsByPullRequestID(t *testing.T) {
+	createdAt := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	columns := []string{"explanationid", "pullrequestid", "reason", "strategy", "teamname", "replaceduserid", "slots", "selected", "candidates", "createdat"}
+	draw := 0
+
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []domain.AssignmentExplanation
+		wantErr bool
+	}{
+		{
+			name: "explanations found",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "pr-1", "reassign", "round_robin", "team-1", "user-1", 1,
+						[]byte(`["user-3"]`),
+						[]byte(`[{"user_id":"user-1","eligible":false,"excluded_reason":"already_assigned","selected":false},{"user_id":"user-3","eligible":true,"selected":true,"draw":0}]`),
+						createdAt)
+				m.ExpectQuery(regexp.QuoteMeta("FROM assignment_explanations")).
+					WithArgs("pr-1").
+					WillReturnRows(rows)
+			},
+			want: []domain.AssignmentExplanation{{
+				ID:             1,
+				PullRequestID:  "pr-1",
+				Reason:         "reassign",
+				Strategy:       "round_robin",
+				TeamName:       "team-1",
+				ReplacedUserID: "user-1",
+				Slots:          1,
+				Selected:       []string{"user-3"},
+				Candidates: []domain.CandidateDecision{
+					{UserID: "user-1", ExcludedReason: domain.CandidateExcludedAlreadyAssigned},
+					{UserID: "user-3", Eligible: true, Selected: true, Draw: &draw},
+				},
+				CreatedAt: createdAt,
+			}},
+		},
+		{
+			name: "no explanations",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM assignment_explanations")).
+					WithArgs("pr-1").
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			want: []domain.AssignmentExplanation{},
+		},
+		{
+			name: "broken candidates",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "pr-1", "create", "random", "team-1", "", 2, []byte(`[]`), []byte(`{`), createdAt)
+				m.ExpectQuery(regexp.QuoteMeta("FROM assignment_explanations")).
+					WithArgs("pr-1").
+					WillReturnRows(rows)
+			},
+			wantErr: true,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("FROM assignment_explanations")).
+					WillReturnError(errors.New("select failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			got, err := repo.GetAssignmentExplanationsByPullRequestID(context.Background(), "pr-1")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetAssignmentExplanationsByPullRequestID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("GetAssignmentExplanationsByPullRequestID() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_ReplaceTeamReviewers(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(sqlmock.Sqlmock)
+		want    []domain.ReviewerReplacement
+		wantErr bool
+	}{
+		{
+			name: "reassigned and unfilled",
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"pullrequestid", "old_userid", "new_userid"}).
+					AddRow("pr-1", "user-1", "user-3").
+					AddRow("pr-2", "user-1", nil)
+				// исключенные автором PR пользователи в кандидаты не попадают
+				m.ExpectQuery(regexp.QuoteMeta("FROM pull_request_excluded_reviewers x")).
+					WithArgs([]byte(`["user-1"]`), false, "team-1").
+					WillReturnRows(rows)
+			},
+			want: []domain.ReviewerReplacement{
+				{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+				{PullRequestID: "pr-2", OldReviewerID: "user-1", NewReviewerID: ""},
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: ReplaceTeamReviewers :many")).
+					WillReturnError(errors.New("select failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &RequestOwnerRepository{db: queries}
+
+			got, err := repo.ReplaceTeamReviewers(context.Background(), "team-1", []string{"user-1"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplaceTeamReviewers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ReplaceTeamReviewers() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestOwnerRepository_ReplaceTeamPullRequestReviewers(t *testing.T) {
+	queries, mock, cleanup := usecase.NewTestQueries(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"pullrequestid", "old_userid", "new_userid"}).
+		AddRow("pr-1", "user-1", "user-2")
+	mock.ExpectQuery(regexp.QuoteMeta("-- name: ReplaceTeamReviewers :many")).
+		WithArgs([]byte(`["user-1"]`), true, "team-1").
+		WillReturnRows(rows)
+
+	repo := &RequestOwnerRepository{db: queries}
+
+	got, err := repo.ReplaceTeamPullRequestReviewers(context.Background(), "team-1", []string{"user-1"})
+	if err != nil {
+		t.Fatalf("ReplaceTeamPullRequestReviewers() unexpected error: %v", err)
+	}
+	want := []domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReplaceTeamPullRequestReviewers() got = %#v, want %#v", got, want)
+	}
+}
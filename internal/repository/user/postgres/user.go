@@ -3,9 +3,11 @@ package postgres
 import (
 	"avito-test/internal/db"
 	"avito-test/internal/domain"
+	transaction "avito-test/internal/repository/transaction/postgres"
 	"avito-test/internal/usecase"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -18,6 +20,10 @@ func NewUserRepository(db *db.Queries) *UserRepository {
 	return &UserRepository{db: db}
 }
 
+func (u *UserRepository) queries(ctx context.Context) *db.Queries {
+	return transaction.Queries(ctx, u.db)
+}
+
 func (u *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
 	if u.db == nil {
 		return errors.New("db is nil")
@@ -25,7 +31,7 @@ func (u *UserRepository) UpdateUser(ctx context.Context, user *domain.User) erro
 	if user == nil {
 		return errors.New("user is nil")
 	}
-	err := u.db.UpdateUser(ctx, db.UpdateUserParams{Userid: user.ID, Username: user.Username, Isactive: user.IsActive})
+	err := u.queries(ctx).UpdateUser(ctx, db.UpdateUserParams{Userid: user.ID, Username: user.Username, Isactive: user.IsActive})
 	if err != nil {
 		return fmt.Errorf("can't update user: %w", err)
 	}
@@ -39,7 +45,7 @@ func (u *UserRepository) SaveUser(ctx context.Context, user *domain.User) error
 	if user == nil {
 		return errors.New("user is nil")
 	}
-	err := u.db.SaveUser(ctx, db.SaveUserParams{Userid: user.ID, Username: user.Username, Isactive: user.IsActive})
+	err := u.queries(ctx).SaveUser(ctx, db.SaveUserParams{Userid: user.ID, Username: user.Username, Isactive: user.IsActive})
 	if err != nil {
 		return fmt.Errorf("can't save new team: %w", err)
 	}
@@ -50,7 +56,7 @@ func (u *UserRepository) GetUserByID(ctx context.Context, id string) (*domain.Us
 	if u.db == nil {
 		return nil, errors.New("db is nil")
 	}
-	user, err := u.db.GetUserByID(ctx, id)
+	user, err := u.queries(ctx).GetUserByID(ctx, id)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, usecase.ErrMemberNotFound
 	} else if err != nil {
@@ -63,7 +69,7 @@ func (u *UserRepository) GetUsersByTeamName(ctx context.Context, teamName string
 	if u.db == nil {
 		return nil, errors.New("db is nil")
 	}
-	gotUser, err := u.db.GetUsersByTeamName(ctx, teamName)
+	gotUser, err := u.queries(ctx).GetUsersByTeamName(ctx, teamName)
 	if errors.Is(err, sql.ErrNoRows) {
 		return []domain.User{}, usecase.ErrMemberNotFound
 	} else if err != nil {
@@ -80,7 +86,7 @@ func (u *UserRepository) GetTeamsByUserID(ctx context.Context, userID string) ([
 	if u.db == nil {
 		return nil, errors.New("db is nil")
 	}
-	team, err := u.db.GetUsersTeams(ctx, userID)
+	team, err := u.queries(ctx).GetUsersTeams(ctx, userID)
 	if errors.Is(err, sql.ErrNoRows) {
 		return []domain.Team{}, usecase.ErrTeamNotFound
 	} else if err != nil {
@@ -92,3 +98,28 @@ func (u *UserRepository) GetTeamsByUserID(ctx context.Context, userID string) ([
 	}
 	return result, nil
 }
+
+func (u *UserRepository) DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	if u.db == nil {
+		return nil, errors.New("db is nil")
+	}
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+	ids, err := json.Marshal(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode user ids: %w", err)
+	}
+	deactivated, err := u.queries(ctx).DeactivateTeamMembers(ctx, db.DeactivateTeamMembersParams{
+		Teamname:   teamName,
+		AllMembers: len(userIDs) == 0,
+		UserIds:    ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't deactivate team members: %w", err)
+	}
+	if deactivated == nil {
+		return []string{}, nil
+	}
+	return deactivated, nil
+}
@@ -221,3 +221,70 @@ func TestUserRepository_GetUserByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_DeactivateTeamMembers(t *testing.T) {
+	type args struct {
+		teamName string
+		userIDs  []string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mock    func(sqlmock.Sqlmock)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "selected members",
+			args: args{teamName: "team-1", userIDs: []string{"user-1", "user-2"}},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: DeactivateTeamMembers :many")).
+					WithArgs("team-1", false, []byte(`["user-1","user-2"]`)).
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}).AddRow("user-1").AddRow("user-2"))
+			},
+			want:    []string{"user-1", "user-2"},
+			wantErr: false,
+		},
+		{
+			name: "all members",
+			args: args{teamName: "team-1", userIDs: nil},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: DeactivateTeamMembers :many")).
+					WithArgs("team-1", true, []byte(`[]`)).
+					WillReturnRows(sqlmock.NewRows([]string{"userid"}))
+			},
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			args: args{teamName: "team-1", userIDs: []string{"user-1"}},
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta("-- name: DeactivateTeamMembers :many")).
+					WillReturnError(errors.New("update failed"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, mock, cleanup := usecase.NewTestQueries(t)
+			defer cleanup()
+
+			tt.mock(mock)
+
+			repo := &UserRepository{db: queries}
+
+			got, err := repo.DeactivateTeamMembers(context.Background(), tt.args.teamName, tt.args.userIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeactivateTeamMembers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("DeactivateTeamMembers() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"avito-test/internal/db"
+	"context"
+	"database/sql"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const dbInstrumentationName = "avito-test/internal/db"
+
+// DBTX оборачивает db.DBTX и создает спан на каждый запрос sqlc.
+// Имя спана - имя запроса из комментария "-- name: X", аргументы в спан не пишутся.
+type DBTX struct {
+	inner  db.DBTX
+	tracer trace.Tracer
+}
+
+func NewDBTX(inner db.DBTX) *DBTX {
+	return &DBTX{inner: inner, tracer: otel.Tracer(dbInstrumentationName)}
+}
+
+// WrapDBTX - обертка в виде функции для мест, где *sql.Tx появляется позже (транзакции).
+func WrapDBTX(inner db.DBTX) db.DBTX {
+	return NewDBTX(inner)
+}
+
+func (d *DBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := d.start(ctx, query)
+	defer span.End()
+	res, err := d.inner.ExecContext(ctx, query, args...)
+	recordError(span, err)
+	return res, err
+}
+
+func (d *DBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := d.start(ctx, query)
+	defer span.End()
+	stmt, err := d.inner.PrepareContext(ctx, query)
+	recordError(span, err)
+	return stmt, err
+}
+
+func (d *DBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := d.start(ctx, query)
+	defer span.End()
+	rows, err := d.inner.QueryContext(ctx, query, args...)
+	recordError(span, err)
+	return rows, err
+}
+
+func (d *DBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := d.start(ctx, query)
+	defer span.End()
+	row := d.inner.QueryRowContext(ctx, query, args...)
+	// sql.ErrNoRows появится только в Scan и ошибкой запроса не считается
+	recordError(span, row.Err())
+	return row
+}
+
+func (d *DBTX) start(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := queryName(query)
+	return d.tracer.Start(ctx, "db."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBQueryText(query),
+			attribute.String("db.sqlc.query", name),
+		),
+	)
+}
+
+// queryName достает имя из первой строки вида "-- name: GetUserByID :one".
+func queryName(query string) string {
+	const prefix = "-- name: "
+	if !strings.HasPrefix(query, prefix) {
+		return "query"
+	}
+	line, _, _ := strings.Cut(strings.TrimPrefix(query, prefix), "\n")
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "query"
+	}
+	return fields[0]
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
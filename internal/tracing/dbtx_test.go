@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "-- name: GetUserByID :one\nSELECT 1", want: "GetUserByID"},
+		{query: "-- name: \nSELECT 1", want: "query"},
+		{query: "SELECT 1", want: "query"},
+	}
+
+	for _, tt := range tests {
+		if got := queryName(tt.query); got != tt.want {
+			t.Fatalf("queryName(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDBTX_SpanPerQuery(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	defer conn.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO teams")).
+		WithArgs("team-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT teamname")).
+		WillReturnError(errors.New("db down"))
+
+	dbtx := NewDBTX(conn)
+	ctx := context.Background()
+
+	// Act
+	_, execErr := dbtx.ExecContext(ctx, "-- name: CreateTeam :exec\nINSERT INTO teams (teamname) VALUES ($1)", "team-1")
+	row := dbtx.QueryRowContext(ctx, "-- name: GetTeamByName :one\nSELECT teamname FROM teams")
+
+	// Assert
+	if execErr != nil {
+		t.Fatalf("ExecContext() unexpected error: %v", execErr)
+	}
+	if row.Err() == nil {
+		t.Fatalf("expected query error")
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name() != "db.CreateTeam" || spans[0].Status().Code == codes.Error {
+		t.Fatalf("unexpected exec span %q with status %v", spans[0].Name(), spans[0].Status())
+	}
+	if spans[1].Name() != "db.GetTeamByName" || spans[1].Status().Code != codes.Error {
+		t.Fatalf("unexpected query span %q with status %v", spans[1].Name(), spans[1].Status())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"fmt"
+)
+
+type actorKey struct{}
+
+// Actor - пользователь, от имени которого выполняется операция.
+type Actor struct {
+	// UserID - id пользователя, пусто для админских токенов без пользователя
+	UserID string
+	// Admin - глобальный админ, роли в командах для него не проверяются
+	Admin bool
+	// TokenID - id токена запроса, пишется в аудит
+	TokenID string
+}
+
+// SystemActor - служебный пользователь для фоновых задач (проверка сроков ревью и т.п.).
+// Задача должна явно положить его в контекст: вызов без пользователя запрещен.
+var SystemActor = Actor{Admin: true}
+
+// WithActor кладет в контекст пользователя, от имени которого выполняется запрос.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// actorFromContext возвращает пользователя из контекста. Без него возвращается пустой пользователь
+// без прав, и проверки доступа запрещают операцию; фоновые задачи используют SystemActor.
+func actorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{}
+}
+
+// requireAdmin разрешает операцию только глобальному админу.
+func requireAdmin(ctx context.Context) error {
+	if actorFromContext(ctx).Admin {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// requireSelf разрешает операцию от имени пользователя userID только ему самому.
+func requireSelf(ctx context.Context, userID string) error {
+	actor := actorFromContext(ctx)
+	if actor.Admin || (actor.UserID != "" && actor.UserID == userID) {
+		return nil
+	}
+	return fmt.Errorf("%w: only %s can do this", ErrForbidden, userID)
+}
+
+// requireTeamLead разрешает операцию над командой ее лиду.
+func requireTeamLead(ctx context.Context, team *domain.Team) error {
+	actor := actorFromContext(ctx)
+	if actor.Admin {
+		return nil
+	}
+	if actor.UserID != "" && team.RoleOf(actor.UserID) == domain.TeamRoleLead {
+		return nil
+	}
+	return fmt.Errorf("%w: only lead of team %s can do this", ErrForbidden, team.Name)
+}
+
+// requireLeadOf разрешает операцию над участником userID лиду любой из его команд.
+// Если allowSelf, участник может выполнить операцию и над собой.
+func requireLeadOf(ctx context.Context, teamRepository TeamRepository, userID string, allowSelf bool) error {
+	actor := actorFromContext(ctx)
+	if actor.Admin {
+		return nil
+	}
+	if actor.UserID == "" {
+		return ErrForbidden
+	}
+	if allowSelf && actor.UserID == userID {
+		return nil
+	}
+	if teamRepository == nil {
+		return ErrTeamRepositoryNotFound
+	}
+	isLead, err := teamRepository.IsTeamLead(ctx, actor.UserID, userID)
+	if err != nil {
+		return err
+	}
+	if !isLead {
+		return fmt.Errorf("%w: %s is not a lead of %s's team", ErrForbidden, actor.UserID, userID)
+	}
+	return nil
+}
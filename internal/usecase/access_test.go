@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+var (
+	actorAdmin  = Actor{Admin: true}
+	actorLead   = Actor{UserID: "lead"}
+	actorMember = Actor{UserID: "member"}
+	actorOther  = Actor{UserID: "other-lead"}
+)
+
+func rolesTeam() *domain.Team {
+	return &domain.Team{
+		Name: "team-1",
+		Members: []domain.User{
+			{ID: "lead", IsActive: true},
+			{ID: "member", IsActive: true},
+			{ID: "peer", IsActive: true},
+		},
+		Roles: map[string]domain.TeamRole{"lead": domain.TeamRoleLead, "member": domain.TeamRoleMember},
+	}
+}
+
+// isLead - ответ IsTeamLead для пар (лид, участник) тестовой команды.
+func isLead(leadID, memberID string) bool {
+	return leadID == "lead" && (memberID == "lead" || memberID == "member" || memberID == "peer")
+}
+
+func TestTeam_DeactivateMembers_Permissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		allowed bool
+	}{
+		{name: "admin", actor: actorAdmin, allowed: true},
+		{name: "lead of team", actor: actorLead, allowed: true},
+		{name: "member of team", actor: actorMember, allowed: false},
+		{name: "lead of other team", actor: actorOther, allowed: false},
+		{name: "user without id", actor: Actor{}, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			teamRepo := NewMockTeamRepository(ctrl)
+			teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(rolesTeam(), nil)
+
+			team := NewTeam(teamRepo, NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
+
+			// Act: участник "ghost" не состоит в команде, поэтому разрешенный вызов падает уже после проверки прав
+			_, err := team.DeactivateMembers(ctx, "team-1", []string{"ghost"})
+
+			// Assert
+			assertPermission(t, err, tt.allowed, ErrMemberNotFound)
+		})
+	}
+}
+
+func TestTeam_CreateTeam_Permissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		allowed bool
+	}{
+		{name: "admin", actor: actorAdmin, allowed: true},
+		{name: "lead", actor: actorLead, allowed: false},
+		{name: "member", actor: actorMember, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			teamRepo := NewMockTeamRepository(ctrl)
+			if tt.allowed {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(rolesTeam(), nil)
+			}
+
+			team := NewTeam(teamRepo, NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			_, err := team.CreateTeam(ctx, &domain.Team{Name: "team-1"}, []domain.User{{ID: "u1"}})
+
+			// Assert
+			assertPermission(t, err, tt.allowed, ErrTeamAlreadyExists)
+		})
+	}
+}
+
+func TestUser_SetActive_Permissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		target  string
+		allowed bool
+	}{
+		{name: "admin", actor: actorAdmin, target: "member", allowed: true},
+		{name: "lead toggles member", actor: actorLead, target: "member", allowed: true},
+		{name: "lead toggles self", actor: actorLead, target: "lead", allowed: true},
+		{name: "member toggles self", actor: actorMember, target: "member", allowed: false},
+		{name: "member toggles peer", actor: actorMember, target: "peer", allowed: false},
+		{name: "lead of other team", actor: actorOther, target: "member", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			userRepo := NewMockUserRepository(ctrl)
+			teamRepo := NewMockTeamRepository(ctrl)
+
+			userRepo.EXPECT().GetUserByID(ctx, tt.target).Return(&domain.User{ID: tt.target, IsActive: true}, nil)
+			if !tt.actor.Admin {
+				teamRepo.EXPECT().IsTeamLead(ctx, tt.actor.UserID, tt.target).Return(isLead(tt.actor.UserID, tt.target), nil)
+			}
+			if tt.allowed {
+				userRepo.EXPECT().UpdateUser(ctx, gomock.Any()).Return(nil)
+			}
+
+			u := NewUser(userRepo, NewMockRequestOwnerRepository(ctrl), NewMockPullRequestRepository(ctrl), teamRepo)
+
+			// Act
+			_, err := u.SetActive(ctx, tt.target, false)
+
+			// Assert
+			assertPermission(t, err, tt.allowed, nil)
+		})
+	}
+}
+
+func TestPullRequest_ReassignRequest_Permissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      Actor
+		oldUser    string
+		checksLead bool
+		allowed    bool
+	}{
+		{name: "admin", actor: actorAdmin, oldUser: "peer", allowed: true},
+		{name: "member reassigns own review", actor: actorMember, oldUser: "member", allowed: true},
+		{name: "member reassigns peer review", actor: actorMember, oldUser: "peer", checksLead: true, allowed: false},
+		{name: "lead force-reassigns in own team", actor: actorLead, oldUser: "peer", checksLead: true, allowed: true},
+		{name: "lead of other team", actor: actorOther, oldUser: "peer", checksLead: true, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			prRepo := NewMockPullRequestRepository(ctrl)
+			teamRepo := NewMockTeamRepository(ctrl)
+			userRepo := NewMockUserRepository(ctrl)
+
+			// PR уже слит: разрешенный вызов доходит до проверки статуса и получает ErrPullRequestIsMerged
+			prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{
+				ID:                  "pr-1",
+				Status:              domain.RequestStatusMerged,
+				AssignedReviewersID: []string{tt.oldUser},
+			}, nil)
+			userRepo.EXPECT().GetUserByID(ctx, tt.oldUser).Return(&domain.User{ID: tt.oldUser, IsActive: true}, nil)
+			if tt.checksLead {
+				teamRepo.EXPECT().IsTeamLead(ctx, tt.actor.UserID, tt.oldUser).Return(isLead(tt.actor.UserID, tt.oldUser), nil)
+			}
+
+			pr := NewPullRequest(prRepo, teamRepo, userRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			_, _, err := pr.ReassignRequest(ctx, "pr-1", tt.oldUser)
+
+			// Assert
+			assertPermission(t, err, tt.allowed, ErrPullRequestIsMerged)
+		})
+	}
+}
+
+func TestPullRequest_ChangeReviewers_Permissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      Actor
+		checksLead bool
+		allowed    bool
+	}{
+		{name: "admin", actor: actorAdmin, allowed: true},
+		{name: "lead of author's team", actor: actorLead, checksLead: true, allowed: true},
+		{name: "author", actor: actorMember, checksLead: true, allowed: false},
+		{name: "peer of author", actor: Actor{UserID: "peer"}, checksLead: true, allowed: false},
+		{name: "lead of other team", actor: actorOther, checksLead: true, allowed: false},
+	}
+
+	operations := map[string]func(uc PullRequest, ctx context.Context) error{
+		"add": func(uc PullRequest, ctx context.Context) error {
+			_, err := uc.AddReviewer(ctx, "pr-1", "peer")
+			return err
+		},
+		"remove": func(uc PullRequest, ctx context.Context) error {
+			_, err := uc.RemoveReviewer(ctx, "pr-1", "peer")
+			return err
+		},
+	}
+
+	for op, call := range operations {
+		for _, tt := range tests {
+			t.Run(op+"/"+tt.name, func(t *testing.T) {
+				// Arrange
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				ctx := WithActor(context.Background(), tt.actor)
+				prRepo := NewMockPullRequestRepository(ctrl)
+				teamRepo := NewMockTeamRepository(ctrl)
+
+				// PR уже слит: разрешенный вызов доходит до проверки статуса и получает ErrPullRequestIsMerged
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{
+					ID:                  "pr-1",
+					AuthorID:            "member",
+					Status:              domain.RequestStatusMerged,
+					AssignedReviewersID: []string{"peer"},
+				}, nil)
+				if tt.checksLead {
+					teamRepo.EXPECT().IsTeamLead(ctx, tt.actor.UserID, "member").Return(isLead(tt.actor.UserID, "member"), nil)
+				}
+
+				uc := NewPullRequest(prRepo, teamRepo, NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+				// Act
+				err := call(uc, ctx)
+
+				// Assert
+				assertPermission(t, err, tt.allowed, ErrPullRequestIsMerged)
+			})
+		}
+	}
+}
+
+func TestPullRequest_ChangeStatus_Permissions(t *testing.T) {
+	tests := []struct {
+		name       string
+		actor      Actor
+		checksLead bool
+		allowed    bool
+	}{
+		{name: "admin", actor: actorAdmin, allowed: true},
+		{name: "author", actor: actorMember, allowed: true},
+		{name: "lead of author", actor: actorLead, checksLead: true, allowed: true},
+		{name: "lead of other team", actor: actorOther, checksLead: true, allowed: false},
+		{name: "reviewer", actor: Actor{UserID: "peer"}, checksLead: true, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			prRepo := NewMockPullRequestRepository(ctrl)
+			teamRepo := NewMockTeamRepository(ctrl)
+
+			// PR уже слит: разрешенный вызов доходит до проверки перехода и получает ErrInvalidStatusTransition
+			prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{
+				ID:                  "pr-1",
+				AuthorID:            "member",
+				Status:              domain.RequestStatusMerged,
+				AssignedReviewersID: []string{"peer"},
+			}, nil)
+			if tt.checksLead {
+				teamRepo.EXPECT().IsTeamLead(ctx, tt.actor.UserID, "member").Return(isLead(tt.actor.UserID, "member"), nil)
+			}
+
+			uc := NewPullRequest(prRepo, teamRepo, NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			_, err := uc.ChangeStatus(ctx, "pr-1", domain.RequestStatusClosed)
+
+			// Assert
+			assertPermission(t, err, tt.allowed, ErrInvalidStatusTransition)
+		})
+	}
+}
+
+func TestPullRequest_SubmitReview_Permissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		allowed bool
+	}{
+		{name: "admin", actor: actorAdmin, allowed: true},
+		{name: "reviewer", actor: Actor{UserID: "peer"}, allowed: true},
+		{name: "lead of reviewer", actor: actorLead, allowed: false},
+		{name: "author", actor: actorMember, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			prRepo := NewMockPullRequestRepository(ctrl)
+
+			// PR уже слит: разрешенный вызов доходит до проверки статуса и получает ErrPullRequestIsMerged
+			prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{
+				ID:                  "pr-1",
+				AuthorID:            "member",
+				Status:              domain.RequestStatusMerged,
+				AssignedReviewersID: []string{"peer"},
+			}, nil)
+
+			uc := NewPullRequest(prRepo, NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			_, err := uc.SubmitReview(ctx, &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "peer", State: domain.ReviewStateApproved})
+
+			// Assert
+			assertPermission(t, err, tt.allowed, ErrPullRequestIsMerged)
+		})
+	}
+}
+
+func TestRequireLeadOf_RepositoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorLead)
+	teamRepo := NewMockTeamRepository(ctrl)
+	teamRepo.EXPECT().IsTeamLead(ctx, "lead", "member").Return(false, errors.New("db down"))
+
+	err := requireLeadOf(ctx, teamRepo, "member", false)
+	if err == nil || errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected repository error, got %v", err)
+	}
+}
+
+func TestAccess_WithoutActor(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		allowed bool
+	}{
+		{name: "no actor", ctx: context.Background(), allowed: false},
+		{name: "system actor", ctx: WithActor(context.Background(), SystemActor), allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Act
+			errs := []error{
+				requireAdmin(tt.ctx),
+				requireSelf(tt.ctx, "member"),
+				requireTeamLead(tt.ctx, rolesTeam()),
+				requireLeadOf(tt.ctx, NewMockTeamRepository(ctrl), "member", true),
+			}
+
+			// Assert
+			for _, err := range errs {
+				assertPermission(t, err, tt.allowed, nil)
+			}
+		})
+	}
+}
+
+// assertPermission проверяет, что запрещенный вызов вернул ErrForbidden,
+// а разрешенный прошел проверку прав и закончился ошибкой afterCheck (nil - успехом).
+func assertPermission(t *testing.T, err error, allowed bool, afterCheck error) {
+	t.Helper()
+	if !allowed {
+		if !errors.Is(err, ErrForbidden) {
+			t.Fatalf("expected ErrForbidden, got %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, afterCheck) {
+		t.Fatalf("expected %v after permission check, got %v", afterCheck, err)
+	}
+}
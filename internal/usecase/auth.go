@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tokenPrefix помогает узнать токен сервиса в конфигах и сканерах секретов.
+const tokenPrefix = "prt_"
+
+type Auth struct {
+	tokenRepository TokenRepository
+	userRepository  UserRepository
+	bootstrapHash   string
+}
+
+// NewAuth создает usecase аутентификации. bootstrapToken - админский токен из конфигурации,
+// которым выпускаются первые токены в БД; пустая строка его отключает.
+func NewAuth(tokenRepository TokenRepository, userRepository UserRepository, bootstrapToken string) Auth {
+	a := Auth{
+		tokenRepository: tokenRepository,
+		userRepository:  userRepository,
+	}
+	if bootstrapToken != "" {
+		a.bootstrapHash = HashToken(bootstrapToken)
+	}
+	return a
+}
+
+// HashToken - sha256 от токена в hex. Токены случайные и длинные, поэтому соль и медленный хеш не нужны.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate возвращает действующий токен по его значению из заголовка Authorization.
+func (a *Auth) Authenticate(ctx context.Context, token string) (_ *domain.APIToken, err error) {
+	ctx, span := startSpan(ctx, "Auth.Authenticate")
+	defer endSpan(span, &err)
+
+	if token == "" {
+		return nil, ErrInvalidToken
+	}
+	hash := HashToken(token)
+	if a.bootstrapHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(a.bootstrapHash)) == 1 {
+		return &domain.APIToken{ID: "bootstrap", Name: "bootstrap", Scope: domain.TokenScopeAdmin}, nil
+	}
+	if a.tokenRepository == nil {
+		return nil, ErrTokenRepositoryNotFound
+	}
+
+	found, err := a.tokenRepository.GetTokenByHash(ctx, hash)
+	if errors.Is(err, ErrTokenNotFound) {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("token.id", found.ID), attribute.String("token.scope", string(found.Scope)))
+	return found, nil
+}
+
+// CreateToken выпускает новый токен. Значение токена возвращается только здесь, в БД хранится хеш.
+// Токен со scope user привязывается к существующему пользователю.
+func (a *Auth) CreateToken(ctx context.Context, name string, scope domain.TokenScope, userID string) (_ *domain.APIToken, _ string, err error) {
+	ctx, span := startSpan(ctx, "Auth.CreateToken", attribute.String("token.scope", string(scope)))
+	defer endSpan(span, &err)
+
+	if a.tokenRepository == nil {
+		return nil, "", ErrTokenRepositoryNotFound
+	} else if a.userRepository == nil {
+		return nil, "", ErrUserRepositoryNotFound
+	}
+
+	switch scope {
+	case domain.TokenScopeAdmin:
+	case domain.TokenScopeUser:
+		if userID == "" {
+			return nil, "", ErrTokenUserRequired
+		}
+	default:
+		return nil, "", ErrInvalidTokenScope
+	}
+	if userID != "" {
+		if _, err := a.userRepository.GetUserByID(ctx, userID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	id, err := randomString(8, hex.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, "", err
+	}
+	secret = tokenPrefix + secret
+
+	token := &domain.APIToken{
+		ID:     "tok_" + id,
+		Name:   name,
+		Hash:   HashToken(secret),
+		Scope:  scope,
+		UserID: userID,
+	}
+	if err := a.tokenRepository.SaveToken(ctx, token); err != nil {
+		return nil, "", err
+	}
+	return token, secret, nil
+}
+
+// GetTokens возвращает все выпущенные токены без их значений.
+func (a *Auth) GetTokens(ctx context.Context) (_ []domain.APIToken, err error) {
+	ctx, span := startSpan(ctx, "Auth.GetTokens")
+	defer endSpan(span, &err)
+
+	if a.tokenRepository == nil {
+		return nil, ErrTokenRepositoryNotFound
+	}
+	return a.tokenRepository.GetTokens(ctx)
+}
+
+// RevokeToken отзывает токен, после чего он перестает проходить аутентификацию.
+func (a *Auth) RevokeToken(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "Auth.RevokeToken", attribute.String("token.id", id))
+	defer endSpan(span, &err)
+
+	if a.tokenRepository == nil {
+		return ErrTokenRepositoryNotFound
+	}
+	return a.tokenRepository.RevokeToken(ctx, id)
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("can't generate token: %w", err)
+	}
+	return encode(b), nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAuth_Authenticate(t *testing.T) {
+	stored := &domain.APIToken{ID: "tok_1", Scope: domain.TokenScopeUser, UserID: "u1"}
+
+	tests := []struct {
+		name      string
+		token     string
+		mock      func(ctx context.Context, tokenRepo *MockTokenRepository)
+		wantID    string
+		wantScope domain.TokenScope
+		wantErr   error
+	}{
+		{
+			name:    "empty token",
+			token:   "",
+			mock:    func(ctx context.Context, tokenRepo *MockTokenRepository) {},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:      "bootstrap token skips repository",
+			token:     "bootstrap-secret",
+			mock:      func(ctx context.Context, tokenRepo *MockTokenRepository) {},
+			wantID:    "bootstrap",
+			wantScope: domain.TokenScopeAdmin,
+		},
+		{
+			name:  "stored token is looked up by hash",
+			token: "prt_user",
+			mock: func(ctx context.Context, tokenRepo *MockTokenRepository) {
+				tokenRepo.EXPECT().GetTokenByHash(ctx, HashToken("prt_user")).Return(stored, nil)
+			},
+			wantID:    "tok_1",
+			wantScope: domain.TokenScopeUser,
+		},
+		{
+			name:  "unknown or revoked token",
+			token: "prt_revoked",
+			mock: func(ctx context.Context, tokenRepo *MockTokenRepository) {
+				tokenRepo.EXPECT().GetTokenByHash(ctx, HashToken("prt_revoked")).Return(nil, ErrTokenNotFound)
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:  "repository error",
+			token: "prt_user",
+			mock: func(ctx context.Context, tokenRepo *MockTokenRepository) {
+				tokenRepo.EXPECT().GetTokenByHash(ctx, HashToken("prt_user")).Return(nil, errors.New("db down"))
+			},
+			wantErr: errors.New("db down"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			tokenRepo := NewMockTokenRepository(ctrl)
+			tt.mock(ctx, tokenRepo)
+
+			auth := NewAuth(tokenRepo, NewMockUserRepository(ctrl), "bootstrap-secret")
+
+			// Act
+			got, err := auth.Authenticate(ctx, tt.token)
+
+			// Assert
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() unexpected error: %v", err)
+			}
+			if got.ID != tt.wantID || got.Scope != tt.wantScope {
+				t.Fatalf("Authenticate() got %+v, want id %s scope %s", got, tt.wantID, tt.wantScope)
+			}
+		})
+	}
+}
+
+func TestAuth_CreateToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   domain.TokenScope
+		userID  string
+		mock    func(ctx context.Context, tokenRepo *MockTokenRepository, userRepo *MockUserRepository)
+		wantErr error
+	}{
+		{
+			name:    "unknown scope",
+			scope:   "root",
+			mock:    func(ctx context.Context, tokenRepo *MockTokenRepository, userRepo *MockUserRepository) {},
+			wantErr: ErrInvalidTokenScope,
+		},
+		{
+			name:    "user scope without user",
+			scope:   domain.TokenScopeUser,
+			mock:    func(ctx context.Context, tokenRepo *MockTokenRepository, userRepo *MockUserRepository) {},
+			wantErr: ErrTokenUserRequired,
+		},
+		{
+			name:   "user not found",
+			scope:  domain.TokenScopeUser,
+			userID: "ghost",
+			mock: func(ctx context.Context, tokenRepo *MockTokenRepository, userRepo *MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "ghost").Return(nil, ErrMemberNotFound)
+			},
+			wantErr: ErrMemberNotFound,
+		},
+		{
+			name:   "user token stores only hash",
+			scope:  domain.TokenScopeUser,
+			userID: "u1",
+			mock: func(ctx context.Context, tokenRepo *MockTokenRepository, userRepo *MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "u1").Return(&domain.User{ID: "u1"}, nil)
+				tokenRepo.EXPECT().SaveToken(ctx, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "admin token",
+			scope: domain.TokenScopeAdmin,
+			mock: func(ctx context.Context, tokenRepo *MockTokenRepository, userRepo *MockUserRepository) {
+				tokenRepo.EXPECT().SaveToken(ctx, gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			tokenRepo := NewMockTokenRepository(ctrl)
+			userRepo := NewMockUserRepository(ctrl)
+			tt.mock(ctx, tokenRepo, userRepo)
+
+			auth := NewAuth(tokenRepo, userRepo, "")
+
+			// Act
+			token, secret, err := auth.CreateToken(ctx, "cli", tt.scope, tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !strings.HasPrefix(secret, tokenPrefix) {
+				t.Fatalf("expected secret with %s prefix, got %q", tokenPrefix, secret)
+			}
+			if token.Hash != HashToken(secret) || strings.Contains(token.Hash, secret) {
+				t.Fatalf("token must keep sha256 of secret, got %q", token.Hash)
+			}
+			if token.Scope != tt.scope || token.UserID != tt.userID {
+				t.Fatalf("unexpected token %+v", token)
+			}
+		})
+	}
+}
+
+func TestAuth_CreateToken_UniqueSecrets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenRepo := NewMockTokenRepository(ctrl)
+	tokenRepo.EXPECT().SaveToken(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	auth := NewAuth(tokenRepo, NewMockUserRepository(ctrl), "")
+
+	first, firstSecret, _ := auth.CreateToken(context.Background(), "a", domain.TokenScopeAdmin, "")
+	second, secondSecret, _ := auth.CreateToken(context.Background(), "b", domain.TokenScopeAdmin, "")
+	if first.ID == second.ID || firstSecret == secondSecret {
+		t.Fatalf("expected unique tokens, got %s/%s and %s/%s", first.ID, firstSecret, second.ID, secondSecret)
+	}
+}
+
+func TestAuth_RevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tokenRepo := NewMockTokenRepository(ctrl)
+	tokenRepo.EXPECT().RevokeToken(ctx, "tok_1").Return(ErrTokenNotFound)
+
+	auth := NewAuth(tokenRepo, nil, "")
+
+	if err := auth.RevokeToken(ctx, "tok_1"); !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("expected ErrTokenNotFound, got %v", err)
+	}
+}
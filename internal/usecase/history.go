@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+)
+
+type assignmentReasonKey struct{}
+
+// withAssignmentReason задает причину событий истории назначений для операций, вызванных с ctx.
+// Так фоновые задачи отличают свои замены от замен по запросу пользователя.
+func withAssignmentReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, assignmentReasonKey{}, reason)
+}
+
+// assignmentReason возвращает причину из ctx, fallback - если она не задана.
+func assignmentReason(ctx context.Context, fallback string) string {
+	if reason, ok := ctx.Value(assignmentReasonKey{}).(string); ok && reason != "" {
+		return reason
+	}
+	return fallback
+}
+
+// newAssignmentEvent создает событие истории назначений от имени пользователя из ctx.
+func newAssignmentEvent(ctx context.Context, pullRequestID string, eventType domain.AssignmentEventType, reason string) domain.AssignmentEvent {
+	actor := actorFromContext(ctx)
+	return domain.AssignmentEvent{
+		PullRequestID: pullRequestID,
+		Type:          eventType,
+		ActorUserID:   actor.UserID,
+		ActorTokenID:  actor.TokenID,
+		Reason:        reason,
+	}
+}
+
+// replacementEvents описывает массовые замены ревьюверов: слот без кандидата - снятие ревьювера.
+// Массовые замены выбирает БД в обход стратегий команды, поэтому Strategy у событий пустая.
+func replacementEvents(ctx context.Context, replacements []domain.ReviewerReplacement, reason string) []domain.AssignmentEvent {
+	events := make([]domain.AssignmentEvent, 0, len(replacements))
+	for _, r := range replacements {
+		if r.NewReviewerID == "" {
+			event := newAssignmentEvent(ctx, r.PullRequestID, domain.AssignmentEventUnassigned, reason)
+			event.UserID = r.OldReviewerID
+			events = append(events, event)
+			continue
+		}
+		event := newAssignmentEvent(ctx, r.PullRequestID, domain.AssignmentEventReassigned, reason)
+		event.UserID = r.NewReviewerID
+		event.PreviousUserID = r.OldReviewerID
+		events = append(events, event)
+	}
+	return events
+}
+
+// saveAssignmentEvents добавляет события в историю, пустой список в БД не пишется.
+func saveAssignmentEvents(ctx context.Context, requestOwnerRepository RequestOwnerRepository, events []domain.AssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return requestOwnerRepository.SaveAssignmentEvents(ctx, events)
+}
+
+// mergeDecisions дополняет решения по пулу пользователей решениями стратегии по кандидатам из selection.
+func mergeDecisions(pool, selection []domain.CandidateDecision) []domain.CandidateDecision {
+	byID := make(map[string]domain.CandidateDecision, len(selection))
+	for _, decision := range selection {
+		byID[decision.UserID] = decision
+	}
+	merged := make([]domain.CandidateDecision, len(pool))
+	for i, decision := range pool {
+		if traced, ok := byID[decision.UserID]; ok && decision.Eligible && !decision.Requested {
+			decision = traced
+		}
+		merged[i] = decision
+	}
+	return merged
+}
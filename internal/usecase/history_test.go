@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestPullRequest_GetHistory(t *testing.T) {
+	errDB := errors.New("db error")
+	events := []domain.AssignmentEvent{
+		{ID: 1, PullRequestID: "pr-1", Type: domain.AssignmentEventAssigned, UserID: "u1", Reason: domain.AssignmentReasonCreate, Strategy: StrategyRandom},
+		{ID: 2, PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "u2", PreviousUserID: "u1", Reason: domain.AssignmentReasonReassign, Strategy: StrategyRandom},
+	}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		mock    func(ctx context.Context, prRepo *MockPullRequestRepository, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository)
+		want    []domain.AssignmentEvent
+		wantErr error
+	}{
+		{
+			name:  "admin",
+			actor: actorAdmin,
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "member"}, nil)
+				ownerRepo.EXPECT().GetAssignmentEventsByPullRequestID(ctx, "pr-1").Return(events, nil)
+			},
+			want: events,
+		},
+		{
+			name:  "author",
+			actor: actorMember,
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "member"}, nil)
+				ownerRepo.EXPECT().GetAssignmentEventsByPullRequestID(ctx, "pr-1").Return(events, nil)
+			},
+			want: events,
+		},
+		{
+			name:  "not a lead of author",
+			actor: actorMember,
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "author-1"}, nil)
+				teamRepo.EXPECT().IsTeamLead(ctx, "member", "author-1").Return(false, nil)
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:  "pull request not found",
+			actor: actorAdmin,
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(nil, ErrPullRequestNotFound)
+			},
+			wantErr: ErrPullRequestNotFound,
+		},
+		{
+			name:  "db error",
+			actor: actorAdmin,
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "member"}, nil)
+				ownerRepo.EXPECT().GetAssignmentEventsByPullRequestID(ctx, "pr-1").Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockPRRepo, mockTeamRepo, mockReqOwnerRepo)
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, NewMockUserRepository(ctrl), mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.GetHistory(ctx, "pr-1")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_GetHistory(t *testing.T) {
+	events := []domain.AssignmentEvent{
+		{ID: 2, PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "u2", PreviousUserID: "member", Reason: domain.AssignmentReasonReviewSLA, Strategy: StrategyRandom},
+	}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		userID  string
+		mock    func(ctx context.Context, userRepo *MockUserRepository, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository)
+		want    []domain.AssignmentEvent
+		wantErr error
+	}{
+		{
+			name:   "self",
+			actor:  actorMember,
+			userID: "member",
+			mock: func(ctx context.Context, userRepo *MockUserRepository, _ *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "member").Return(&domain.User{ID: "member"}, nil)
+				ownerRepo.EXPECT().GetAssignmentEventsByUserID(ctx, "member").Return(events, nil)
+			},
+			want: events,
+		},
+		{
+			name:   "lead of user",
+			actor:  actorLead,
+			userID: "member",
+			mock: func(ctx context.Context, userRepo *MockUserRepository, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "member").Return(&domain.User{ID: "member"}, nil)
+				teamRepo.EXPECT().IsTeamLead(ctx, "lead", "member").Return(true, nil)
+				ownerRepo.EXPECT().GetAssignmentEventsByUserID(ctx, "member").Return(events, nil)
+			},
+			want: events,
+		},
+		{
+			name:   "other user",
+			actor:  actorMember,
+			userID: "u2",
+			mock: func(ctx context.Context, userRepo *MockUserRepository, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&domain.User{ID: "u2"}, nil)
+				teamRepo.EXPECT().IsTeamLead(ctx, "member", "u2").Return(false, nil)
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:   "user not found",
+			actor:  actorAdmin,
+			userID: "ghost",
+			mock: func(ctx context.Context, userRepo *MockUserRepository, _ *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "ghost").Return(nil, ErrMemberNotFound)
+			},
+			wantErr: ErrMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockUserRepo, mockTeamRepo, mockReqOwnerRepo)
+
+			uc := NewUser(mockUserRepo, mockReqOwnerRepo, NewMockPullRequestRepository(ctrl), mockTeamRepo)
+
+			// Act
+			got, err := uc.GetHistory(ctx, tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignmentEvents_Actor(t *testing.T) {
+	// Arrange
+	ctx := withAssignmentReason(WithActor(context.Background(), Actor{UserID: "lead", TokenID: "tok-1"}), domain.AssignmentReasonReviewSLA)
+	replacements := []domain.ReviewerReplacement{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+		{PullRequestID: "pr-2", OldReviewerID: "u1"},
+	}
+
+	// Act
+	got := replacementEvents(ctx, replacements, assignmentReason(ctx, domain.AssignmentReasonReassign))
+
+	// Assert
+	want := []domain.AssignmentEvent{
+		{PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "u2", PreviousUserID: "u1", ActorUserID: "lead", ActorTokenID: "tok-1", Reason: domain.AssignmentReasonReviewSLA},
+		{PullRequestID: "pr-2", Type: domain.AssignmentEventUnassigned, UserID: "u1", ActorUserID: "lead", ActorTokenID: "tok-1", Reason: domain.AssignmentReasonReviewSLA},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if reason := assignmentReason(context.Background(), domain.AssignmentReasonReassign); reason != domain.AssignmentReasonReassign {
+		t.Fatalf("expected fallback reason, got %q", reason)
+	}
+}
+
+func TestPullRequest_GetExplanations(t *testing.T) {
+	errDB := errors.New("db error")
+	explanations := []domain.AssignmentExplanation{
+		{
+			ID: 1, PullRequestID: "pr-1", Reason: domain.AssignmentReasonCreate, Strategy: StrategyRandom, TeamName: "team-1", Slots: 1,
+			Selected: []string{"reviewer"},
+			Candidates: []domain.CandidateDecision{
+				{UserID: "author-1", ExcludedReason: domain.CandidateExcludedAuthor},
+				{UserID: "reviewer", Eligible: true, Selected: true, Draw: intPtr(0)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository)
+		authErr error
+		dbErr   error
+		want    []domain.AssignmentExplanation
+		wantErr error
+	}{
+		{
+			name:  "admin",
+			actor: actorAdmin,
+			want:  explanations,
+		},
+		{
+			name:  "author",
+			actor: Actor{UserID: "author-1"},
+			want:  explanations,
+		},
+		{
+			name:  "lead of author",
+			actor: actorLead,
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().IsTeamLead(ctx, "lead", "author-1").Return(true, nil)
+			},
+			want: explanations,
+		},
+		{
+			name:  "selected reviewer",
+			actor: Actor{UserID: "reviewer"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().IsTeamLead(ctx, "reviewer", "author-1").Return(false, nil)
+			},
+			want: explanations,
+		},
+		{
+			name:  "not selected member",
+			actor: actorMember,
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().IsTeamLead(ctx, "member", "author-1").Return(false, nil)
+			},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:  "lead check error",
+			actor: actorMember,
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().IsTeamLead(ctx, "member", "author-1").Return(false, errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name:    "db error",
+			actor:   actorAdmin,
+			dbErr:   errDB,
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "author-1"}, nil)
+			if tt.dbErr != nil {
+				mockReqOwnerRepo.EXPECT().GetAssignmentExplanationsByPullRequestID(ctx, "pr-1").Return(nil, tt.dbErr)
+			} else {
+				mockReqOwnerRepo.EXPECT().GetAssignmentExplanationsByPullRequestID(ctx, "pr-1").Return(explanations, nil)
+			}
+			if tt.mock != nil {
+				tt.mock(ctx, mockTeamRepo)
+			}
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, NewMockUserRepository(ctrl), mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.GetExplanations(ctx, "pr-1")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequest_GetExplanations_NotFound(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(nil, ErrPullRequestNotFound)
+
+	uc := NewPullRequest(mockPRRepo, NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+	// Act
+	got, err := uc.GetExplanations(ctx, "pr-1")
+
+	// Assert
+	if got != nil || !errors.Is(err, ErrPullRequestNotFound) {
+		t.Fatalf("expected ErrPullRequestNotFound, got %#v, %v", got, err)
+	}
+}
@@ -0,0 +1,27 @@
+package usecase
+
+// Операции, в разрезе которых считаются метрики назначений.
+const (
+	OperationCreate      = "create"
+	OperationReassign    = "reassign"
+	OperationDeactivate  = "deactivate"
+	OperationLeaveTeam   = "leave_team"
+	OperationSyncTeam    = "sync_team"
+	OperationAddReviewer = "add_reviewer"
+	OperationReady       = "ready"
+	OperationReopen      = "reopen"
+)
+
+// nopMetrics используется, если метрики не переданы в конструктор.
+type nopMetrics struct{}
+
+func (nopMetrics) ReviewersAssigned(string, int) {}
+func (nopMetrics) Reassigned(string, int)        {}
+func (nopMetrics) NoCandidate(string, int)       {}
+
+func metricsOrNop(m Metrics) Metrics {
+	if m == nil {
+		return nopMetrics{}
+	}
+	return m
+}
@@ -4,7 +4,12 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
-	"math/rand"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type PullRequest struct {
@@ -12,24 +17,56 @@ type PullRequest struct {
 	teamRepository         TeamRepository
 	userRepository         UserRepository
 	requestOwnerRepository RequestOwnerRepository
+	transactor             Transactor
+	reviewerSelector       ReviewerSelector
+	metrics                Metrics
 }
 
 func NewPullRequest(pullRequestRepo PullRequestRepository,
 	teamRepository TeamRepository,
 	userRepository UserRepository,
-	requestOwnerRepository RequestOwnerRepository) PullRequest {
+	requestOwnerRepository RequestOwnerRepository,
+	transactor Transactor,
+	reviewerSelector ReviewerSelector,
+	metrics Metrics) PullRequest {
 	return PullRequest{
 		pullRequestRepository:  pullRequestRepo,
 		teamRepository:         teamRepository,
 		userRepository:         userRepository,
 		requestOwnerRepository: requestOwnerRepository,
+		transactor:             transactor,
+		reviewerSelector:       reviewerSelector,
+		metrics:                metricsOrNop(metrics),
 	}
 }
 
-func (p *PullRequest) CreatePullRequest(ctx context.Context, request *domain.PullRequest) (*domain.PullRequest, error) {
+// CreatePullRequest создает PR и назначает ревьюверов: сначала запрошенные автором, остальные слоты
+// заполняет стратегия выбора без учета исключенных пользователей. Исключения сохраняются с PR и действуют
+// при всех заменах ревьюверов. PR со статусом DRAFT создается без ревьюверов, исключенные пользователи
+// не назначаются и при его переводе в OPEN.
+func (p *PullRequest) CreatePullRequest(ctx context.Context, request *domain.PullRequest, preferences domain.ReviewerPreferences) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.CreatePullRequest")
+	defer endSpan(span, &err)
+
 	if request == nil {
 		return nil, ErrAuthorNotFound
 	}
+	span.SetAttributes(attribute.String("pull_request.id", request.ID), attribute.String("pull_request.author_id", request.AuthorID),
+		attribute.Int("pull_request.requested_reviewers", len(preferences.Requested)), attribute.Int("pull_request.excluded_reviewers", len(preferences.Excluded)))
+	switch request.Status {
+	case "":
+		request.Status = domain.RequestStatusOpen
+	case domain.RequestStatusOpen:
+	case domain.RequestStatusDraft:
+		if len(preferences.Requested) > 0 {
+			return nil, fmt.Errorf("%w: draft pull request gets no reviewers", ErrInvalidReviewerRequest)
+		}
+	default:
+		return nil, fmt.Errorf("%w: pull request can't be created as %s", ErrInvalidStatusTransition, request.Status)
+	}
+	if err := checkReviewerPreferences(request.AuthorID, preferences); err != nil {
+		return nil, err
+	}
 	if p.teamRepository == nil {
 		return nil, ErrTeamRepositoryNotFound
 	} else if p.userRepository == nil {
@@ -38,77 +75,312 @@ func (p *PullRequest) CreatePullRequest(ctx context.Context, request *domain.Pul
 		return nil, ErrRequestOwnerRepositoryNotFound
 	} else if p.pullRequestRepository == nil {
 		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	} else if p.reviewerSelector == nil {
+		return nil, ErrReviewerSelectorNotFound
 	}
 
-	author, err := p.userRepository.GetUserByID(ctx, request.AuthorID)
-	if errors.Is(err, ErrMemberNotFound) {
-		return nil, ErrAuthorNotFound
-	} else if err != nil {
+	var (
+		created  *domain.PullRequest
+		settings domain.TeamSettings
+	)
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, settings, err = p.createPullRequest(ctx, request, preferences)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
-	if !author.IsActive {
-		return nil, ErrAuthorIsInactive
+
+	span.SetAttributes(attribute.Bool("pull_request.under_reviewed", created.UnderReviewed()))
+	if created.Status != domain.RequestStatusDraft {
+		p.recordAssignment(OperationCreate, settings, len(created.AssignedReviewersID))
 	}
-	_, err = p.pullRequestRepository.GetPullRequestByID(ctx, request.ID)
-	if err == nil {
-		return nil, ErrPullRequestAlreadyExists
-	} else if !errors.Is(err, ErrPullRequestNotFound) {
-		return nil, err
+	return created, nil
+}
+
+// createPullRequest создает PR и назначает ревьюверов по настройкам команды автора, которые и возвращает.
+func (p *PullRequest) createPullRequest(ctx context.Context, request *domain.PullRequest, preferences domain.ReviewerPreferences) (*domain.PullRequest, domain.TeamSettings, error) {
+	authorTeam, settings, err := p.newPullRequestTeams(ctx, request)
+	if err != nil {
+		return nil, settings, err
 	}
+	request.MinReviewers = settings.MinReviewers
 
 	err = p.pullRequestRepository.SavePullRequest(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, settings, err
 	}
 
 	err = p.requestOwnerRepository.SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: request.ID, UserID: request.AuthorID, Role: domain.UserRoleAuthor})
+	if err != nil {
+		return nil, settings, err
+	}
+
+	if request.Status == domain.RequestStatusDraft {
+		// ревьюверы черновика назначаются при переводе в OPEN, исключения проверяются уже сейчас
+		for _, userID := range preferences.Excluded {
+			if _, err := p.teamMember(ctx, userID, nil); err != nil && !errors.Is(err, ErrReviewerNotInTeam) {
+				return nil, settings, err
+			}
+		}
+		request.AssignedReviewersID = []string{}
+	} else {
+		request.AssignedReviewersID, err = p.assignReviewers(ctx, request, authorTeam, settings, preferences, domain.AssignmentReasonCreate)
+		if err != nil {
+			return nil, settings, err
+		}
+	}
+	// исключения действуют при назначении после черновика или повторного открытия и при всех заменах
+	if len(preferences.Excluded) > 0 {
+		if err := p.requestOwnerRepository.SaveExcludedReviewers(ctx, request.ID, preferences.Excluded); err != nil {
+			return nil, settings, err
+		}
+	}
+	return request, settings, nil
+}
+
+// assignReviewers назначает ревьюверов PR из команд автора authorTeams: сначала запрошенных автором,
+// остальные слоты до settings.MaxReviewers заполняет стратегия выбора. Назначения с причиной reason
+// пишутся в историю. Возвращает id назначенных.
+func (p *PullRequest) assignReviewers(ctx context.Context, request *domain.PullRequest, authorTeams []domain.Team, settings domain.TeamSettings, preferences domain.ReviewerPreferences, reason string) ([]string, error) {
+	plan, err := p.planReviewers(ctx, request.AuthorID, authorTeams, settings, preferences)
 	if err != nil {
 		return nil, err
 	}
+	picked, selection, err := selectReviewers(ctx, p.reviewerSelector, plan.team, plan.candidates, plan.slots)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := append(plan.requested, picked...)
+
+	strategy := selectorStrategy(p.reviewerSelector, plan.team)
+	assignedReviewers := make([]string, 0, len(reviewers))
+	events := make([]domain.AssignmentEvent, 0, len(reviewers))
+	for i, reviewer := range reviewers {
+		err := p.requestOwnerRepository.SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: request.ID, UserID: reviewer.ID, Role: domain.UserRoleReviewer})
+		if err != nil {
+			return nil, err
+		}
+		assignedReviewers = append(assignedReviewers, reviewer.ID)
+
+		event := newAssignmentEvent(ctx, request.ID, domain.AssignmentEventAssigned, reason)
+		event.UserID = reviewer.ID
+		event.Strategy = strategy
+		if i < len(plan.requested) {
+			event.Strategy = domain.AssignmentStrategyRequested
+		}
+		events = append(events, event)
+	}
+	if err := saveAssignmentEvents(ctx, p.requestOwnerRepository, events); err != nil {
+		return nil, err
+	}
+
+	if err := p.requestOwnerRepository.SaveAssignmentExplanation(ctx, &domain.AssignmentExplanation{
+		PullRequestID: request.ID,
+		Reason:        reason,
+		Strategy:      strategy,
+		TeamName:      plan.team,
+		Slots:         plan.slots,
+		Selected:      assignedReviewers,
+		Candidates:    mergeDecisions(plan.decisions, selection),
+	}); err != nil {
+		return nil, err
+	}
+	return assignedReviewers, nil
+}
+
+// newPullRequestTeams проверяет автора и отсутствие PR request.ID и возвращает команды автора
+// с настройками команды, чья стратегия выбора применяется. PR без ID не проверяется.
+func (p *PullRequest) newPullRequestTeams(ctx context.Context, request *domain.PullRequest) ([]domain.Team, domain.TeamSettings, error) {
+	var settings domain.TeamSettings
+
+	author, err := p.userRepository.GetUserByID(ctx, request.AuthorID)
+	if errors.Is(err, ErrMemberNotFound) {
+		return nil, settings, ErrAuthorNotFound
+	} else if err != nil {
+		return nil, settings, err
+	}
+	if !author.IsActive {
+		return nil, settings, ErrAuthorIsInactive
+	}
+	if request.ID != "" {
+		_, err = p.pullRequestRepository.GetPullRequestByID(ctx, request.ID)
+		if err == nil {
+			return nil, settings, ErrPullRequestAlreadyExists
+		} else if !errors.Is(err, ErrPullRequestNotFound) {
+			return nil, settings, err
+		}
+	}
 
 	authorTeam, err := p.userRepository.GetTeamsByUserID(ctx, request.AuthorID)
 	if errors.Is(err, ErrMemberNotFound) {
-		return nil, ErrAuthorNotFound
+		return nil, settings, ErrAuthorNotFound
 	} else if err != nil {
+		return nil, settings, err
+	}
+	// число ревьюверов задает команда, чья стратегия выбора применяется
+	settings, err = loadTeamSettings(ctx, p.teamRepository, selectionTeam(authorTeam))
+	if err != nil {
+		return nil, settings, err
+	}
+	return authorTeam, settings, nil
+}
+
+// PreviewAssignment показывает, кого CreatePullRequest назначил бы ревьюверами PR request, ничего не записывая.
+// Кандидаты отбираются так же, как при создании, а стратегия выбирает без побочных эффектов: курсор round_robin
+// не сдвигается, случайные стратегии разыгрывают выбор отдельным rng. Поэтому совпадение с созданием
+// гарантируется только для детерминированной стратегии.
+func (p *PullRequest) PreviewAssignment(ctx context.Context, request *domain.PullRequest, preferences domain.ReviewerPreferences) (_ *domain.AssignmentPreview, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.PreviewAssignment")
+	defer endSpan(span, &err)
+
+	if request == nil {
+		return nil, ErrAuthorNotFound
+	}
+	span.SetAttributes(attribute.String("pull_request.id", request.ID), attribute.String("pull_request.author_id", request.AuthorID),
+		attribute.Int("pull_request.requested_reviewers", len(preferences.Requested)), attribute.Int("pull_request.excluded_reviewers", len(preferences.Excluded)))
+	if err := checkReviewerPreferences(request.AuthorID, preferences); err != nil {
 		return nil, err
 	}
-	coworkers := make([]domain.User, 0, 10)
-	for _, team := range authorTeam {
+	if p.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	} else if p.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	} else if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.reviewerSelector == nil {
+		return nil, ErrReviewerSelectorNotFound
+	}
+
+	authorTeams, settings, err := p.newPullRequestTeams(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := p.planReviewers(ctx, request.AuthorID, authorTeams, settings, preferences)
+	if err != nil {
+		return nil, err
+	}
+	picked, selection, err := previewReviewers(ctx, p.reviewerSelector, plan.team, plan.candidates, plan.slots)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed := make([]string, 0, len(plan.requested)+len(picked))
+	for _, reviewer := range append(plan.requested, picked...) {
+		proposed = append(proposed, reviewer.ID)
+	}
+	strategy := selectorStrategy(p.reviewerSelector, plan.team)
+	return &domain.AssignmentPreview{
+		AuthorID:      request.AuthorID,
+		Strategy:      strategy,
+		TeamName:      plan.team,
+		MinReviewers:  settings.MinReviewers,
+		Slots:         plan.slots,
+		Proposed:      proposed,
+		Candidates:    mergeDecisions(plan.decisions, selection),
+		Deterministic: deterministicStrategy(strategy),
+	}, nil
+}
+
+// reviewerPlan - подготовка к выбору ревьюверов PR: запрошенные автором ревьюверы, кандидаты для стратегии
+// на оставшиеся слоты и решения по всем пользователям команд автора.
+type reviewerPlan struct {
+	team       string
+	requested  []domain.User
+	candidates []domain.User
+	slots      int
+	decisions  []domain.CandidateDecision
+}
+
+// planReviewers проверяет пожелания автора и отбирает кандидатов из команд автора. Автор, неактивные,
+// запрошенные и исключенные автором пользователи в кандидаты не попадают.
+func (p *PullRequest) planReviewers(ctx context.Context, authorID string, authorTeams []domain.Team, settings domain.TeamSettings, preferences domain.ReviewerPreferences) (*reviewerPlan, error) {
+	// pool - все пользователи команд автора без повторов, members - все они кроме автора
+	pool := make([]domain.User, 0, 10)
+	members := make(map[string]domain.User)
+	seen := make(map[string]struct{})
+	for _, team := range authorTeams {
+		// команда без участников не дает кандидатов, как и при замене ревьювера
 		cwrk, err := p.userRepository.GetUsersByTeamName(ctx, team.Name)
-		if errors.Is(err, ErrTeamNotFound) {
-			return nil, ErrTeamNotFound
+		if errors.Is(err, ErrMemberNotFound) {
+			continue
 		} else if err != nil {
 			return nil, err
 		}
 		for _, user := range cwrk {
-			if !user.IsActive {
-				continue
-			} else if user.ID == request.AuthorID {
+			if _, dup := seen[user.ID]; dup {
 				continue
 			}
-			coworkers = append(coworkers, user)
+			seen[user.ID] = struct{}{}
+			pool = append(pool, user)
+			if user.ID != authorID {
+				members[user.ID] = user
+			}
 		}
 	}
-	rand.Shuffle(len(coworkers), func(i, j int) { coworkers[i], coworkers[j] = coworkers[j], coworkers[i] })
 
-	assignedReviewers := make([]string, 0, 2)
-	for i := 2; i > 0 && len(coworkers) > 0; i-- {
-		err := p.requestOwnerRepository.SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: request.ID, UserID: coworkers[0].ID, Role: domain.UserRoleReviewer})
+	if len(preferences.Requested) > settings.MaxReviewers {
+		return nil, fmt.Errorf("%w: requested %d reviewers, team allows %d",
+			ErrInvalidReviewerRequest, len(preferences.Requested), settings.MaxReviewers)
+	}
+	requested := make(map[string]struct{}, len(preferences.Requested))
+	plan := &reviewerPlan{team: selectionTeam(authorTeams), requested: make([]domain.User, 0, settings.MaxReviewers)}
+	for _, userID := range preferences.Requested {
+		reviewer, err := p.teamMember(ctx, userID, members)
 		if err != nil {
 			return nil, err
 		}
-		assignedReviewers = append(assignedReviewers, coworkers[0].ID)
-		coworkers = coworkers[1:]
+		if !reviewer.IsActive {
+			return nil, fmt.Errorf("%w: %s", ErrReviewerInactive, userID)
+		}
+		requested[userID] = struct{}{}
+		plan.requested = append(plan.requested, reviewer)
+	}
+	excluded := make(map[string]struct{}, len(preferences.Excluded))
+	for _, userID := range preferences.Excluded {
+		// исключать можно и пользователей из других команд, но не несуществующих
+		if _, err := p.teamMember(ctx, userID, members); err != nil && !errors.Is(err, ErrReviewerNotInTeam) {
+			return nil, err
+		}
+		excluded[userID] = struct{}{}
+	}
+	plan.slots = settings.MaxReviewers - len(plan.requested)
+
+	plan.candidates = make([]domain.User, 0, len(pool))
+	plan.decisions = make([]domain.CandidateDecision, 0, len(pool))
+	for _, user := range pool {
+		decision := domain.CandidateDecision{UserID: user.ID}
+		_, isRequested := requested[user.ID]
+		_, isExcluded := excluded[user.ID]
+		switch {
+		case user.ID == authorID:
+			decision.ExcludedReason = domain.CandidateExcludedAuthor
+		case !user.IsActive:
+			decision.ExcludedReason = domain.CandidateExcludedInactive
+		case isRequested:
+			decision.Eligible, decision.Requested, decision.Selected = true, true, true
+		case isExcluded:
+			decision.ExcludedReason = domain.CandidateExcludedByAuthor
+		default:
+			decision.Eligible = true
+			plan.candidates = append(plan.candidates, user)
+		}
+		plan.decisions = append(plan.decisions, decision)
 	}
-	request.AssignedReviewersID = assignedReviewers
-	request.Status = domain.RequestStatusOpen
-	return request, nil
+	return plan, nil
 }
 
-func (p *PullRequest) UpdatePullRequest(ctx context.Context, request *domain.PullRequest) (*domain.PullRequest, error) {
+func (p *PullRequest) UpdatePullRequest(ctx context.Context, request *domain.PullRequest) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.UpdatePullRequest")
+	defer endSpan(span, &err)
+
 	if request == nil {
 		return nil, ErrAuthorNotFound
 	}
+	span.SetAttributes(attribute.String("pull_request.id", request.ID))
 	if p.teamRepository == nil {
 		return nil, ErrTeamRepositoryNotFound
 	} else if p.userRepository == nil {
@@ -117,8 +389,25 @@ func (p *PullRequest) UpdatePullRequest(ctx context.Context, request *domain.Pul
 		return nil, ErrRequestOwnerRepositoryNotFound
 	} else if p.pullRequestRepository == nil {
 		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	} else if p.reviewerSelector == nil {
+		return nil, ErrReviewerSelectorNotFound
+	}
+
+	var updated *domain.PullRequest
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = p.updatePullRequest(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return updated, nil
+}
 
+func (p *PullRequest) updatePullRequest(ctx context.Context, request *domain.PullRequest) (*domain.PullRequest, error) {
 	req, err := p.pullRequestRepository.GetPullRequestByID(ctx, request.ID)
 	if errors.Is(err, ErrPullRequestNotFound) {
 		return nil, ErrPullRequestNotFound
@@ -141,7 +430,16 @@ func (p *PullRequest) UpdatePullRequest(ctx context.Context, request *domain.Pul
 	return req, nil
 }
 
-func (p *PullRequest) MergePullRequest(ctx context.Context, id string) (*domain.PullRequest, error) {
+// MergePullRequest сливает PR, если выполнены условия команды автора: набрано required_approvals одобрений
+// и ни один ревьювер не запросил изменения. С override админ сливает PR в обход условий, обход пишется в аудит.
+// Повторное слияние возвращает PR без проверок.
+func (p *PullRequest) MergePullRequest(ctx context.Context, id string, override bool) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.MergePullRequest",
+		attribute.String("pull_request.id", id),
+		attribute.Bool("merge.override", override),
+	)
+	defer endSpan(span, &err)
+
 	if p.teamRepository == nil {
 		return nil, ErrTeamRepositoryNotFound
 	} else if p.userRepository == nil {
@@ -150,7 +448,30 @@ func (p *PullRequest) MergePullRequest(ctx context.Context, id string) (*domain.
 		return nil, ErrRequestOwnerRepositoryNotFound
 	} else if p.pullRequestRepository == nil {
 		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	} else if p.reviewerSelector == nil {
+		return nil, ErrReviewerSelectorNotFound
+	}
+	if override {
+		if err := requireAdmin(ctx); err != nil {
+			return nil, fmt.Errorf("%w: only admin can override merge conditions", err)
+		}
+	}
+
+	var merged *domain.PullRequest
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		merged, err = p.mergePullRequest(ctx, id, override)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return merged, nil
+}
+
+func (p *PullRequest) mergePullRequest(ctx context.Context, id string, override bool) (*domain.PullRequest, error) {
 	req, err := p.pullRequestRepository.GetPullRequestByID(ctx, id)
 	if errors.Is(err, ErrPullRequestNotFound) {
 		return nil, ErrPullRequestNotFound
@@ -160,15 +481,57 @@ func (p *PullRequest) MergePullRequest(ctx context.Context, id string) (*domain.
 	if req.Status == domain.RequestStatusMerged {
 		return req, nil
 	}
+	if err := checkStatusTransition(req.Status, domain.RequestStatusMerged); err != nil {
+		return nil, err
+	}
+
+	authorTeams, err := p.userRepository.GetTeamsByUserID(ctx, req.AuthorID)
+	if err != nil && !errors.Is(err, ErrTeamNotFound) {
+		return nil, err
+	}
+	settings, err := loadTeamSettings(ctx, p.teamRepository, selectionTeam(authorTeams))
+	if err != nil {
+		return nil, err
+	}
+	reason := domain.AssignmentReasonMerge
+	if blockers := req.MergeBlockers(settings.RequiredApprovals); len(blockers) > 0 {
+		if !override {
+			return nil, fmt.Errorf("%w: %s", ErrMergeBlocked, strings.Join(blockers, "; "))
+		}
+		actor := actorFromContext(ctx)
+		if err := p.pullRequestRepository.SaveMergeOverride(ctx, &domain.MergeOverride{
+			PullRequestID: req.ID,
+			ActorUserID:   actor.UserID,
+			ActorTokenID:  actor.TokenID,
+			Conditions:    blockers,
+		}); err != nil {
+			return nil, err
+		}
+		reason = domain.AssignmentReasonMergeOverride
+	}
+
 	req.Status = domain.RequestStatusMerged
-	req, err = p.UpdatePullRequest(ctx, req)
+	req.MergedAt = time.Now().UTC()
+	req, err = p.updatePullRequest(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+
+	event := newAssignmentEvent(ctx, req.ID, domain.AssignmentEventMerged, reason)
+	event.Status = domain.RequestStatusMerged
+	if err := saveAssignmentEvents(ctx, p.requestOwnerRepository, []domain.AssignmentEvent{event}); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
-func (p *PullRequest) ReassignRequest(ctx context.Context, requestID, userID string) (*domain.PullRequest, *domain.User, error) {
+func (p *PullRequest) ReassignRequest(ctx context.Context, requestID, userID string) (_ *domain.PullRequest, _ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.ReassignRequest",
+		attribute.String("pull_request.id", requestID),
+		attribute.String("user.id", userID),
+	)
+	defer endSpan(span, &err)
+
 	if p.teamRepository == nil {
 		return nil, nil, ErrTeamRepositoryNotFound
 	} else if p.userRepository == nil {
@@ -177,8 +540,34 @@ func (p *PullRequest) ReassignRequest(ctx context.Context, requestID, userID str
 		return nil, nil, ErrRequestOwnerRepositoryNotFound
 	} else if p.pullRequestRepository == nil {
 		return nil, nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, nil, ErrTransactorNotFound
+	} else if p.reviewerSelector == nil {
+		return nil, nil, ErrReviewerSelectorNotFound
 	}
 
+	var (
+		pr          *domain.PullRequest
+		newReviewer *domain.User
+	)
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, newReviewer, err = p.reassignRequest(ctx, requestID, userID)
+		return err
+	})
+	if errors.Is(err, ErrCannotFindActiveMembers) {
+		p.metrics.NoCandidate(OperationReassign, 1)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.metrics.ReviewersAssigned(OperationReassign, 1)
+	p.metrics.Reassigned(OperationReassign, 1)
+	return pr, newReviewer, nil
+}
+
+func (p *PullRequest) reassignRequest(ctx context.Context, requestID, userID string) (*domain.PullRequest, *domain.User, error) {
 	pr, err := p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
 	if errors.Is(err, ErrPullRequestNotFound) {
 		return nil, nil, ErrPullRequestNotFound
@@ -186,69 +575,102 @@ func (p *PullRequest) ReassignRequest(ctx context.Context, requestID, userID str
 		return nil, nil, err
 	}
 
-	if pr.Status == domain.RequestStatusMerged {
-		return nil, nil, nil
-	}
-
-	author, err := p.userRepository.GetUserByID(ctx, pr.AuthorID)
-	if errors.Is(err, ErrMemberNotFound) {
-		return nil, nil, ErrAuthorNotFound
+	if _, err := p.userRepository.GetUserByID(ctx, userID); errors.Is(err, ErrMemberNotFound) {
+		return nil, nil, ErrMemberNotFound
 	} else if err != nil {
 		return nil, nil, err
 	}
-	if !author.IsActive {
-		return nil, nil, ErrAuthorIsInactive
-	}
 
-	if err := p.requestOwnerRepository.DeleteRequestOwner(ctx, &domain.RequestOwner{
-		RequestID: pr.ID,
-		UserID:    userID,
-		Role:      domain.UserRoleReviewer,
-	}); err != nil {
+	// участник переназначает только свои ревью, лид - ревью любого участника своей команды
+	if err := requireLeadOf(ctx, p.teamRepository, userID, true); err != nil {
 		return nil, nil, err
 	}
 
-	owners, err := p.requestOwnerRepository.GetUsersByPullRequestID(ctx, pr.ID)
-	if err != nil {
-		return nil, nil, err
+	if pr.Status == domain.RequestStatusMerged {
+		return nil, nil, ErrPullRequestIsMerged
+	} else if !pr.Status.AcceptsReviews() {
+		return nil, nil, fmt.Errorf("%w: status %s", ErrPullRequestNotOpen, pr.Status)
+	}
+
+	assigned := make(map[string]struct{}, len(pr.AssignedReviewersID))
+	for _, id := range pr.AssignedReviewersID {
+		assigned[id] = struct{}{}
 	}
-	assigned := make(map[string]struct{}, len(owners))
-	for _, o := range owners {
-		assigned[o.UserID] = struct{}{}
+	if _, ok := assigned[userID]; !ok {
+		return nil, nil, ErrReviewerNotAssigned
 	}
 
-	authorTeam, err := p.userRepository.GetTeamsByUserID(ctx, pr.AuthorID)
-	if errors.Is(err, ErrMemberNotFound) {
-		return nil, nil, ErrAuthorNotFound
-	} else if err != nil {
+	// кандидаты ищутся в командах заменяемого ревьювера, а не автора
+	reviewerTeams, err := p.userRepository.GetTeamsByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, ErrTeamNotFound) {
 		return nil, nil, err
 	}
-	coworkers, err := p.userRepository.GetUsersByTeamName(ctx, authorTeam[0].Name)
-	if errors.Is(err, ErrTeamNotFound) {
-		return nil, nil, ErrTeamNotFound
-	} else if err != nil {
+	excludedIDs, err := p.requestOwnerRepository.GetExcludedReviewers(ctx, pr.ID)
+	if err != nil {
 		return nil, nil, err
 	}
+	excluded := make(map[string]struct{}, len(excludedIDs))
+	for _, id := range excludedIDs {
+		excluded[id] = struct{}{}
+	}
 
-	candidates := make([]domain.User, 0, len(coworkers))
-	for _, u := range coworkers {
-		if !u.IsActive {
-			continue
-		}
-		if u.ID == author.ID {
+	seen := make(map[string]struct{})
+	candidates := make([]domain.User, 0, 10)
+	decisions := make([]domain.CandidateDecision, 0, 10)
+	for _, team := range reviewerTeams {
+		coworkers, err := p.userRepository.GetUsersByTeamName(ctx, team.Name)
+		if errors.Is(err, ErrMemberNotFound) {
 			continue
+		} else if err != nil {
+			return nil, nil, err
 		}
-		if _, used := assigned[u.ID]; used {
-			continue
+		for _, u := range coworkers {
+			if _, dup := seen[u.ID]; dup {
+				continue
+			}
+			seen[u.ID] = struct{}{}
+
+			decision := domain.CandidateDecision{UserID: u.ID}
+			_, used := assigned[u.ID]
+			_, isExcluded := excluded[u.ID]
+			switch {
+			case !u.IsActive:
+				decision.ExcludedReason = domain.CandidateExcludedInactive
+			case u.ID == pr.AuthorID:
+				decision.ExcludedReason = domain.CandidateExcludedAuthor
+			case used:
+				decision.ExcludedReason = domain.CandidateExcludedAlreadyAssigned
+			case isExcluded:
+				decision.ExcludedReason = domain.CandidateExcludedByAuthor
+			default:
+				decision.Eligible = true
+				candidates = append(candidates, u)
+			}
+			decisions = append(decisions, decision)
 		}
-		candidates = append(candidates, u)
 	}
 
 	if len(candidates) == 0 {
 		return nil, nil, ErrCannotFindActiveMembers
 	}
 
-	newReviewer := candidates[rand.Intn(len(candidates))]
+	team := selectionTeam(reviewerTeams)
+	picked, selection, err := selectReviewers(ctx, p.reviewerSelector, team, candidates, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(picked) == 0 {
+		return nil, nil, ErrCannotFindActiveMembers
+	}
+	newReviewer := picked[0]
+
+	if err := p.requestOwnerRepository.DeleteRequestOwner(ctx, &domain.RequestOwner{
+		RequestID: pr.ID,
+		UserID:    userID,
+		Role:      domain.UserRoleReviewer,
+	}); err != nil {
+		return nil, nil, err
+	}
 
 	if err := p.requestOwnerRepository.SaveRequestOwner(ctx, &domain.RequestOwner{
 		RequestID: pr.ID,
@@ -258,6 +680,29 @@ func (p *PullRequest) ReassignRequest(ctx context.Context, requestID, userID str
 		return nil, nil, err
 	}
 
+	reason := assignmentReason(ctx, domain.AssignmentReasonReassign)
+	strategy := selectorStrategy(p.reviewerSelector, team)
+	event := newAssignmentEvent(ctx, pr.ID, domain.AssignmentEventReassigned, reason)
+	event.UserID = newReviewer.ID
+	event.PreviousUserID = userID
+	event.Strategy = strategy
+	if err := saveAssignmentEvents(ctx, p.requestOwnerRepository, []domain.AssignmentEvent{event}); err != nil {
+		return nil, nil, err
+	}
+
+	if err := p.requestOwnerRepository.SaveAssignmentExplanation(ctx, &domain.AssignmentExplanation{
+		PullRequestID:  pr.ID,
+		Reason:         reason,
+		Strategy:       strategy,
+		TeamName:       team,
+		ReplacedUserID: userID,
+		Slots:          1,
+		Selected:       []string{newReviewer.ID},
+		Candidates:     mergeDecisions(decisions, selection),
+	}); err != nil {
+		return nil, nil, err
+	}
+
 	pr, err = p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
 	if err != nil {
 		return nil, nil, err
@@ -265,3 +710,366 @@ func (p *PullRequest) ReassignRequest(ctx context.Context, requestID, userID str
 
 	return pr, &newReviewer, nil
 }
+
+// AddReviewer назначает дополнительного ревьювера на открытый PR в пределах max_reviewers команды автора.
+// Доступно админу и лиду команды автора.
+func (p *PullRequest) AddReviewer(ctx context.Context, requestID, userID string) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.AddReviewer",
+		attribute.String("pull_request.id", requestID),
+		attribute.String("user.id", userID),
+	)
+	defer endSpan(span, &err)
+
+	if p.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	} else if p.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	} else if p.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	} else if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var pr *domain.PullRequest
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = p.addReviewer(ctx, requestID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.metrics.ReviewersAssigned(OperationAddReviewer, 1)
+	return pr, nil
+}
+
+func (p *PullRequest) addReviewer(ctx context.Context, requestID, userID string) (*domain.PullRequest, error) {
+	pr, err := p.openPullRequestForReviewers(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == pr.AuthorID {
+		return nil, fmt.Errorf("%w: author %s can't review own pull request", ErrInvalidReviewerRequest, userID)
+	}
+	for _, id := range pr.AssignedReviewersID {
+		if id == userID {
+			return nil, ErrReviewerAlreadyAssigned
+		}
+	}
+
+	// дополнительный ревьювер может быть и не из команды автора, например эксперт по затронутому коду
+	reviewer, err := p.userRepository.GetUserByID(ctx, userID)
+	if errors.Is(err, ErrMemberNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrReviewerNotFound, userID)
+	} else if err != nil {
+		return nil, err
+	}
+	if !reviewer.IsActive {
+		return nil, fmt.Errorf("%w: %s", ErrReviewerInactive, userID)
+	}
+
+	authorTeams, err := p.userRepository.GetTeamsByUserID(ctx, pr.AuthorID)
+	if err != nil && !errors.Is(err, ErrTeamNotFound) {
+		return nil, err
+	}
+	settings, err := loadTeamSettings(ctx, p.teamRepository, selectionTeam(authorTeams))
+	if err != nil {
+		return nil, err
+	}
+	if len(pr.AssignedReviewersID) >= settings.MaxReviewers {
+		return nil, fmt.Errorf("%w: %d of %d", ErrReviewerLimitReached, len(pr.AssignedReviewersID), settings.MaxReviewers)
+	}
+
+	if err := p.requestOwnerRepository.SaveRequestOwner(ctx, &domain.RequestOwner{
+		RequestID: pr.ID,
+		UserID:    userID,
+		Role:      domain.UserRoleReviewer,
+	}); err != nil {
+		return nil, err
+	}
+
+	event := newAssignmentEvent(ctx, pr.ID, domain.AssignmentEventAssigned, domain.AssignmentReasonAddReviewer)
+	event.UserID = userID
+	event.Strategy = domain.AssignmentStrategyManual
+	if err := saveAssignmentEvents(ctx, p.requestOwnerRepository, []domain.AssignmentEvent{event}); err != nil {
+		return nil, err
+	}
+
+	return p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+}
+
+// RemoveReviewer снимает ревьювера с открытого PR без замены. Доступно админу и лиду команды автора.
+func (p *PullRequest) RemoveReviewer(ctx context.Context, requestID, userID string) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.RemoveReviewer",
+		attribute.String("pull_request.id", requestID),
+		attribute.String("user.id", userID),
+	)
+	defer endSpan(span, &err)
+
+	if p.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	} else if p.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	} else if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var pr *domain.PullRequest
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = p.removeReviewer(ctx, requestID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (p *PullRequest) removeReviewer(ctx context.Context, requestID, userID string) (*domain.PullRequest, error) {
+	pr, err := p.openPullRequestForReviewers(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := false
+	for _, id := range pr.AssignedReviewersID {
+		if id == userID {
+			assigned = true
+			break
+		}
+	}
+	if !assigned {
+		return nil, ErrReviewerNotAssigned
+	}
+
+	if err := p.requestOwnerRepository.DeleteRequestOwner(ctx, &domain.RequestOwner{
+		RequestID: pr.ID,
+		UserID:    userID,
+		Role:      domain.UserRoleReviewer,
+	}); err != nil {
+		return nil, err
+	}
+
+	event := newAssignmentEvent(ctx, pr.ID, domain.AssignmentEventUnassigned, domain.AssignmentReasonRemoveReviewer)
+	event.UserID = userID
+	if err := saveAssignmentEvents(ctx, p.requestOwnerRepository, []domain.AssignmentEvent{event}); err != nil {
+		return nil, err
+	}
+
+	return p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+}
+
+// GetHistory возвращает историю назначений ревьюверов PR в порядке событий.
+// Доступно автору PR, лиду его команды и админу.
+func (p *PullRequest) GetHistory(ctx context.Context, requestID string) (_ []domain.AssignmentEvent, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.GetHistory", attribute.String("pull_request.id", requestID))
+	defer endSpan(span, &err)
+
+	if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+
+	pr, err := p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+	if errors.Is(err, ErrPullRequestNotFound) {
+		return nil, ErrPullRequestNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if err := requireLeadOf(ctx, p.teamRepository, pr.AuthorID, true); err != nil {
+		return nil, err
+	}
+	return p.requestOwnerRepository.GetAssignmentEventsByPullRequestID(ctx, requestID)
+}
+
+// GetExplanations возвращает объяснения выбора ревьюверов PR в порядке выбора.
+// Доступно автору PR, лиду его команды, админу и ревьюверам, выбранным хотя бы в одном из объяснений.
+func (p *PullRequest) GetExplanations(ctx context.Context, requestID string) (_ []domain.AssignmentExplanation, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.GetExplanations", attribute.String("pull_request.id", requestID))
+	defer endSpan(span, &err)
+
+	if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+
+	pr, err := p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+	if errors.Is(err, ErrPullRequestNotFound) {
+		return nil, ErrPullRequestNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	explanations, err := p.requestOwnerRepository.GetAssignmentExplanationsByPullRequestID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessErr := requireLeadOf(ctx, p.teamRepository, pr.AuthorID, true)
+	if accessErr == nil {
+		return explanations, nil
+	} else if !errors.Is(accessErr, ErrForbidden) {
+		return nil, accessErr
+	}
+	// ревьювер может узнать, почему выбрали его
+	if actor := actorFromContext(ctx); actor.UserID != "" {
+		for _, explanation := range explanations {
+			if slices.Contains(explanation.Selected, actor.UserID) {
+				return explanations, nil
+			}
+		}
+	}
+	return nil, accessErr
+}
+
+// openPullRequestForReviewers возвращает PR, ревьюверов которого можно менять вручную:
+// PR не слит, а пользователь из контекста - админ или лид команды автора.
+func (p *PullRequest) openPullRequestForReviewers(ctx context.Context, requestID string) (*domain.PullRequest, error) {
+	pr, err := p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+	if errors.Is(err, ErrPullRequestNotFound) {
+		return nil, ErrPullRequestNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := requireLeadOf(ctx, p.teamRepository, pr.AuthorID, false); err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.RequestStatusMerged {
+		return nil, ErrPullRequestIsMerged
+	} else if !pr.Status.AcceptsReviews() {
+		return nil, fmt.Errorf("%w: status %s", ErrPullRequestNotOpen, pr.Status)
+	}
+	return pr, nil
+}
+
+// SubmitReview сохраняет решение назначенного ревьювера по открытому PR. Отправить решение может только сам ревьювер,
+// повторное решение не заменяет предыдущие, а становится последним.
+func (p *PullRequest) SubmitReview(ctx context.Context, review *domain.ReviewDecision) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.SubmitReview")
+	defer endSpan(span, &err)
+
+	if review == nil {
+		return nil, ErrInvalidReviewState
+	}
+	span.SetAttributes(
+		attribute.String("pull_request.id", review.PullRequestID),
+		attribute.String("user.id", review.UserID),
+		attribute.String("review.decision", string(review.State)),
+	)
+	if !review.State.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidReviewState, review.State)
+	}
+	if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var pr *domain.PullRequest
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = p.submitReview(ctx, review)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+func (p *PullRequest) submitReview(ctx context.Context, review *domain.ReviewDecision) (*domain.PullRequest, error) {
+	pr, err := p.pullRequestRepository.GetPullRequestByID(ctx, review.PullRequestID)
+	if errors.Is(err, ErrPullRequestNotFound) {
+		return nil, ErrPullRequestNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := requireSelf(ctx, review.UserID); err != nil {
+		return nil, err
+	}
+
+	if pr.Status == domain.RequestStatusMerged {
+		return nil, ErrPullRequestIsMerged
+	} else if !pr.Status.AcceptsReviews() {
+		return nil, fmt.Errorf("%w: status %s", ErrPullRequestNotOpen, pr.Status)
+	}
+	assigned := false
+	for _, id := range pr.AssignedReviewersID {
+		if id == review.UserID {
+			assigned = true
+			break
+		}
+	}
+	if !assigned {
+		return nil, ErrReviewerNotAssigned
+	}
+
+	if err := p.pullRequestRepository.SaveReviewDecision(ctx, review); err != nil {
+		return nil, err
+	}
+
+	return p.pullRequestRepository.GetPullRequestByID(ctx, review.PullRequestID)
+}
+
+// recordAssignment учитывает в метриках назначенных ревьюверов и слоты, для которых не нашлось кандидата.
+func (p *PullRequest) recordAssignment(operation string, settings domain.TeamSettings, assigned int) {
+	p.metrics.ReviewersAssigned(operation, assigned)
+	if missing := settings.MaxReviewers - assigned; missing > 0 {
+		p.metrics.NoCandidate(operation, missing)
+	}
+}
+
+// checkReviewerPreferences проверяет пожелания автора без обращения к БД: без повторов,
+// без самого автора и без пользователей, которые одновременно запрошены и исключены.
+func checkReviewerPreferences(authorID string, preferences domain.ReviewerPreferences) error {
+	requested := make(map[string]struct{}, len(preferences.Requested))
+	for _, userID := range preferences.Requested {
+		if userID == authorID {
+			return fmt.Errorf("%w: author %s can't review own pull request", ErrInvalidReviewerRequest, userID)
+		} else if _, dup := requested[userID]; dup {
+			return fmt.Errorf("%w: reviewer %s requested twice", ErrInvalidReviewerRequest, userID)
+		}
+		requested[userID] = struct{}{}
+	}
+	for _, userID := range preferences.Excluded {
+		if _, ok := requested[userID]; ok {
+			return fmt.Errorf("%w: reviewer %s is both requested and excluded", ErrInvalidReviewerRequest, userID)
+		}
+	}
+	return nil
+}
+
+// teamMember возвращает участника команд автора из members. Для остальных пользователей
+// различает несуществующих (ErrReviewerNotFound) и состоящих в других командах (ErrReviewerNotInTeam).
+func (p *PullRequest) teamMember(ctx context.Context, userID string, members map[string]domain.User) (domain.User, error) {
+	if user, ok := members[userID]; ok {
+		return user, nil
+	}
+	_, err := p.userRepository.GetUserByID(ctx, userID)
+	if errors.Is(err, ErrMemberNotFound) {
+		return domain.User{}, fmt.Errorf("%w: %s", ErrReviewerNotFound, userID)
+	} else if err != nil {
+		return domain.User{}, err
+	}
+	return domain.User{}, fmt.Errorf("%w: %s", ErrReviewerNotInTeam, userID)
+}
+
+// selectionTeam - команда, чья стратегия выбора применяется: если пользователь состоит
+// в нескольких командах, берется первая.
+func selectionTeam(teams []domain.Team) string {
+	if len(teams) == 0 {
+		return ""
+	}
+	return teams[0].Name
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// statusTransitions - допустимые переходы между статусами PR. Из MERGED переходов нет.
+var statusTransitions = map[domain.RequestStatus][]domain.RequestStatus{
+	domain.RequestStatusDraft:    {domain.RequestStatusOpen, domain.RequestStatusClosed},
+	domain.RequestStatusOpen:     {domain.RequestStatusMerged, domain.RequestStatusClosed},
+	domain.RequestStatusReopened: {domain.RequestStatusMerged, domain.RequestStatusClosed},
+	domain.RequestStatusClosed:   {domain.RequestStatusReopened},
+}
+
+// checkStatusTransition возвращает ErrInvalidStatusTransition, если PR нельзя перевести из from в to.
+func checkStatusTransition(from, to domain.RequestStatus) error {
+	if slices.Contains(statusTransitions[from], to) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, to)
+}
+
+// ChangeStatus переводит PR в статус status по правилам statusTransitions:
+// DRAFT -> OPEN назначает ревьюверов, переход в CLOSED снимает всех ревьюверов,
+// CLOSED -> REOPENED назначает ревьюверов заново: решения, принятые до нового назначения,
+// не учитываются при слиянии. Повторный переход в текущий статус ничего не меняет.
+// Менять статус могут автор PR, лид команды автора и админ.
+// Слияние выполняется только через MergePullRequest, где проверяются условия команды.
+func (p *PullRequest) ChangeStatus(ctx context.Context, requestID string, status domain.RequestStatus) (_ *domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "PullRequest.ChangeStatus",
+		attribute.String("pull_request.id", requestID),
+		attribute.String("pull_request.status", string(status)),
+	)
+	defer endSpan(span, &err)
+
+	if status == domain.RequestStatusMerged {
+		return nil, fmt.Errorf("%w: use merge to move pull request to %s", ErrInvalidStatusTransition, status)
+	}
+	if p.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	} else if p.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	} else if p.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	} else if p.pullRequestRepository == nil {
+		return nil, ErrPullRequestRepositoryNotFound
+	} else if p.transactor == nil {
+		return nil, ErrTransactorNotFound
+	} else if p.reviewerSelector == nil {
+		return nil, ErrReviewerSelectorNotFound
+	}
+
+	var (
+		changed  *domain.PullRequest
+		settings *domain.TeamSettings
+	)
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		changed, settings, err = p.changeStatus(ctx, requestID, status)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// settings загружаются только при назначении ревьюверов
+	if settings != nil {
+		operation := OperationReady
+		if status == domain.RequestStatusReopened {
+			operation = OperationReopen
+		}
+		p.recordAssignment(operation, *settings, len(changed.AssignedReviewersID))
+	}
+	return changed, nil
+}
+
+func (p *PullRequest) changeStatus(ctx context.Context, requestID string, status domain.RequestStatus) (*domain.PullRequest, *domain.TeamSettings, error) {
+	pr, err := p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+	if errors.Is(err, ErrPullRequestNotFound) {
+		return nil, nil, ErrPullRequestNotFound
+	} else if err != nil {
+		return nil, nil, err
+	}
+	// статусом PR управляет его автор или лид команды автора
+	if err := requireLeadOf(ctx, p.teamRepository, pr.AuthorID, true); err != nil {
+		return nil, nil, err
+	}
+	if pr.Status == status {
+		return pr, nil, nil
+	}
+	if err := checkStatusTransition(pr.Status, status); err != nil {
+		return nil, nil, err
+	}
+
+	reason := statusChangeReason(status)
+	events := make([]domain.AssignmentEvent, 0, len(pr.AssignedReviewersID)+1)
+	if status == domain.RequestStatusClosed {
+		for _, reviewerID := range pr.AssignedReviewersID {
+			if err := p.requestOwnerRepository.DeleteRequestOwner(ctx, &domain.RequestOwner{
+				RequestID: pr.ID,
+				UserID:    reviewerID,
+				Role:      domain.UserRoleReviewer,
+			}); err != nil {
+				return nil, nil, err
+			}
+			event := newAssignmentEvent(ctx, pr.ID, domain.AssignmentEventUnassigned, reason)
+			event.UserID = reviewerID
+			events = append(events, event)
+		}
+	}
+
+	pr.Status = status
+	if err := p.pullRequestRepository.UpdatePullRequest(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+	event := newAssignmentEvent(ctx, pr.ID, domain.AssignmentEventStatusChanged, reason)
+	event.Status = status
+	if err := saveAssignmentEvents(ctx, p.requestOwnerRepository, append(events, event)); err != nil {
+		return nil, nil, err
+	}
+
+	var settings *domain.TeamSettings
+	if status.AcceptsReviews() {
+		authorTeams, err := p.userRepository.GetTeamsByUserID(ctx, pr.AuthorID)
+		if err != nil && !errors.Is(err, ErrTeamNotFound) {
+			return nil, nil, err
+		}
+		loaded, err := loadTeamSettings(ctx, p.teamRepository, selectionTeam(authorTeams))
+		if err != nil {
+			return nil, nil, err
+		}
+		settings = &loaded
+		// исключения автора, сохраненные при создании PR, действуют и при повторном назначении
+		excluded, err := p.requestOwnerRepository.GetExcludedReviewers(ctx, pr.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := p.assignReviewers(ctx, pr, authorTeams, loaded, domain.ReviewerPreferences{Excluded: excluded}, reason); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pr, err = p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pr, settings, nil
+}
+
+// statusChangeReason - причина событий истории при переходе PR в статус status.
+func statusChangeReason(status domain.RequestStatus) string {
+	switch status {
+	case domain.RequestStatusOpen:
+		return domain.AssignmentReasonReady
+	case domain.RequestStatusReopened:
+		return domain.AssignmentReasonReopen
+	default:
+		return domain.AssignmentReasonClose
+	}
+}
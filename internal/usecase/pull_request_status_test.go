@@ -0,0 +1,447 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestCheckStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to domain.RequestStatus
+		allowed  bool
+	}{
+		{from: domain.RequestStatusDraft, to: domain.RequestStatusOpen, allowed: true},
+		{from: domain.RequestStatusDraft, to: domain.RequestStatusClosed, allowed: true},
+		{from: domain.RequestStatusDraft, to: domain.RequestStatusMerged, allowed: false},
+		{from: domain.RequestStatusOpen, to: domain.RequestStatusMerged, allowed: true},
+		{from: domain.RequestStatusOpen, to: domain.RequestStatusClosed, allowed: true},
+		{from: domain.RequestStatusOpen, to: domain.RequestStatusDraft, allowed: false},
+		{from: domain.RequestStatusOpen, to: domain.RequestStatusReopened, allowed: false},
+		{from: domain.RequestStatusClosed, to: domain.RequestStatusReopened, allowed: true},
+		{from: domain.RequestStatusClosed, to: domain.RequestStatusOpen, allowed: false},
+		{from: domain.RequestStatusClosed, to: domain.RequestStatusMerged, allowed: false},
+		{from: domain.RequestStatusReopened, to: domain.RequestStatusMerged, allowed: true},
+		{from: domain.RequestStatusReopened, to: domain.RequestStatusClosed, allowed: true},
+		{from: domain.RequestStatusMerged, to: domain.RequestStatusClosed, allowed: false},
+		{from: domain.RequestStatusMerged, to: domain.RequestStatusReopened, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := checkStatusTransition(tt.from, tt.to)
+			if tt.allowed && err != nil {
+				t.Fatalf("expected transition to be allowed, got %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Fatalf("expected ErrInvalidStatusTransition, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPullRequest_ChangeStatus(t *testing.T) {
+	coworkers := []domain.User{
+		{ID: "author-1", IsActive: true},
+		{ID: "u1", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "u3", IsActive: false},
+	}
+	pullRequest := func(status domain.RequestStatus, reviewers ...string) *domain.PullRequest {
+		if reviewers == nil {
+			reviewers = []string{}
+		}
+		return &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: status, AssignedReviewersID: reviewers}
+	}
+
+	tests := []struct {
+		name          string
+		current       *domain.PullRequest
+		status        domain.RequestStatus
+		excluded      []string
+		wantReleased  []string
+		wantAssigned  []string
+		wantOperation string
+		wantErr       error
+	}{
+		{
+			name:          "draft is marked ready",
+			current:       pullRequest(domain.RequestStatusDraft),
+			status:        domain.RequestStatusOpen,
+			wantAssigned:  []string{"u1", "u2"},
+			wantOperation: OperationReady,
+		},
+		{
+			name:          "draft exclusions apply when marked ready",
+			current:       pullRequest(domain.RequestStatusDraft),
+			status:        domain.RequestStatusOpen,
+			excluded:      []string{"u1"},
+			wantAssigned:  []string{"u2"},
+			wantOperation: OperationReady,
+		},
+		{
+			name:         "open is closed",
+			current:      pullRequest(domain.RequestStatusOpen, "u1", "u2"),
+			status:       domain.RequestStatusClosed,
+			wantReleased: []string{"u1", "u2"},
+		},
+		{
+			name:         "reopened is closed",
+			current:      pullRequest(domain.RequestStatusReopened, "u2"),
+			status:       domain.RequestStatusClosed,
+			wantReleased: []string{"u2"},
+		},
+		{
+			name:          "closed is reopened",
+			current:       pullRequest(domain.RequestStatusClosed),
+			status:        domain.RequestStatusReopened,
+			wantAssigned:  []string{"u1", "u2"},
+			wantOperation: OperationReopen,
+		},
+		{
+			name:    "same status",
+			current: pullRequest(domain.RequestStatusClosed),
+			status:  domain.RequestStatusClosed,
+		},
+		{
+			name:    "merged can't be closed",
+			current: pullRequest(domain.RequestStatusMerged, "u1"),
+			status:  domain.RequestStatusClosed,
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:    "closed can't be marked ready",
+			current: pullRequest(domain.RequestStatusClosed),
+			status:  domain.RequestStatusOpen,
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:    "merge goes through MergePullRequest",
+			status:  domain.RequestStatusMerged,
+			wantErr: ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			mockMetrics := NewMockMetrics(ctrl)
+
+			changed := pullRequest(tt.status, tt.wantAssigned...)
+			if tt.current != nil {
+				mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(tt.current, nil)
+			}
+			for _, userID := range tt.wantReleased {
+				mockReqOwnerRepo.EXPECT().DeleteRequestOwner(ctx, &domain.RequestOwner{RequestID: "pr-1", UserID: userID, Role: domain.UserRoleReviewer}).Return(nil)
+			}
+			changes := tt.wantErr == nil && tt.current != nil && tt.current.Status != tt.status
+			reason := statusChangeReason(tt.status)
+			if changes {
+				mockPRRepo.EXPECT().UpdatePullRequest(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, pr *domain.PullRequest) error {
+					if pr.Status != tt.status {
+						t.Fatalf("expected status %s to be saved, got %s", tt.status, pr.Status)
+					}
+					return nil
+				})
+				events := make([]domain.AssignmentEvent, 0, len(tt.wantReleased)+1)
+				for _, userID := range tt.wantReleased {
+					events = append(events, domain.AssignmentEvent{PullRequestID: "pr-1", Type: domain.AssignmentEventUnassigned, UserID: userID, Reason: reason})
+				}
+				events = append(events, domain.AssignmentEvent{PullRequestID: "pr-1", Type: domain.AssignmentEventStatusChanged, Status: tt.status, Reason: reason})
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, events).Return(nil)
+			}
+			if tt.wantAssigned != nil {
+				mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil)
+				mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil)
+				mockReqOwnerRepo.EXPECT().GetExcludedReviewers(ctx, "pr-1").Return(tt.excluded, nil)
+				mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(coworkers, nil)
+				assigned := make([]domain.AssignmentEvent, 0, len(tt.wantAssigned))
+				for _, userID := range tt.wantAssigned {
+					mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: "pr-1", UserID: userID, Role: domain.UserRoleReviewer}).Return(nil)
+					assigned = append(assigned, domain.AssignmentEvent{PullRequestID: "pr-1", Type: domain.AssignmentEventAssigned, UserID: userID, Reason: reason, Strategy: StrategyRoundRobin})
+				}
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, assigned).Return(nil)
+				mockReqOwnerRepo.EXPECT().
+					SaveAssignmentExplanation(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, explanation *domain.AssignmentExplanation) error {
+						if explanation.Reason != reason || !reflect.DeepEqual(explanation.Selected, tt.wantAssigned) {
+							t.Fatalf("unexpected explanation: %#v", explanation)
+						}
+						return nil
+					})
+				mockMetrics.EXPECT().ReviewersAssigned(tt.wantOperation, len(tt.wantAssigned))
+				if missing := domain.DefaultTeamSettings("team-1").MaxReviewers - len(tt.wantAssigned); missing > 0 {
+					mockMetrics.EXPECT().NoCandidate(tt.wantOperation, missing)
+				}
+			}
+			if changes {
+				mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(changed, nil)
+			}
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), NewRoundRobinSelector(), mockMetrics)
+
+			// Act
+			got, err := uc.ChangeStatus(ctx, "pr-1", tt.status)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, changed) {
+				t.Fatalf("got %#v, want %#v", got, changed)
+			}
+		})
+	}
+}
+
+func TestPullRequest_CreatePullRequest_Draft(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	// черновик не учитывается в метриках назначений
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), NewMockMetrics(ctrl))
+
+	author := &domain.User{ID: "author-1", IsActive: true}
+	pr := &domain.PullRequest{ID: "pr-1", Name: "Draft PR", AuthorID: author.ID, Status: domain.RequestStatusDraft}
+
+	mockUserRepo.EXPECT().GetUserByID(ctx, author.ID).Return(author, nil)
+	mockPRRepo.EXPECT().GetPullRequestByID(ctx, pr.ID).Return(nil, ErrPullRequestNotFound)
+	mockUserRepo.EXPECT().GetTeamsByUserID(ctx, author.ID).Return([]domain.Team{{Name: "team-1"}}, nil)
+	mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil)
+	mockPRRepo.EXPECT().
+		SavePullRequest(ctx, pr).
+		DoAndReturn(func(_ context.Context, saved *domain.PullRequest) error {
+			if saved.Status != domain.RequestStatusDraft {
+				t.Fatalf("expected PR to be saved as draft, got %s", saved.Status)
+			}
+			return nil
+		})
+	mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: author.ID, Role: domain.UserRoleAuthor}).Return(nil)
+	// исключения черновика проверяются и сохраняются до перевода в OPEN
+	mockUserRepo.EXPECT().GetUserByID(ctx, "u1").Return(&domain.User{ID: "u1", IsActive: true}, nil)
+	mockReqOwnerRepo.EXPECT().SaveExcludedReviewers(ctx, pr.ID, []string{"u1"}).Return(nil)
+
+	// Act
+	got, err := uc.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{Excluded: []string{"u1"}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreatePullRequest() unexpected error: %v", err)
+	}
+	if got.Status != domain.RequestStatusDraft || len(got.AssignedReviewersID) != 0 {
+		t.Fatalf("expected draft without reviewers, got %#v", got)
+	}
+}
+
+func TestPullRequest_CreatePullRequest_InvalidStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      domain.RequestStatus
+		preferences domain.ReviewerPreferences
+		wantErr     error
+	}{
+		{name: "draft with requested reviewers", status: domain.RequestStatusDraft, preferences: domain.ReviewerPreferences{Requested: []string{"u1"}}, wantErr: ErrInvalidReviewerRequest},
+		{name: "created closed", status: domain.RequestStatusClosed, wantErr: ErrInvalidStatusTransition},
+		{name: "created merged", status: domain.RequestStatusMerged, wantErr: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// проверка выполняется до обращения к репозиториям
+			uc := NewPullRequest(NewMockPullRequestRepository(ctrl), NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			_, err := uc.CreatePullRequest(WithActor(context.Background(), actorAdmin), &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: tt.status}, tt.preferences)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPullRequest_CloseReopenMerge_RequiresNewApprovals(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	// состояние БД: время назначения ревьюверов и решения; в PR попадают только решения,
+	// принятые после текущего назначения ревьювера, как в GetPullRequestByID
+	var (
+		now         = 2
+		status      = domain.RequestStatusOpen
+		assignedAt  = map[string]int{"u1": 1}
+		decisions   = []domain.ReviewDecision{{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved}}
+		decidedAt   = []int{2}
+		tick        = func() int { now++; return now }
+		pullRequest = func() *domain.PullRequest {
+			pr := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: status, AssignedReviewersID: []string{}, Reviews: []domain.ReviewDecision{}}
+			for userID, at := range assignedAt {
+				pr.AssignedReviewersID = append(pr.AssignedReviewersID, userID)
+				for i, decision := range decisions {
+					if decision.UserID == userID && decidedAt[i] >= at {
+						pr.Reviews = append(pr.Reviews, decision)
+					}
+				}
+			}
+			return pr
+		}
+	)
+	mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").DoAndReturn(func(context.Context, string) (*domain.PullRequest, error) {
+		return pullRequest(), nil
+	}).AnyTimes()
+	mockPRRepo.EXPECT().UpdatePullRequest(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, pr *domain.PullRequest) error {
+		status = pr.Status
+		return nil
+	}).AnyTimes()
+	mockReqOwnerRepo.EXPECT().DeleteRequestOwner(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, owner *domain.RequestOwner) error {
+		delete(assignedAt, owner.UserID)
+		return nil
+	}).AnyTimes()
+	mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, owner *domain.RequestOwner) error {
+		assignedAt[owner.UserID] = tick()
+		return nil
+	}).AnyTimes()
+	mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Any()).Return(nil).AnyTimes()
+	mockReqOwnerRepo.EXPECT().SaveAssignmentExplanation(ctx, gomock.Any()).Return(nil).AnyTimes()
+	mockReqOwnerRepo.EXPECT().GetExcludedReviewers(ctx, "pr-1").Return([]string{}, nil).AnyTimes()
+	mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil).AnyTimes()
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return([]domain.User{{ID: "author-1", IsActive: true}, {ID: "u1", IsActive: true}}, nil).AnyTimes()
+	mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(&domain.TeamSettings{TeamName: "team-1", MaxReviewers: 1, RequiredApprovals: 1}, nil).AnyTimes()
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), NewRoundRobinSelector(), nil)
+
+	// Act
+	if _, err := uc.ChangeStatus(ctx, "pr-1", domain.RequestStatusClosed); err != nil {
+		t.Fatalf("close: unexpected error %v", err)
+	}
+	reopened, err := uc.ChangeStatus(ctx, "pr-1", domain.RequestStatusReopened)
+	if err != nil {
+		t.Fatalf("reopen: unexpected error %v", err)
+	}
+	_, blockedErr := uc.MergePullRequest(ctx, "pr-1", false)
+
+	decisions = append(decisions, domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved})
+	decidedAt = append(decidedAt, tick())
+	merged, mergeErr := uc.MergePullRequest(ctx, "pr-1", false)
+
+	// Assert
+	if !reflect.DeepEqual(reopened.AssignedReviewersID, []string{"u1"}) || len(reopened.Reviews) != 0 {
+		t.Fatalf("expected reopened pull request without old reviews, got %#v", reopened)
+	}
+	if !errors.Is(blockedErr, ErrMergeBlocked) {
+		t.Fatalf("expected merge to be blocked after reopen, got %v", blockedErr)
+	}
+	if mergeErr != nil || merged.Status != domain.RequestStatusMerged {
+		t.Fatalf("expected merge after new approval, got %#v, %v", merged, mergeErr)
+	}
+}
+
+func TestPullRequest_DraftReady_KeepsExclusions(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	// состояние БД: сохраненный PR, его ревьюверы и исключения автора
+	var (
+		saved     *domain.PullRequest
+		reviewers []string
+		excluded  []string
+	)
+	members := []domain.User{{ID: "author-1", IsActive: true}, {ID: "u1", IsActive: true}, {ID: "u2", IsActive: true}, {ID: "u3", IsActive: true}}
+	mockUserRepo.EXPECT().GetUserByID(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, userID string) (*domain.User, error) {
+		return &domain.User{ID: userID, IsActive: true}, nil
+	}).AnyTimes()
+	mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").DoAndReturn(func(context.Context, string) (*domain.PullRequest, error) {
+		if saved == nil {
+			return nil, ErrPullRequestNotFound
+		}
+		pr := *saved
+		pr.AssignedReviewersID = append([]string{}, reviewers...)
+		return &pr, nil
+	}).AnyTimes()
+	mockPRRepo.EXPECT().SavePullRequest(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, pr *domain.PullRequest) error {
+		stored := *pr
+		saved = &stored
+		return nil
+	})
+	mockPRRepo.EXPECT().UpdatePullRequest(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, pr *domain.PullRequest) error {
+		saved.Status = pr.Status
+		return nil
+	})
+	mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, owner *domain.RequestOwner) error {
+		if owner.Role == domain.UserRoleReviewer {
+			reviewers = append(reviewers, owner.UserID)
+		}
+		return nil
+	}).AnyTimes()
+	mockReqOwnerRepo.EXPECT().SaveExcludedReviewers(ctx, "pr-1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, userIDs []string) error {
+		excluded = append(excluded, userIDs...)
+		return nil
+	})
+	mockReqOwnerRepo.EXPECT().GetExcludedReviewers(ctx, "pr-1").DoAndReturn(func(context.Context, string) ([]string, error) {
+		return excluded, nil
+	})
+	mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Any()).Return(nil).AnyTimes()
+	mockReqOwnerRepo.EXPECT().SaveAssignmentExplanation(ctx, gomock.Any()).Return(nil)
+	mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil).AnyTimes()
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(members, nil)
+	mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil).AnyTimes()
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), NewRoundRobinSelector(), nil)
+
+	// Act
+	draft := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusDraft}
+	if _, err := uc.CreatePullRequest(ctx, draft, domain.ReviewerPreferences{Excluded: []string{"u1"}}); err != nil {
+		t.Fatalf("create draft: unexpected error %v", err)
+	}
+	got, err := uc.ChangeStatus(ctx, "pr-1", domain.RequestStatusOpen)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ready: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got.AssignedReviewersID, []string{"u2", "u3"}) {
+		t.Fatalf("expected excluded u1 to be skipped, got %v", got.AssignedReviewersID)
+	}
+}
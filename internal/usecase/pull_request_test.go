@@ -4,6 +4,10 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -14,14 +18,14 @@ func TestPullRequest_CreateRepository_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	author := &domain.User{
 		ID:       "author-1",
@@ -49,6 +53,10 @@ func TestPullRequest_CreateRepository_Success(t *testing.T) {
 		GetPullRequestByID(ctx, pr.ID).
 		Return(nil, ErrPullRequestNotFound)
 
+	mockTeamRepo.EXPECT().
+		GetTeamSettings(ctx, "team-1").
+		Return(nil, nil)
+
 	mockPRRepo.EXPECT().
 		SavePullRequest(ctx, pr).
 		Return(nil)
@@ -100,7 +108,51 @@ func TestPullRequest_CreateRepository_Success(t *testing.T) {
 			return nil
 		})
 
-	gomock.InOrder(authorCall, reviewerCall1, reviewerCall2)
+	eventsCall := mockReqOwnerRepo.EXPECT().
+		SaveAssignmentEvents(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []domain.AssignmentEvent) error {
+			if len(events) != 2 {
+				t.Fatalf("expected 2 assignment events, got %d", len(events))
+			}
+			for _, event := range events {
+				if event.Type != domain.AssignmentEventAssigned || event.Reason != domain.AssignmentReasonCreate || event.Strategy != StrategyRandom {
+					t.Fatalf("unexpected assignment event: %#v", event)
+				}
+				if !selectedReviewers[event.UserID] {
+					t.Fatalf("event for not assigned reviewer: %s", event.UserID)
+				}
+			}
+			return nil
+		})
+
+	explanationCall := mockReqOwnerRepo.EXPECT().
+		SaveAssignmentExplanation(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, explanation *domain.AssignmentExplanation) error {
+			if explanation.PullRequestID != pr.ID || explanation.Reason != domain.AssignmentReasonCreate ||
+				explanation.Strategy != StrategyRandom || explanation.TeamName != "team-1" || explanation.Slots != 2 {
+				t.Fatalf("unexpected explanation: %#v", explanation)
+			}
+			if len(explanation.Selected) != 2 || !selectedReviewers[explanation.Selected[0]] || !selectedReviewers[explanation.Selected[1]] {
+				t.Fatalf("unexpected selected reviewers: %v", explanation.Selected)
+			}
+			excluded := map[string]string{}
+			for _, decision := range explanation.Candidates {
+				if decision.Eligible != (decision.ExcludedReason == "") || decision.Selected != selectedReviewers[decision.UserID] {
+					t.Fatalf("inconsistent decision: %#v", decision)
+				}
+				if decision.Eligible && decision.Draw == nil {
+					t.Fatalf("expected random draw for %s", decision.UserID)
+				}
+				excluded[decision.UserID] = decision.ExcludedReason
+			}
+			want := map[string]string{"u1": "", "u2": "", "u3": domain.CandidateExcludedInactive, "author-1": domain.CandidateExcludedAuthor}
+			if !reflect.DeepEqual(excluded, want) {
+				t.Fatalf("expected exclusions %v, got %v", want, excluded)
+			}
+			return nil
+		})
+
+	gomock.InOrder(authorCall, reviewerCall1, reviewerCall2, eventsCall, explanationCall)
 
 	mockUserRepo.EXPECT().
 		GetTeamsByUserID(ctx, author.ID).
@@ -111,7 +163,7 @@ func TestPullRequest_CreateRepository_Success(t *testing.T) {
 		Return(coworkers, nil)
 
 	// Act
-	got, err := usecase.CreatePullRequest(ctx, pr)
+	got, err := usecase.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{})
 
 	// Assert
 	if err != nil {
@@ -138,11 +190,11 @@ func TestPullRequest_CreateRepository_RequestNil(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	usecase := NewPullRequest(nil, nil, nil, nil)
-	ctx := context.Background()
+	usecase := NewPullRequest(nil, nil, nil, nil, nil, nil, nil)
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	// Act
-	got, err := usecase.CreatePullRequest(ctx, nil)
+	got, err := usecase.CreatePullRequest(ctx, nil, domain.ReviewerPreferences{})
 
 	// Assert
 	if got != nil {
@@ -158,14 +210,14 @@ func TestPullRequest_CreateRepository_AuthorNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	pr := &domain.PullRequest{
 		ID:       "pr-1",
@@ -178,7 +230,7 @@ func TestPullRequest_CreateRepository_AuthorNotFound(t *testing.T) {
 		Return(nil, ErrMemberNotFound)
 
 	// Act
-	got, err := usecase.CreatePullRequest(ctx, pr)
+	got, err := usecase.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{})
 
 	// Assert
 	if got != nil {
@@ -194,14 +246,14 @@ func TestPullRequest_CreateRepository_PullRequestAlreadyExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	author := &domain.User{
 		ID:       "author-1",
@@ -223,7 +275,7 @@ func TestPullRequest_CreateRepository_PullRequestAlreadyExists(t *testing.T) {
 		Return(&domain.PullRequest{}, nil)
 
 	// Act
-	got, err := usecase.CreatePullRequest(ctx, pr)
+	got, err := usecase.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{})
 
 	// Assert
 	if got != nil {
@@ -239,14 +291,14 @@ func TestPullRequest_UpdateRepository_MergedNoUpdate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	existing := &domain.PullRequest{
 		ID:     "pr-1",
@@ -278,14 +330,14 @@ func TestPullRequest_UpdateRepository_PullRequestNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	req := &domain.PullRequest{ID: "pr-1"}
 
@@ -310,14 +362,14 @@ func TestPullRequest_UpdateRepository_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	old := &domain.PullRequest{
 		ID:     "pr-1",
@@ -354,101 +406,165 @@ func TestPullRequest_UpdateRepository_Success(t *testing.T) {
 	}
 }
 
-func TestPullRequest_ReassignRequest_MergedNoOp(t *testing.T) {
+func TestPullRequest_ReassignRequest_Merged(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	usecase := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
-	pr := &domain.PullRequest{ID: "pr-1"}
 	stored := &domain.PullRequest{
-		ID:       "pr-1",
-		AuthorID: "author-1",
-		Status:   domain.RequestStatusMerged,
+		ID:                  "pr-1",
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusMerged,
+		AssignedReviewersID: []string{"old-reviewer"},
 	}
 
 	mockPRRepo.EXPECT().
-		GetPullRequestByID(ctx, pr.ID).
+		GetPullRequestByID(ctx, stored.ID).
 		Return(stored, nil)
 
+	mockUserRepo.EXPECT().
+		GetUserByID(ctx, "old-reviewer").
+		Return(&domain.User{ID: "old-reviewer", IsActive: true}, nil)
+
 	// Act
-	got, _, err := usecase.ReassignRequest(ctx, pr.ID, "old-reviewer")
+	got, newReviewer, err := usecase.ReassignRequest(ctx, stored.ID, "old-reviewer")
 
 	// Assert
-	if err != nil {
-		t.Fatalf("ReassignRequest() unexpected error: %v", err)
+	if !errors.Is(err, ErrPullRequestIsMerged) {
+		t.Fatalf("expected ErrPullRequestIsMerged, got %v", err)
 	}
-	if got != nil {
-		t.Fatalf("expected nil result for merged PR, got %#v", got)
+	if got != nil || newReviewer != nil {
+		t.Fatalf("expected nil result for merged PR, got %#v, %#v", got, newReviewer)
 	}
 }
 
-func TestPullRequest_ReassignRequest_NoCandidates(t *testing.T) {
+func TestPullRequest_ReassignRequest_UserNotFound(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
-	stored := &domain.PullRequest{
-		ID:       "pr-1",
-		AuthorID: "author-1",
-		Status:   domain.RequestStatusOpen,
+	stored := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen}
+
+	mockPRRepo.EXPECT().
+		GetPullRequestByID(ctx, stored.ID).
+		Return(stored, nil)
+
+	mockUserRepo.EXPECT().
+		GetUserByID(ctx, "ghost").
+		Return(nil, ErrMemberNotFound)
+
+	// Act
+	_, _, err := uc.ReassignRequest(ctx, stored.ID, "ghost")
+
+	// Assert
+	if !errors.Is(err, ErrMemberNotFound) {
+		t.Fatalf("expected ErrMemberNotFound, got %v", err)
 	}
-	author := &domain.User{
-		ID:       "author-1",
-		Username: "author",
-		IsActive: true,
+}
+
+func TestPullRequest_ReassignRequest_NotAssigned(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+	stored := &domain.PullRequest{
+		ID:                  "pr-1",
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"reviewer-1"},
 	}
 
 	mockPRRepo.EXPECT().
 		GetPullRequestByID(ctx, stored.ID).
 		Return(stored, nil)
 
+	// автор существует, но ревьювером не назначен
 	mockUserRepo.EXPECT().
-		GetUserByID(ctx, stored.AuthorID).
-		Return(author, nil)
+		GetUserByID(ctx, "author-1").
+		Return(&domain.User{ID: "author-1", IsActive: true}, nil)
 
-	mockReqOwnerRepo.EXPECT().
-		DeleteRequestOwner(ctx, &domain.RequestOwner{
-			RequestID: stored.ID,
-			UserID:    "old-reviewer",
-			Role:      domain.UserRoleReviewer,
-		}).
-		Return(nil)
+	// Act
+	_, _, err := uc.ReassignRequest(ctx, stored.ID, "author-1")
 
-	mockReqOwnerRepo.EXPECT().
-		GetUsersByPullRequestID(ctx, stored.ID).
-		Return([]domain.RequestOwner{
-			{UserID: "used-1", RequestID: stored.ID, Role: domain.UserRoleReviewer},
-		}, nil)
+	// Assert
+	if !errors.Is(err, ErrReviewerNotAssigned) {
+		t.Fatalf("expected ErrReviewerNotAssigned, got %v", err)
+	}
+}
+
+func TestPullRequest_ReassignRequest_NoCandidates(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	mockMetrics := NewMockMetrics(ctrl)
+	mockMetrics.EXPECT().NoCandidate(OperationReassign, 1)
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), mockMetrics)
+
+	stored := &domain.PullRequest{
+		ID:                  "pr-1",
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"old-reviewer", "used-1"},
+	}
+
+	mockPRRepo.EXPECT().
+		GetPullRequestByID(ctx, stored.ID).
+		Return(stored, nil)
 
-	// ВАЖНО: теперь usecase вызывает GetTeamsByUserID по pr.AuthorID,
-	// а не по req.AuthorID, поэтому аргумент — stored.AuthorID
 	mockUserRepo.EXPECT().
-		GetTeamsByUserID(ctx, stored.AuthorID).
+		GetUserByID(ctx, "old-reviewer").
+		Return(&domain.User{ID: "old-reviewer", IsActive: true}, nil)
+
+	mockUserRepo.EXPECT().
+		GetTeamsByUserID(ctx, "old-reviewer").
 		Return([]domain.Team{{Name: "team-1"}}, nil)
 
+	mockReqOwnerRepo.EXPECT().
+		GetExcludedReviewers(ctx, stored.ID).
+		Return([]string{}, nil)
+
 	// Все кандидаты либо неактивные, либо уже назначены / автор
 	coworkers := []domain.User{
-		{ID: "used-1", Username: "used", IsActive: true},     // уже назначен
-		{ID: "author-1", Username: "author", IsActive: true}, // автор
-		{ID: "u3", Username: "u3", IsActive: false},          // неактивный
+		{ID: "old-reviewer", Username: "old", IsActive: true}, // заменяемый
+		{ID: "used-1", Username: "used", IsActive: true},      // уже назначен
+		{ID: "author-1", Username: "author", IsActive: true},  // автор
+		{ID: "u3", Username: "u3", IsActive: false},           // неактивный
 	}
 	mockUserRepo.EXPECT().
 		GetUsersByTeamName(ctx, "team-1").
@@ -471,98 +587,1381 @@ func TestPullRequest_ReassignRequest_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockPRRepo := NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
-	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
 
 	requestID := "pr-1"
 
 	stored := &domain.PullRequest{
-		ID:       requestID,
-		AuthorID: "author-1",
-		Status:   domain.RequestStatusOpen,
+		ID:                  requestID,
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"old-reviewer", "used-1"},
 	}
-	author := &domain.User{
-		ID:       "author-1",
-		Username: "author",
-		IsActive: true,
+	reassigned := &domain.PullRequest{
+		ID:                  requestID,
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"free-2", "used-1"},
 	}
 
-	// Первый вызов GetPullRequestByID — в начале usecase
-	mockPRRepo.EXPECT().
-		GetPullRequestByID(ctx, requestID).
-		Return(stored, nil)
+	gomock.InOrder(
+		mockPRRepo.EXPECT().
+			GetPullRequestByID(ctx, requestID).
+			Return(stored, nil),
+		mockUserRepo.EXPECT().
+			GetUserByID(ctx, "old-reviewer").
+			Return(&domain.User{ID: "old-reviewer", IsActive: true}, nil),
+		// кандидаты берутся из команды заменяемого ревьювера, а не автора
+		mockUserRepo.EXPECT().
+			GetTeamsByUserID(ctx, "old-reviewer").
+			Return([]domain.Team{{Name: "team-2"}}, nil),
+		// исключенный автором при создании PR пользователь не выбирается и при замене
+		mockReqOwnerRepo.EXPECT().
+			GetExcludedReviewers(ctx, requestID).
+			Return([]string{"excluded-3"}, nil),
+		mockUserRepo.EXPECT().
+			GetUsersByTeamName(ctx, "team-2").
+			Return([]domain.User{
+				{ID: "old-reviewer", Username: "old", IsActive: true},
+				{ID: "used-1", Username: "used", IsActive: true},
+				{ID: "excluded-3", Username: "excluded", IsActive: true},
+				{ID: "free-2", Username: "free", IsActive: true},
+			}, nil),
+		mockReqOwnerRepo.EXPECT().
+			DeleteRequestOwner(ctx, &domain.RequestOwner{
+				RequestID: stored.ID,
+				UserID:    "old-reviewer",
+				Role:      domain.UserRoleReviewer,
+			}).
+			Return(nil),
+		mockReqOwnerRepo.EXPECT().
+			SaveRequestOwner(ctx, &domain.RequestOwner{
+				RequestID: stored.ID,
+				UserID:    "free-2",
+				Role:      domain.UserRoleReviewer,
+			}).
+			Return(nil),
+		mockReqOwnerRepo.EXPECT().
+			SaveAssignmentEvents(ctx, []domain.AssignmentEvent{{
+				PullRequestID:  stored.ID,
+				Type:           domain.AssignmentEventReassigned,
+				UserID:         "free-2",
+				PreviousUserID: "old-reviewer",
+				Reason:         domain.AssignmentReasonReassign,
+				Strategy:       StrategyRandom,
+			}}).
+			Return(nil),
+		mockReqOwnerRepo.EXPECT().
+			SaveAssignmentExplanation(ctx, &domain.AssignmentExplanation{
+				PullRequestID:  stored.ID,
+				Reason:         domain.AssignmentReasonReassign,
+				Strategy:       StrategyRandom,
+				TeamName:       "team-2",
+				ReplacedUserID: "old-reviewer",
+				Slots:          1,
+				Selected:       []string{"free-2"},
+				Candidates: []domain.CandidateDecision{
+					{UserID: "old-reviewer", ExcludedReason: domain.CandidateExcludedAlreadyAssigned},
+					{UserID: "used-1", ExcludedReason: domain.CandidateExcludedAlreadyAssigned},
+					{UserID: "excluded-3", ExcludedReason: domain.CandidateExcludedByAuthor},
+					{UserID: "free-2", Eligible: true, Selected: true, Draw: intPtr(0)},
+				},
+			}).
+			Return(nil),
+		mockPRRepo.EXPECT().
+			GetPullRequestByID(ctx, requestID).
+			Return(reassigned, nil),
+	)
 
-	mockUserRepo.EXPECT().
-		GetUserByID(ctx, stored.AuthorID).
-		Return(author, nil)
+	// Act
+	got, newReviewer, err := uc.ReassignRequest(ctx, requestID, "old-reviewer")
 
-	mockReqOwnerRepo.EXPECT().
-		DeleteRequestOwner(ctx, &domain.RequestOwner{
-			RequestID: stored.ID,
-			UserID:    "old-reviewer",
-			Role:      domain.UserRoleReviewer,
-		}).
-		Return(nil)
+	// Assert
+	if err != nil {
+		t.Fatalf("ReassignRequest() unexpected error: %v", err)
+	}
+	if got != reassigned {
+		t.Fatalf("expected reassigned PullRequest, got %#v", got)
+	}
+	if newReviewer == nil || newReviewer.ID != "free-2" {
+		t.Fatalf("expected new reviewer free-2, got %#v", newReviewer)
+	}
+}
 
-	// Уже назначенный ревьюер, которого нельзя назначать снова
-	mockReqOwnerRepo.EXPECT().
-		GetUsersByPullRequestID(ctx, stored.ID).
-		Return([]domain.RequestOwner{
-			{UserID: "used-1", RequestID: stored.ID, Role: domain.UserRoleReviewer},
-		}, nil)
+func TestPullRequest_CreatePullRequest_RollbackOnFailure(t *testing.T) {
+	errStep := errors.New("step failed")
 
-	// ВАЖНО: GetTeamsByUserID теперь вызывается с pr.AuthorID
-	mockUserRepo.EXPECT().
-		GetTeamsByUserID(ctx, stored.AuthorID).
-		Return([]domain.Team{{Name: "team-1"}}, nil)
+	author := &domain.User{ID: "author-1", Username: "author", IsActive: true}
+	coworkers := []domain.User{
+		{ID: "u1", Username: "u1", IsActive: true},
+		{ID: "u2", Username: "u2", IsActive: true},
+	}
+
+	// шаги CreatePullRequest после проверок автора и существования PR, в порядке выполнения
+	steps := []string{
+		"GetTeamsByUserID",
+		"GetTeamSettings",
+		"SavePullRequest",
+		"SaveAuthor",
+		"GetUsersByTeamName",
+		"SaveFirstReviewer",
+		"SaveSecondReviewer",
+		"SaveAssignmentEvents",
+		"SaveAssignmentExplanation",
+	}
+
+	for failAt, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
 
+			var rolledBack error
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newRollbackTransactor(ctrl, &rolledBack), newTestSelector(), nil)
+
+			pr := &domain.PullRequest{ID: "pr-1", Name: "Test PR", AuthorID: author.ID}
+
+			errAt := func(i int) error {
+				if i == failAt {
+					return errStep
+				}
+				return nil
+			}
+
+			mockUserRepo.EXPECT().GetUserByID(ctx, author.ID).Return(author, nil)
+			mockPRRepo.EXPECT().GetPullRequestByID(ctx, pr.ID).Return(nil, ErrPullRequestNotFound)
+
+			calls := []*gomock.Call{
+				mockUserRepo.EXPECT().GetTeamsByUserID(ctx, author.ID).Return([]domain.Team{{Name: "team-1"}}, errAt(0)),
+				mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, errAt(1)),
+				mockPRRepo.EXPECT().SavePullRequest(ctx, pr).Return(errAt(2)),
+				mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: author.ID, Role: domain.UserRoleAuthor}).Return(errAt(3)),
+				mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(coworkers, errAt(4)),
+				mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).Return(errAt(5)),
+				mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).Return(errAt(6)),
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Any()).Return(errAt(7)),
+				mockReqOwnerRepo.EXPECT().SaveAssignmentExplanation(ctx, gomock.Any()).Return(errAt(8)),
+			}
+			for i := failAt + 1; i < len(calls); i++ {
+				calls[i].Times(0)
+			}
+			gomock.InOrder(calls[:failAt+1]...)
+
+			// Act
+			got, err := uc.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{})
+
+			// Assert
+			if got != nil {
+				t.Fatalf("expected nil result, got %#v", got)
+			}
+			if !errors.Is(err, errStep) {
+				t.Fatalf("expected step error, got %v", err)
+			}
+			if !errors.Is(rolledBack, errStep) {
+				t.Fatalf("expected transaction to be rolled back with step error, got %v", rolledBack)
+			}
+		})
+	}
+}
+
+func TestPullRequest_ReassignRequest_RollbackOnFailure(t *testing.T) {
+	errStep := errors.New("step failed")
+
+	stored := &domain.PullRequest{
+		ID:                  "pr-1",
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"old-reviewer"},
+	}
 	coworkers := []domain.User{
-		{ID: "used-1", Username: "used", IsActive: true},
+		{ID: "author-1", Username: "author", IsActive: true},
 		{ID: "free-1", Username: "free", IsActive: true},
 	}
-	mockUserRepo.EXPECT().
-		GetUsersByTeamName(ctx, "team-1").
-		Return(coworkers, nil)
 
-	mockReqOwnerRepo.EXPECT().
-		SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).
-		DoAndReturn(func(_ context.Context, ro *domain.RequestOwner) error {
-			if ro.Role != domain.UserRoleReviewer {
-				t.Fatalf("expected reviewer role, got %v", ro.Role)
+	// шаги ReassignRequest после проверки PR и заменяемого ревьювера, в порядке выполнения
+	steps := []string{
+		"GetTeamsByUserID",
+		"GetExcludedReviewers",
+		"GetUsersByTeamName",
+		"DeleteRequestOwner",
+		"SaveRequestOwner",
+		"SaveAssignmentEvents",
+		"SaveAssignmentExplanation",
+		"GetPullRequestByID",
+	}
+
+	for failAt, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+			var rolledBack error
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newRollbackTransactor(ctrl, &rolledBack), newTestSelector(), nil)
+
+			errAt := func(i int) error {
+				if i == failAt {
+					return errStep
+				}
+				return nil
 			}
-			if ro.RequestID != stored.ID {
-				t.Fatalf("expected RequestID %s, got %s", stored.ID, ro.RequestID)
+
+			gomock.InOrder(
+				mockPRRepo.EXPECT().GetPullRequestByID(ctx, stored.ID).Return(stored, nil),
+				mockUserRepo.EXPECT().GetUserByID(ctx, "old-reviewer").Return(&domain.User{ID: "old-reviewer", IsActive: true}, nil),
+			)
+
+			calls := []*gomock.Call{
+				mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "old-reviewer").Return([]domain.Team{{Name: "team-1"}}, errAt(0)),
+				mockReqOwnerRepo.EXPECT().GetExcludedReviewers(ctx, stored.ID).Return([]string{}, errAt(1)),
+				mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(coworkers, errAt(2)),
+				mockReqOwnerRepo.EXPECT().DeleteRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).Return(errAt(3)),
+				mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).Return(errAt(4)),
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Any()).Return(errAt(5)),
+				mockReqOwnerRepo.EXPECT().SaveAssignmentExplanation(ctx, gomock.Any()).Return(errAt(6)),
+				mockPRRepo.EXPECT().GetPullRequestByID(ctx, stored.ID).Return(stored, errAt(7)),
 			}
-			if ro.UserID == "used-1" || ro.UserID == "author-1" {
-				t.Fatalf("assigned invalid reviewer id: %s", ro.UserID)
+			for i := failAt + 1; i < len(calls); i++ {
+				calls[i].Times(0)
+			}
+			gomock.InOrder(calls[:failAt+1]...)
+
+			// Act
+			got, newReviewer, err := uc.ReassignRequest(ctx, stored.ID, "old-reviewer")
+
+			// Assert
+			if got != nil || newReviewer != nil {
+				t.Fatalf("expected nil result, got %#v, %#v", got, newReviewer)
+			}
+			if !errors.Is(err, errStep) {
+				t.Fatalf("expected step error, got %v", err)
+			}
+			if !errors.Is(rolledBack, errStep) {
+				t.Fatalf("expected transaction to be rolled back with step error, got %v", rolledBack)
 			}
-			return nil
 		})
+	}
+}
 
-	// Новый usecase в конце ещё раз читает PR из репозитория:
-	// pr, err = p.pullRequestRepository.GetPullRequestByID(ctx, requestID)
-	// поэтому нужно второе ожидание
-	mockPRRepo.EXPECT().
-		GetPullRequestByID(ctx, requestID).
-		Return(stored, nil)
+func TestPullRequest_CreatePullRequest_TransactorNil(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewPullRequest(NewMockPullRequestRepository(ctrl), NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), nil, NewRandomSelector(nil), nil)
+
+	// Act
+	got, err := uc.CreatePullRequest(WithActor(context.Background(), actorAdmin), &domain.PullRequest{ID: "pr-1", AuthorID: "author-1"}, domain.ReviewerPreferences{})
+
+	// Assert
+	if got != nil {
+		t.Fatalf("expected nil result, got %#v", got)
+	}
+	if !errors.Is(err, ErrTransactorNotFound) {
+		t.Fatalf("expected ErrTransactorNotFound, got %v", err)
+	}
+}
+
+func TestPullRequest_CreatePullRequest_UsesReviewerSelector(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	mockMetrics := NewMockMetrics(ctrl)
+	mockMetrics.EXPECT().ReviewersAssigned(OperationCreate, 2)
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), NewRoundRobinSelector(), mockMetrics)
+
+	author := &domain.User{ID: "author-1", Username: "author", IsActive: true}
+	pr := &domain.PullRequest{ID: "pr-1", Name: "Test PR", AuthorID: author.ID}
+
+	mockUserRepo.EXPECT().GetUserByID(ctx, author.ID).Return(author, nil)
+	mockPRRepo.EXPECT().GetPullRequestByID(ctx, pr.ID).Return(nil, ErrPullRequestNotFound)
+	mockPRRepo.EXPECT().SavePullRequest(ctx, pr).Return(nil)
+	mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: author.ID, Role: domain.UserRoleAuthor}).Return(nil)
+	mockUserRepo.EXPECT().GetTeamsByUserID(ctx, author.ID).Return([]domain.Team{{Name: "team-1"}, {Name: "team-2"}}, nil)
+	mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil)
+	// u2 состоит в обеих командах и не должен учитываться дважды
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return([]domain.User{
+		{ID: "u3", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "author-1", IsActive: true},
+	}, nil)
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-2").Return([]domain.User{
+		{ID: "u2", IsActive: true},
+		{ID: "u1", IsActive: false},
+	}, nil)
+	gomock.InOrder(
+		mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: "u2", Role: domain.UserRoleReviewer}).Return(nil),
+		mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: "u3", Role: domain.UserRoleReviewer}).Return(nil),
+		mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{
+			{PullRequestID: pr.ID, Type: domain.AssignmentEventAssigned, UserID: "u2", Reason: domain.AssignmentReasonCreate, Strategy: StrategyRoundRobin},
+			{PullRequestID: pr.ID, Type: domain.AssignmentEventAssigned, UserID: "u3", Reason: domain.AssignmentReasonCreate, Strategy: StrategyRoundRobin},
+		}).Return(nil),
+		// u2 первый в очереди round_robin, автор и неактивный u1 в пул не попадают
+		mockReqOwnerRepo.EXPECT().SaveAssignmentExplanation(ctx, &domain.AssignmentExplanation{
+			PullRequestID: pr.ID,
+			Reason:        domain.AssignmentReasonCreate,
+			Strategy:      StrategyRoundRobin,
+			TeamName:      "team-1",
+			Slots:         2,
+			Selected:      []string{"u2", "u3"},
+			Candidates: []domain.CandidateDecision{
+				{UserID: "u3", Eligible: true, Selected: true, Draw: intPtr(1)},
+				{UserID: "u2", Eligible: true, Selected: true, Draw: intPtr(0)},
+				{UserID: "author-1", ExcludedReason: domain.CandidateExcludedAuthor},
+				{UserID: "u1", ExcludedReason: domain.CandidateExcludedInactive},
+			},
+		}).Return(nil),
+	)
 
 	// Act
-	got, _, err := uc.ReassignRequest(ctx, requestID, "old-reviewer")
+	got, err := uc.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreatePullRequest() unexpected error: %v", err)
+	}
+	if want := []string{"u2", "u3"}; !reflect.DeepEqual(got.AssignedReviewersID, want) {
+		t.Fatalf("expected reviewers %v, got %v", want, got.AssignedReviewersID)
+	}
+}
+
+func TestPullRequest_CreatePullRequest_TeamSettings(t *testing.T) {
+	coworkers := []domain.User{
+		{ID: "u1", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "u3", IsActive: true},
+	}
+
+	tests := []struct {
+		name              string
+		settings          *domain.TeamSettings
+		wantReviewers     []string
+		wantUnderReviewed bool
+		wantNoCandidate   int
+	}{
+		{
+			name:          "max limits assigned reviewers",
+			settings:      &domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 1},
+			wantReviewers: []string{"u1"},
+		},
+		{
+			name:              "fewer candidates than min",
+			settings:          &domain.TeamSettings{TeamName: "team-1", MinReviewers: 4, MaxReviewers: 5},
+			wantReviewers:     []string{"u1", "u2", "u3"},
+			wantUnderReviewed: true,
+			wantNoCandidate:   2,
+		},
+		{
+			name:          "no reviewers required",
+			settings:      &domain.TeamSettings{TeamName: "team-1", MinReviewers: 0, MaxReviewers: 0},
+			wantReviewers: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+			mockMetrics := NewMockMetrics(ctrl)
+			mockMetrics.EXPECT().ReviewersAssigned(OperationCreate, len(tt.wantReviewers))
+			if tt.wantNoCandidate > 0 {
+				mockMetrics.EXPECT().NoCandidate(OperationCreate, tt.wantNoCandidate)
+			}
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), NewRoundRobinSelector(), mockMetrics)
+
+			author := &domain.User{ID: "author-1", IsActive: true}
+			pr := &domain.PullRequest{ID: "pr-1", Name: "Test PR", AuthorID: author.ID}
+
+			mockUserRepo.EXPECT().GetUserByID(ctx, author.ID).Return(author, nil)
+			mockPRRepo.EXPECT().GetPullRequestByID(ctx, pr.ID).Return(nil, ErrPullRequestNotFound)
+			mockUserRepo.EXPECT().GetTeamsByUserID(ctx, author.ID).Return([]domain.Team{{Name: "team-1"}}, nil)
+			mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(tt.settings, nil)
+			mockPRRepo.EXPECT().
+				SavePullRequest(ctx, pr).
+				DoAndReturn(func(_ context.Context, saved *domain.PullRequest) error {
+					if saved.MinReviewers != tt.settings.MinReviewers {
+						t.Fatalf("expected PR to be saved with min reviewers %d, got %d", tt.settings.MinReviewers, saved.MinReviewers)
+					}
+					return nil
+				})
+			mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: author.ID, Role: domain.UserRoleAuthor}).Return(nil)
+			mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(coworkers, nil)
+			mockReqOwnerRepo.EXPECT().
+				SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).
+				Return(nil).
+				Times(len(tt.wantReviewers))
+			if len(tt.wantReviewers) > 0 {
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Len(len(tt.wantReviewers))).Return(nil)
+			}
+			// объяснение сохраняется и тогда, когда выбирать некого
+			mockReqOwnerRepo.EXPECT().
+				SaveAssignmentExplanation(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, explanation *domain.AssignmentExplanation) error {
+					if explanation.Slots != tt.settings.MaxReviewers {
+						t.Fatalf("expected %d slots, got %d", tt.settings.MaxReviewers, explanation.Slots)
+					}
+					if !reflect.DeepEqual(explanation.Selected, tt.wantReviewers) {
+						t.Fatalf("expected selected %v, got %v", tt.wantReviewers, explanation.Selected)
+					}
+					return nil
+				})
+
+			// Act
+			got, err := uc.CreatePullRequest(ctx, pr, domain.ReviewerPreferences{})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("CreatePullRequest() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.AssignedReviewersID, tt.wantReviewers) {
+				t.Fatalf("expected reviewers %v, got %v", tt.wantReviewers, got.AssignedReviewersID)
+			}
+			if got.UnderReviewed() != tt.wantUnderReviewed {
+				t.Fatalf("expected under reviewed %v, got %v", tt.wantUnderReviewed, got.UnderReviewed())
+			}
+		})
+	}
+}
+
+func TestPullRequest_CreatePullRequest_ReviewerPreferences(t *testing.T) {
+	teamMembers := []domain.User{
+		{ID: "author-1", IsActive: true},
+		{ID: "u1", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "u3", IsActive: false},
+		{ID: "u4", IsActive: true},
+	}
+
+	tests := []struct {
+		name        string
+		preferences domain.ReviewerPreferences
+		lookups     func(ctx context.Context, userRepo *MockUserRepository)
+		want        []string
+		// wantExcluded - причины исключения из пула в объяснении выбора
+		wantExcluded map[string]string
+		wantErr      error
+	}{
+		{
+			name:         "requested reviewer fills slot first",
+			preferences:  domain.ReviewerPreferences{Requested: []string{"u4"}},
+			want:         []string{"u4", "u1"},
+			wantExcluded: map[string]string{"author-1": domain.CandidateExcludedAuthor, "u3": domain.CandidateExcludedInactive},
+		},
+		{
+			name:         "excluded reviewer is never picked",
+			preferences:  domain.ReviewerPreferences{Excluded: []string{"u1"}},
+			want:         []string{"u2", "u4"},
+			wantExcluded: map[string]string{"author-1": domain.CandidateExcludedAuthor, "u1": domain.CandidateExcludedByAuthor, "u3": domain.CandidateExcludedInactive},
+		},
+		{
+			name:         "requested and excluded",
+			preferences:  domain.ReviewerPreferences{Requested: []string{"u2"}, Excluded: []string{"u1"}},
+			want:         []string{"u2", "u4"},
+			wantExcluded: map[string]string{"author-1": domain.CandidateExcludedAuthor, "u1": domain.CandidateExcludedByAuthor, "u3": domain.CandidateExcludedInactive},
+		},
+		{
+			name:        "excluded user from another team",
+			preferences: domain.ReviewerPreferences{Excluded: []string{"outsider"}},
+			lookups: func(ctx context.Context, userRepo *MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "outsider").Return(&domain.User{ID: "outsider", IsActive: true}, nil)
+			},
+			want:         []string{"u1", "u2"},
+			wantExcluded: map[string]string{"author-1": domain.CandidateExcludedAuthor, "u3": domain.CandidateExcludedInactive},
+		},
+		{
+			name:        "unknown requested reviewer",
+			preferences: domain.ReviewerPreferences{Requested: []string{"ghost"}},
+			lookups: func(ctx context.Context, userRepo *MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "ghost").Return(nil, ErrMemberNotFound)
+			},
+			wantErr: ErrReviewerNotFound,
+		},
+		{
+			name:        "unknown excluded user",
+			preferences: domain.ReviewerPreferences{Excluded: []string{"ghost"}},
+			lookups: func(ctx context.Context, userRepo *MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "ghost").Return(nil, ErrMemberNotFound)
+			},
+			wantErr: ErrReviewerNotFound,
+		},
+		{
+			name:        "inactive requested reviewer",
+			preferences: domain.ReviewerPreferences{Requested: []string{"u3"}},
+			wantErr:     ErrReviewerInactive,
+		},
+		{
+			name:        "requested reviewer from another team",
+			preferences: domain.ReviewerPreferences{Requested: []string{"outsider"}},
+			lookups: func(ctx context.Context, userRepo *MockUserRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "outsider").Return(&domain.User{ID: "outsider", IsActive: true}, nil)
+			},
+			wantErr: ErrReviewerNotInTeam,
+		},
+		{
+			name:        "more requested than team allows",
+			preferences: domain.ReviewerPreferences{Requested: []string{"u1", "u2", "u4"}},
+			wantErr:     ErrInvalidReviewerRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+			var rolledBack error
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newRollbackTransactor(ctrl, &rolledBack), NewRoundRobinSelector(), nil)
+
+			author := &domain.User{ID: "author-1", IsActive: true}
+			pr := &domain.PullRequest{ID: "pr-1", Name: "Test PR", AuthorID: author.ID}
+
+			mockUserRepo.EXPECT().GetUserByID(ctx, author.ID).Return(author, nil)
+			mockPRRepo.EXPECT().GetPullRequestByID(ctx, pr.ID).Return(nil, ErrPullRequestNotFound)
+			mockUserRepo.EXPECT().GetTeamsByUserID(ctx, author.ID).Return([]domain.Team{{Name: "team-1"}}, nil)
+			mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil)
+			mockPRRepo.EXPECT().SavePullRequest(ctx, pr).Return(nil)
+			mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: pr.ID, UserID: author.ID, Role: domain.UserRoleAuthor}).Return(nil)
+			mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(teamMembers, nil)
+			if tt.lookups != nil {
+				tt.lookups(ctx, mockUserRepo)
+			}
+
+			saved := []string{}
+			mockReqOwnerRepo.EXPECT().
+				SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).
+				DoAndReturn(func(_ context.Context, ro *domain.RequestOwner) error {
+					saved = append(saved, ro.UserID)
+					return nil
+				}).
+				Times(len(tt.want))
+			if len(tt.want) > 0 {
+				events := make([]domain.AssignmentEvent, 0, len(tt.want))
+				for i, userID := range tt.want {
+					strategy := StrategyRoundRobin
+					if i < len(tt.preferences.Requested) {
+						strategy = domain.AssignmentStrategyRequested
+					}
+					events = append(events, domain.AssignmentEvent{PullRequestID: pr.ID, Type: domain.AssignmentEventAssigned, UserID: userID, Reason: domain.AssignmentReasonCreate, Strategy: strategy})
+				}
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, events).Return(nil)
+				mockReqOwnerRepo.EXPECT().
+					SaveAssignmentExplanation(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, explanation *domain.AssignmentExplanation) error {
+						if !reflect.DeepEqual(explanation.Selected, tt.want) {
+							t.Fatalf("expected selected %v, got %v", tt.want, explanation.Selected)
+						}
+						if want := 2 - len(tt.preferences.Requested); explanation.Slots != want {
+							t.Fatalf("expected %d slots for strategy, got %d", want, explanation.Slots)
+						}
+						excluded := map[string]string{}
+						for _, decision := range explanation.Candidates {
+							if decision.ExcludedReason != "" {
+								excluded[decision.UserID] = decision.ExcludedReason
+							}
+							if decision.Requested != slices.Contains(tt.preferences.Requested, decision.UserID) {
+								t.Fatalf("unexpected requested flag: %#v", decision)
+							}
+						}
+						if !reflect.DeepEqual(excluded, tt.wantExcluded) {
+							t.Fatalf("expected exclusions %v, got %v", tt.wantExcluded, excluded)
+						}
+						return nil
+					})
+			}
+			if len(tt.want) > 0 && len(tt.preferences.Excluded) > 0 {
+				// исключения сохраняются с PR для последующих замен
+				mockReqOwnerRepo.EXPECT().SaveExcludedReviewers(ctx, pr.ID, tt.preferences.Excluded).Return(nil)
+			}
+
+			// Act
+			got, err := uc.CreatePullRequest(ctx, pr, tt.preferences)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(rolledBack, tt.wantErr) {
+					t.Fatalf("expected transaction to be rolled back, got %v", rolledBack)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got.AssignedReviewersID, tt.want) {
+				t.Fatalf("expected reviewers %v, got %v", tt.want, got.AssignedReviewersID)
+			}
+			if !reflect.DeepEqual(saved, tt.want) {
+				t.Fatalf("expected reviewers to be saved in order %v, got %v", tt.want, saved)
+			}
+		})
+	}
+}
+
+func TestPullRequest_CreatePullRequest_InvalidPreferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences domain.ReviewerPreferences
+	}{
+		{name: "author requested", preferences: domain.ReviewerPreferences{Requested: []string{"author-1"}}},
+		{name: "duplicate requested", preferences: domain.ReviewerPreferences{Requested: []string{"u1", "u1"}}},
+		{name: "requested and excluded", preferences: domain.ReviewerPreferences{Requested: []string{"u1"}, Excluded: []string{"u1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := NewPullRequest(NewMockPullRequestRepository(ctrl), NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl),
+				NewMockRequestOwnerRepository(ctrl), NewMockTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.CreatePullRequest(WithActor(context.Background(), actorAdmin), &domain.PullRequest{ID: "pr-1", AuthorID: "author-1"}, tt.preferences)
+
+			// Assert
+			if got != nil {
+				t.Fatalf("expected nil result, got %#v", got)
+			}
+			if !errors.Is(err, ErrInvalidReviewerRequest) {
+				t.Fatalf("expected ErrInvalidReviewerRequest, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPullRequest_ReassignRequest_LeastLoaded(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl),
+		NewLeastLoadedSelector(rand.New(rand.NewSource(1)), mockReqOwnerRepo), nil)
+
+	stored := &domain.PullRequest{
+		ID:                  "pr-1",
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"old-reviewer"},
+	}
+
+	mockPRRepo.EXPECT().GetPullRequestByID(ctx, stored.ID).Return(stored, nil).Times(2)
+	mockUserRepo.EXPECT().GetUserByID(ctx, "old-reviewer").Return(&domain.User{ID: "old-reviewer", IsActive: true}, nil)
+	mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "old-reviewer").Return([]domain.Team{{Name: "team-1"}}, nil)
+	mockReqOwnerRepo.EXPECT().GetExcludedReviewers(ctx, stored.ID).Return([]string{}, nil)
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return([]domain.User{
+		{ID: "busy", IsActive: true},
+		{ID: "idle", IsActive: true},
+	}, nil)
+	mockReqOwnerRepo.EXPECT().GetOpenReviewCounts(ctx).Return(map[string]int{"busy": 4, "idle": 1}, nil)
+	mockReqOwnerRepo.EXPECT().DeleteRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).Return(nil)
+	mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: stored.ID, UserID: "idle", Role: domain.UserRoleReviewer}).Return(nil)
+	mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{{
+		PullRequestID:  stored.ID,
+		Type:           domain.AssignmentEventReassigned,
+		UserID:         "idle",
+		PreviousUserID: "old-reviewer",
+		Reason:         domain.AssignmentReasonReassign,
+		Strategy:       StrategyLeastLoaded,
+	}}).Return(nil)
+	// оценка least_loaded - нагрузка кандидата
+	var scores map[string]int
+	mockReqOwnerRepo.EXPECT().
+		SaveAssignmentExplanation(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, explanation *domain.AssignmentExplanation) error {
+			scores = map[string]int{}
+			for _, decision := range explanation.Candidates {
+				if decision.Score == nil || decision.Draw == nil || decision.Selected != (decision.UserID == "idle") {
+					t.Fatalf("unexpected decision: %#v", decision)
+				}
+				scores[decision.UserID] = *decision.Score
+			}
+			return nil
+		})
+
+	// Act
+	_, newReviewer, err := uc.ReassignRequest(ctx, stored.ID, "old-reviewer")
 
 	// Assert
 	if err != nil {
 		t.Fatalf("ReassignRequest() unexpected error: %v", err)
 	}
-	if got == nil {
-		t.Fatalf("expected non-nil PullRequest")
+	if newReviewer.ID != "idle" {
+		t.Fatalf("expected least loaded reviewer idle, got %s", newReviewer.ID)
+	}
+	if want := map[string]int{"busy": 4, "idle": 1}; !reflect.DeepEqual(scores, want) {
+		t.Fatalf("expected scores %v, got %v", want, scores)
+	}
+}
+
+func TestPullRequest_AddReviewer(t *testing.T) {
+	open := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen, AssignedReviewersID: []string{"u1"}}
+	updated := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen, AssignedReviewersID: []string{"u1", "u2"}}
+
+	tests := []struct {
+		name    string
+		userID  string
+		mock    func(ctx context.Context, prRepo *MockPullRequestRepository, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository)
+		want    *domain.PullRequest
+		wantErr error
+	}{
+		{
+			name:   "added within limit",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository) {
+				gomock.InOrder(
+					prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil),
+					userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&domain.User{ID: "u2", IsActive: true}, nil),
+					userRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil),
+					teamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil),
+					ownerRepo.EXPECT().SaveRequestOwner(ctx, &domain.RequestOwner{RequestID: "pr-1", UserID: "u2", Role: domain.UserRoleReviewer}).Return(nil),
+					ownerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{{
+						PullRequestID: "pr-1",
+						Type:          domain.AssignmentEventAssigned,
+						UserID:        "u2",
+						Reason:        domain.AssignmentReasonAddReviewer,
+						Strategy:      domain.AssignmentStrategyManual,
+					}}).Return(nil),
+					prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(updated, nil),
+				)
+			},
+			want: updated,
+		},
+		{
+			name:   "team limit reached",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+				userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&domain.User{ID: "u2", IsActive: true}, nil)
+				userRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil)
+				teamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(&domain.TeamSettings{TeamName: "team-1", MaxReviewers: 1}, nil)
+			},
+			wantErr: ErrReviewerLimitReached,
+		},
+		{
+			name:   "already assigned",
+			userID: "u1",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, _ *MockUserRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+			},
+			wantErr: ErrReviewerAlreadyAssigned,
+		},
+		{
+			name:   "author",
+			userID: "author-1",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, _ *MockUserRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+			},
+			wantErr: ErrInvalidReviewerRequest,
+		},
+		{
+			name:   "inactive reviewer",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, userRepo *MockUserRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+				userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&domain.User{ID: "u2", IsActive: false}, nil)
+			},
+			wantErr: ErrReviewerInactive,
+		},
+		{
+			name:   "unknown reviewer",
+			userID: "ghost",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, userRepo *MockUserRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+				userRepo.EXPECT().GetUserByID(ctx, "ghost").Return(nil, ErrMemberNotFound)
+			},
+			wantErr: ErrReviewerNotFound,
+		},
+		{
+			name:   "merged",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, _ *MockUserRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusMerged}, nil)
+			},
+			wantErr: ErrPullRequestIsMerged,
+		},
+		{
+			name:   "pull request not found",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockTeamRepository, _ *MockUserRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(nil, ErrPullRequestNotFound)
+			},
+			wantErr: ErrPullRequestNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo)
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.AddReviewer(ctx, "pr-1", tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequest_RemoveReviewer(t *testing.T) {
+	open := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen, AssignedReviewersID: []string{"u1", "u2"}}
+	updated := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen, AssignedReviewersID: []string{"u1"}}
+
+	tests := []struct {
+		name    string
+		userID  string
+		mock    func(ctx context.Context, prRepo *MockPullRequestRepository, ownerRepo *MockRequestOwnerRepository)
+		want    *domain.PullRequest
+		wantErr error
+	}{
+		{
+			name:   "removed without replacement",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, ownerRepo *MockRequestOwnerRepository) {
+				gomock.InOrder(
+					prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil),
+					ownerRepo.EXPECT().DeleteRequestOwner(ctx, &domain.RequestOwner{RequestID: "pr-1", UserID: "u2", Role: domain.UserRoleReviewer}).Return(nil),
+					ownerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{{
+						PullRequestID: "pr-1",
+						Type:          domain.AssignmentEventUnassigned,
+						UserID:        "u2",
+						Reason:        domain.AssignmentReasonRemoveReviewer,
+					}}).Return(nil),
+					prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(updated, nil),
+				)
+			},
+			want: updated,
+		},
+		{
+			name:   "not assigned",
+			userID: "u3",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+			},
+			wantErr: ErrReviewerNotAssigned,
+		},
+		{
+			name:   "merged",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusMerged, AssignedReviewersID: []string{"u2"}}, nil)
+			},
+			wantErr: ErrPullRequestIsMerged,
+		},
+		{
+			name:   "closed",
+			userID: "u2",
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, _ *MockRequestOwnerRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusClosed}, nil)
+			},
+			wantErr: ErrPullRequestNotOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockPRRepo, mockReqOwnerRepo)
+
+			uc := NewPullRequest(mockPRRepo, NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.RemoveReviewer(ctx, "pr-1", tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequest_SubmitReview(t *testing.T) {
+	open := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen, AssignedReviewersID: []string{"u1", "u2"}}
+	reviewed := &domain.PullRequest{
+		ID:                  "pr-1",
+		AuthorID:            "author-1",
+		Status:              domain.RequestStatusOpen,
+		AssignedReviewersID: []string{"u1", "u2"},
+		Reviews:             []domain.ReviewDecision{{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved}},
+	}
+
+	tests := []struct {
+		name    string
+		review  *domain.ReviewDecision
+		mock    func(ctx context.Context, prRepo *MockPullRequestRepository)
+		want    *domain.PullRequest
+		wantErr error
+	}{
+		{
+			name:   "approved",
+			review: &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved},
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository) {
+				gomock.InOrder(
+					prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil),
+					prRepo.EXPECT().SaveReviewDecision(ctx, &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved}).Return(nil),
+					prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(reviewed, nil),
+				)
+			},
+			want: reviewed,
+		},
+		{
+			name:    "invalid decision",
+			review:  &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: "LGTM"},
+			mock:    func(context.Context, *MockPullRequestRepository) {},
+			wantErr: ErrInvalidReviewState,
+		},
+		{
+			name:   "pull request not found",
+			review: &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateCommented},
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(nil, ErrPullRequestNotFound)
+			},
+			wantErr: ErrPullRequestNotFound,
+		},
+		{
+			name:   "not assigned",
+			review: &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u3", State: domain.ReviewStateApproved},
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(open, nil)
+			},
+			wantErr: ErrReviewerNotAssigned,
+		},
+		{
+			name:   "merged",
+			review: &domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateChangesRequested},
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository) {
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1", Status: domain.RequestStatusMerged, AssignedReviewersID: []string{"u1"}}, nil)
+			},
+			wantErr: ErrPullRequestIsMerged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			tt.mock(ctx, mockPRRepo)
+
+			uc := NewPullRequest(mockPRRepo, NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.SubmitReview(ctx, tt.review)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequest_MergePullRequest(t *testing.T) {
+	openPR := func(reviews ...domain.ReviewDecision) *domain.PullRequest {
+		return &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusOpen, AssignedReviewersID: []string{"u1", "u2"}, Reviews: reviews}
+	}
+	approved := domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u1", State: domain.ReviewStateApproved}
+	changes := domain.ReviewDecision{PullRequestID: "pr-1", UserID: "u2", State: domain.ReviewStateChangesRequested}
+	mergedPR := &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusMerged}
+
+	tests := []struct {
+		name              string
+		actor             Actor
+		override          bool
+		pr                *domain.PullRequest
+		requiredApprovals int
+		wantOverride      []string
+		wantMerged        bool
+		wantErr           error
+		wantMessage       string
+	}{
+		{name: "no conditions", actor: actorAdmin, pr: openPR(), wantMerged: true},
+		{name: "enough approvals", actor: actorAdmin, pr: openPR(approved), requiredApprovals: 1, wantMerged: true},
+		{name: "not enough approvals", actor: actorAdmin, pr: openPR(approved), requiredApprovals: 2, wantErr: ErrMergeBlocked, wantMessage: "approvals: 1 of 2 required"},
+		{name: "changes requested", actor: actorAdmin, pr: openPR(approved, changes), requiredApprovals: 1, wantErr: ErrMergeBlocked, wantMessage: "changes requested by u2"},
+		{
+			name:              "admin override is audited",
+			actor:             Actor{Admin: true, UserID: "admin-1", TokenID: "tok-1"},
+			override:          true,
+			pr:                openPR(changes),
+			requiredApprovals: 1,
+			wantOverride:      []string{"approvals: 0 of 1 required", "changes requested by u2"},
+			wantMerged:        true,
+		},
+		{name: "override without blockers is not audited", actor: actorAdmin, override: true, pr: openPR(approved), requiredApprovals: 1, wantMerged: true},
+		{name: "already merged", actor: actorAdmin, pr: mergedPR, requiredApprovals: 2},
+		{name: "draft", actor: actorAdmin, pr: &domain.PullRequest{ID: "pr-1", AuthorID: "author-1", Status: domain.RequestStatusDraft}, wantErr: ErrInvalidStatusTransition},
+		{name: "override by non admin", actor: actorLead, override: true, wantErr: ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+			if tt.pr != nil {
+				mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(tt.pr, nil)
+			}
+			if tt.pr != nil && tt.pr.Status.AcceptsReviews() {
+				mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil)
+				mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(&domain.TeamSettings{TeamName: "team-1", MaxReviewers: 2, RequiredApprovals: tt.requiredApprovals}, nil)
+			}
+			if tt.wantOverride != nil {
+				mockPRRepo.EXPECT().SaveMergeOverride(ctx, &domain.MergeOverride{
+					PullRequestID: "pr-1",
+					ActorUserID:   tt.actor.UserID,
+					ActorTokenID:  tt.actor.TokenID,
+					Conditions:    tt.wantOverride,
+				}).Return(nil)
+			}
+			if tt.wantMerged {
+				gomock.InOrder(
+					mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(openPR(), nil),
+					mockPRRepo.EXPECT().UpdatePullRequest(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, pr *domain.PullRequest) error {
+						if pr.Status != domain.RequestStatusMerged || pr.MergedAt.IsZero() {
+							t.Fatalf("expected merged status with merge time, got %#v", pr)
+						}
+						return nil
+					}),
+					mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(mergedPR, nil),
+				)
+				reason := domain.AssignmentReasonMerge
+				if tt.wantOverride != nil {
+					reason = domain.AssignmentReasonMergeOverride
+				}
+				mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{{
+					PullRequestID: "pr-1",
+					Type:          domain.AssignmentEventMerged,
+					Status:        domain.RequestStatusMerged,
+					ActorUserID:   tt.actor.UserID,
+					ActorTokenID:  tt.actor.TokenID,
+					Reason:        reason,
+				}}).Return(nil)
+			}
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+			// Act
+			got, err := uc.MergePullRequest(ctx, "pr-1", tt.override)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantMessage != "" && !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Fatalf("expected error to list %q, got %v", tt.wantMessage, err)
+			}
+			if tt.wantErr == nil && got.Status != domain.RequestStatusMerged {
+				t.Fatalf("expected merged pull request, got %#v", got)
+			}
+		})
+	}
+}
+
+func TestPullRequest_PreviewAssignment_MatchesCreate(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, mockReqOwnerRepo, newPassThroughTransactor(ctrl), NewRoundRobinSelector(), nil)
+
+	author := &domain.User{ID: "author-1", IsActive: true}
+	members := []domain.User{
+		{ID: "author-1", IsActive: true},
+		{ID: "u3", IsActive: true},
+		{ID: "u1", IsActive: true},
+		{ID: "u2", IsActive: false},
+		{ID: "u4", IsActive: true},
+	}
+	preferences := domain.ReviewerPreferences{Excluded: []string{"u1"}}
+
+	// превью только читает: любые записи упадут как неожиданные вызовы
+	reads := func(times int) {
+		mockUserRepo.EXPECT().GetUserByID(ctx, author.ID).Return(author, nil).Times(times)
+		mockPRRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(nil, ErrPullRequestNotFound).Times(times)
+		mockUserRepo.EXPECT().GetTeamsByUserID(ctx, author.ID).Return([]domain.Team{{Name: "team-1"}}, nil).Times(times)
+		mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(&domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2}, nil).Times(times)
+		mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return(members, nil).Times(times)
+	}
+	reads(2)
+
+	// Act
+	first, err := uc.PreviewAssignment(ctx, &domain.PullRequest{ID: "pr-1", AuthorID: author.ID}, preferences)
+	if err != nil {
+		t.Fatalf("PreviewAssignment() unexpected error: %v", err)
+	}
+	second, err := uc.PreviewAssignment(ctx, &domain.PullRequest{ID: "pr-1", AuthorID: author.ID}, preferences)
+	if err != nil {
+		t.Fatalf("PreviewAssignment() unexpected error: %v", err)
+	}
+
+	reads(1)
+	pr := &domain.PullRequest{ID: "pr-1", Name: "Test PR", AuthorID: author.ID}
+	mockPRRepo.EXPECT().SavePullRequest(ctx, pr).Return(nil)
+	mockReqOwnerRepo.EXPECT().SaveRequestOwner(ctx, gomock.AssignableToTypeOf(&domain.RequestOwner{})).Return(nil).Times(3)
+	mockReqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Len(2)).Return(nil)
+	mockReqOwnerRepo.EXPECT().SaveAssignmentExplanation(ctx, gomock.Any()).Return(nil)
+	mockReqOwnerRepo.EXPECT().SaveExcludedReviewers(ctx, pr.ID, preferences.Excluded).Return(nil)
+
+	created, err := uc.CreatePullRequest(ctx, pr, preferences)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("CreatePullRequest() unexpected error: %v", err)
+	}
+	want := &domain.AssignmentPreview{
+		AuthorID:     author.ID,
+		Strategy:     StrategyRoundRobin,
+		TeamName:     "team-1",
+		MinReviewers: 1,
+		Slots:        2,
+		Proposed:     []string{"u3", "u4"},
+		Candidates: []domain.CandidateDecision{
+			{UserID: "author-1", ExcludedReason: domain.CandidateExcludedAuthor},
+			{UserID: "u3", Eligible: true, Selected: true, Draw: intPtr(0)},
+			{UserID: "u1", ExcludedReason: domain.CandidateExcludedByAuthor},
+			{UserID: "u2", ExcludedReason: domain.CandidateExcludedInactive},
+			{UserID: "u4", Eligible: true, Selected: true, Draw: intPtr(1)},
+		},
+		Deterministic: true,
+	}
+	if !reflect.DeepEqual(first, want) {
+		t.Fatalf("got preview %#v, want %#v", first, want)
+	}
+	// превью не сдвигает очередь round_robin
+	if !reflect.DeepEqual(second, first) {
+		t.Fatalf("repeated preview differs: %#v, want %#v", second, first)
+	}
+	if !reflect.DeepEqual(created.AssignedReviewersID, first.Proposed) {
+		t.Fatalf("created with reviewers %v, preview proposed %v", created.AssignedReviewersID, first.Proposed)
+	}
+}
+
+func TestPullRequest_PreviewAssignment_Errors(t *testing.T) {
+	errDB := errors.New("db error")
+
+	tests := []struct {
+		name        string
+		request     *domain.PullRequest
+		preferences domain.ReviewerPreferences
+		mock        func(ctx context.Context, prRepo *MockPullRequestRepository, userRepo *MockUserRepository, teamRepo *MockTeamRepository)
+		wantErr     error
+	}{
+		{
+			name:    "nil request",
+			wantErr: ErrAuthorNotFound,
+		},
+		{
+			name:        "author requests own review",
+			request:     &domain.PullRequest{AuthorID: "author-1"},
+			preferences: domain.ReviewerPreferences{Requested: []string{"author-1"}},
+			wantErr:     ErrInvalidReviewerRequest,
+		},
+		{
+			name:    "author not found",
+			request: &domain.PullRequest{AuthorID: "author-1"},
+			mock: func(ctx context.Context, _ *MockPullRequestRepository, userRepo *MockUserRepository, _ *MockTeamRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "author-1").Return(nil, ErrMemberNotFound)
+			},
+			wantErr: ErrAuthorNotFound,
+		},
+		{
+			name:    "author inactive",
+			request: &domain.PullRequest{AuthorID: "author-1"},
+			mock: func(ctx context.Context, _ *MockPullRequestRepository, userRepo *MockUserRepository, _ *MockTeamRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "author-1").Return(&domain.User{ID: "author-1"}, nil)
+			},
+			wantErr: ErrAuthorIsInactive,
+		},
+		{
+			name:    "pull request exists",
+			request: &domain.PullRequest{ID: "pr-1", AuthorID: "author-1"},
+			mock: func(ctx context.Context, prRepo *MockPullRequestRepository, userRepo *MockUserRepository, _ *MockTeamRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "author-1").Return(&domain.User{ID: "author-1", IsActive: true}, nil)
+				prRepo.EXPECT().GetPullRequestByID(ctx, "pr-1").Return(&domain.PullRequest{ID: "pr-1"}, nil)
+			},
+			wantErr: ErrPullRequestAlreadyExists,
+		},
+		{
+			name:        "inactive requested reviewer",
+			request:     &domain.PullRequest{AuthorID: "author-1"},
+			preferences: domain.ReviewerPreferences{Requested: []string{"u1"}},
+			mock: func(ctx context.Context, _ *MockPullRequestRepository, userRepo *MockUserRepository, teamRepo *MockTeamRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "author-1").Return(&domain.User{ID: "author-1", IsActive: true}, nil)
+				userRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil)
+				teamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil)
+				userRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return([]domain.User{{ID: "u1"}}, nil)
+			},
+			wantErr: ErrReviewerInactive,
+		},
+		{
+			name:    "settings error",
+			request: &domain.PullRequest{AuthorID: "author-1"},
+			mock: func(ctx context.Context, _ *MockPullRequestRepository, userRepo *MockUserRepository, teamRepo *MockTeamRepository) {
+				userRepo.EXPECT().GetUserByID(ctx, "author-1").Return(&domain.User{ID: "author-1", IsActive: true}, nil)
+				userRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil)
+				teamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+
+			mockPRRepo := NewMockPullRequestRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			if tt.mock != nil {
+				tt.mock(ctx, mockPRRepo, mockUserRepo, mockTeamRepo)
+			}
+
+			uc := NewPullRequest(mockPRRepo, mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), nil, NewRoundRobinSelector(), nil)
+
+			// Act
+			got, err := uc.PreviewAssignment(ctx, tt.request, tt.preferences)
+
+			// Assert
+			if got != nil {
+				t.Fatalf("expected nil preview, got %#v", got)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPullRequest_PlanReviewers_TeamWithoutMembers(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "empty").Return([]domain.User{}, ErrMemberNotFound)
+	mockUserRepo.EXPECT().GetUsersByTeamName(ctx, "team-1").Return([]domain.User{{ID: "author-1", IsActive: true}, {ID: "u1", IsActive: true}}, nil)
+
+	uc := NewPullRequest(NewMockPullRequestRepository(ctrl), NewMockTeamRepository(ctrl), mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), newTestSelector(), nil)
+
+	// Act
+	plan, err := uc.planReviewers(ctx, "author-1", []domain.Team{{Name: "empty"}, {Name: "team-1"}}, domain.DefaultTeamSettings("team-1"), domain.ReviewerPreferences{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	if got.ID != requestID {
-		t.Fatalf("expected PullRequest ID %s, got %s", requestID, got.ID)
+	if len(plan.candidates) != 1 || plan.candidates[0].ID != "u1" {
+		t.Fatalf("expected u1 as the only candidate, got %#v", plan.candidates)
 	}
 }
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Clock - источник текущего времени для проверки сроков ревью.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ReviewSLA - проверка сроков ответа ревьюверов. Сроки задаются настройками команды автора PR:
+// после review_sla_hours ревьювер получает напоминание, после reassign_after_hours заменяется через ReassignRequest.
+type ReviewSLA struct {
+	requestOwnerRepository RequestOwnerRepository
+	userRepository         UserRepository
+	teamRepository         TeamRepository
+	reassigner             ReviewerReassigner
+	notifier               Notifier
+	clock                  Clock
+}
+
+// NewReviewSLA создает проверку сроков ревью, clock == nil - системное время.
+func NewReviewSLA(requestOwnerRepository RequestOwnerRepository,
+	userRepository UserRepository,
+	teamRepository TeamRepository,
+	reassigner ReviewerReassigner,
+	notifier Notifier,
+	clock Clock) ReviewSLA {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return ReviewSLA{
+		requestOwnerRepository: requestOwnerRepository,
+		userRepository:         userRepository,
+		teamRepository:         teamRepository,
+		reassigner:             reassigner,
+		notifier:               notifier,
+		clock:                  clock,
+	}
+}
+
+// Run проверяет сроки ревью каждые interval, пока не отменен ctx. Ошибки проверки логируются, цикл продолжается.
+func (s *ReviewSLA) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.CheckOverdue(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "review sla check failed", slog.Any("error", err))
+			}
+			if report != nil && (len(report.Notified) > 0 || len(report.Reassigned) > 0) {
+				slog.InfoContext(ctx, "review sla check",
+					slog.Int("notified", len(report.Notified)),
+					slog.Int("reassigned", len(report.Reassigned)),
+				)
+			}
+		}
+	}
+}
+
+// CheckOverdue выполняет одну проверку назначений на OPEN PR без одобрения или запроса изменений.
+// После второго срока ревьювер заменяется, а если кандидата нет - получает повторное напоминание.
+// После первого срока ревьювер получает одно напоминание. Ошибка по одному назначению не останавливает
+// проверку остальных: возвращается отчет по обработанным и объединенная ошибка.
+// Замена выполняется от имени SystemActor независимо от пользователя в ctx.
+func (s *ReviewSLA) CheckOverdue(ctx context.Context) (_ *domain.ReviewSLAReport, err error) {
+	ctx, span := startSpan(ctx, "ReviewSLA.CheckOverdue")
+	defer endSpan(span, &err)
+
+	if s.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	} else if s.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	} else if s.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	} else if s.reassigner == nil {
+		return nil, ErrReviewerReassignerNotFound
+	} else if s.notifier == nil {
+		return nil, ErrNotifierNotFound
+	}
+
+	now := s.clock.Now()
+	assignments, err := s.requestOwnerRepository.GetPendingReviewAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ReviewSLAReport{Notified: []domain.ReviewAssignment{}, Reassigned: []domain.ReviewerReplacement{}}
+	settingsByAuthor := make(map[string]domain.TeamSettings)
+	var errs []error
+	for _, assignment := range assignments {
+		settings, ok := settingsByAuthor[assignment.AuthorID]
+		if !ok {
+			settings, err = s.authorSettings(ctx, assignment.AuthorID)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			settingsByAuthor[assignment.AuthorID] = settings
+		}
+
+		dueAt, overdue := settings.ReviewDueAt(assignment.AssignedAt)
+		overdue = overdue && !now.Before(dueAt) && assignment.NotifiedAt.IsZero()
+
+		// напоминание, отправленное после второго срока, означает, что замену уже не нашли
+		if reassignAt, ok := settings.ReassignAt(assignment.AssignedAt); ok && !now.Before(reassignAt) && assignment.NotifiedAt.Before(reassignAt) {
+			switch replacement, err := s.reassign(ctx, assignment); {
+			case errors.Is(err, ErrCannotFindActiveMembers):
+				// кандидата нет - ревьювер остается и получает напоминание
+				dueAt, overdue = reassignAt, true
+			case err != nil:
+				errs = append(errs, err)
+				continue
+			case replacement != nil:
+				report.Reassigned = append(report.Reassigned, *replacement)
+				continue
+			default:
+				continue
+			}
+		}
+		if !overdue {
+			continue
+		}
+
+		if err := s.notify(ctx, assignment, dueAt, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		assignment.NotifiedAt = now
+		report.Notified = append(report.Notified, assignment)
+	}
+
+	span.SetAttributes(
+		attribute.Int("review_sla.pending", len(assignments)),
+		attribute.Int("review_sla.notified", len(report.Notified)),
+		attribute.Int("review_sla.reassigned", len(report.Reassigned)),
+	)
+	return report, errors.Join(errs...)
+}
+
+// authorSettings - настройки команды, по которой назначались ревьюверы на PR автора.
+func (s *ReviewSLA) authorSettings(ctx context.Context, authorID string) (domain.TeamSettings, error) {
+	authorTeams, err := s.userRepository.GetTeamsByUserID(ctx, authorID)
+	if err != nil && !errors.Is(err, ErrTeamNotFound) {
+		return domain.TeamSettings{}, err
+	}
+	return loadTeamSettings(ctx, s.teamRepository, selectionTeam(authorTeams))
+}
+
+// reassign заменяет ревьювера просроченного назначения. nil без ошибки - назначение уже неактуально
+// (ревьювер снят, PR закрыт или слит), ErrCannotFindActiveMembers - кандидата на замену нет.
+func (s *ReviewSLA) reassign(ctx context.Context, assignment domain.ReviewAssignment) (*domain.ReviewerReplacement, error) {
+	// замену делает сама проверка сроков, а не пользователь запроса
+	ctx = withAssignmentReason(WithActor(ctx, SystemActor), domain.AssignmentReasonReviewSLA)
+	_, newReviewer, err := s.reassigner.ReassignRequest(ctx, assignment.PullRequestID, assignment.ReviewerID)
+	switch {
+	case err == nil:
+		return &domain.ReviewerReplacement{
+			PullRequestID: assignment.PullRequestID,
+			OldReviewerID: assignment.ReviewerID,
+			NewReviewerID: newReviewer.ID,
+		}, nil
+	case errors.Is(err, ErrReviewerNotAssigned),
+		errors.Is(err, ErrPullRequestIsMerged),
+		errors.Is(err, ErrPullRequestNotOpen),
+		errors.Is(err, ErrPullRequestNotFound):
+		return nil, nil
+	default:
+		return nil, err
+	}
+}
+
+func (s *ReviewSLA) notify(ctx context.Context, assignment domain.ReviewAssignment, dueAt, now time.Time) error {
+	if err := s.notifier.NotifyOverdueReview(ctx, assignment, dueAt); err != nil {
+		return err
+	}
+	return s.requestOwnerRepository.MarkAssignmentNotified(ctx, assignment.PullRequestID, assignment.ReviewerID, now)
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestAddBusinessHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  time.Time
+		hours int
+		want  time.Time
+	}{
+		{name: "within week", from: time.Date(2025, 10, 20, 10, 0, 0, 0, time.UTC), hours: 24, want: time.Date(2025, 10, 21, 10, 0, 0, 0, time.UTC)},
+		{name: "over weekend", from: time.Date(2025, 10, 17, 15, 0, 0, 0, time.UTC), hours: 24, want: time.Date(2025, 10, 20, 15, 0, 0, 0, time.UTC)},
+		{name: "assigned on saturday", from: time.Date(2025, 10, 18, 20, 0, 0, 0, time.UTC), hours: 8, want: time.Date(2025, 10, 20, 8, 0, 0, 0, time.UTC)},
+		{name: "ends at midnight before weekend", from: time.Date(2025, 10, 17, 0, 0, 0, 0, time.UTC), hours: 24, want: time.Date(2025, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "zero hours", from: time.Date(2025, 10, 18, 20, 0, 0, 0, time.UTC), hours: 0, want: time.Date(2025, 10, 18, 20, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.AddBusinessHours(tt.from, tt.hours); !got.Equal(tt.want) {
+				t.Fatalf("AddBusinessHours() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewSLA_CheckOverdue(t *testing.T) {
+	errDB := errors.New("db error")
+	// среда
+	now := time.Date(2025, 10, 22, 12, 0, 0, 0, time.UTC)
+	escalation := &domain.TeamSettings{TeamName: "team-1", MaxReviewers: 2, ReviewSLAHours: 24, ReassignAfterHours: 48}
+	assignment := func(assignedAt, notifiedAt time.Time) domain.ReviewAssignment {
+		return domain.ReviewAssignment{PullRequestID: "pr-1", AuthorID: "author-1", ReviewerID: "u1", AssignedAt: assignedAt, NotifiedAt: notifiedAt}
+	}
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		settings     *domain.TeamSettings
+		assignment   domain.ReviewAssignment
+		reassign     bool
+		reassignErr  error
+		wantNotified time.Time
+		wantReplaced bool
+		wantErr      error
+	}{
+		{
+			name:       "not due yet",
+			settings:   escalation,
+			assignment: assignment(now.Add(-time.Hour), time.Time{}),
+		},
+		{
+			name:         "overdue with default settings",
+			assignment:   assignment(now.Add(-26*time.Hour), time.Time{}),
+			wantNotified: now.Add(-2 * time.Hour),
+		},
+		{
+			name:       "already notified",
+			settings:   escalation,
+			assignment: assignment(now.Add(-30*time.Hour), now.Add(-6*time.Hour)),
+		},
+		{
+			name:       "weekend is not counted",
+			now:        time.Date(2025, 10, 20, 14, 0, 0, 0, time.UTC),
+			settings:   escalation,
+			assignment: assignment(time.Date(2025, 10, 17, 15, 0, 0, 0, time.UTC), time.Time{}),
+		},
+		{
+			name:         "assigned time with offset",
+			settings:     escalation,
+			assignment:   assignment(now.Add(-26*time.Hour).In(time.FixedZone("MSK", 3*60*60)), time.Time{}),
+			wantNotified: now.Add(-2 * time.Hour),
+		},
+		{
+			// пятница 23:30 по Нью-Йорку - уже суббота в UTC, отсчет начинается с понедельника
+			name:       "weekend is counted in utc",
+			now:        time.Date(2025, 10, 20, 23, 45, 0, 0, time.UTC),
+			settings:   escalation,
+			assignment: assignment(time.Date(2025, 10, 17, 23, 30, 0, 0, time.FixedZone("EST", -5*60*60)), time.Time{}),
+		},
+		{
+			name:       "review sla disabled",
+			settings:   &domain.TeamSettings{TeamName: "team-1", MaxReviewers: 2},
+			assignment: assignment(now.Add(-72*time.Hour), time.Time{}),
+		},
+		{
+			name:         "reassigned after second threshold",
+			settings:     escalation,
+			assignment:   assignment(now.Add(-50*time.Hour), now.Add(-26*time.Hour)),
+			reassign:     true,
+			wantReplaced: true,
+		},
+		{
+			name:         "no candidate for replacement",
+			settings:     escalation,
+			assignment:   assignment(now.Add(-50*time.Hour), now.Add(-26*time.Hour)),
+			reassign:     true,
+			reassignErr:  ErrCannotFindActiveMembers,
+			wantNotified: now.Add(-2 * time.Hour),
+		},
+		{
+			name:       "no candidate already reported",
+			settings:   escalation,
+			assignment: assignment(now.Add(-50*time.Hour), now.Add(-time.Hour)),
+		},
+		{
+			name:        "reviewer removed concurrently",
+			settings:    escalation,
+			assignment:  assignment(now.Add(-50*time.Hour), time.Time{}),
+			reassign:    true,
+			reassignErr: ErrReviewerNotAssigned,
+		},
+		{
+			name:        "reassign error",
+			settings:    escalation,
+			assignment:  assignment(now.Add(-50*time.Hour), time.Time{}),
+			reassign:    true,
+			reassignErr: errDB,
+			wantErr:     errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			checkedAt := now
+			if !tt.now.IsZero() {
+				checkedAt = tt.now
+			}
+
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockReassigner := NewMockReviewerReassigner(ctrl)
+			mockNotifier := NewMockNotifier(ctrl)
+
+			mockReqOwnerRepo.EXPECT().GetPendingReviewAssignments(ctx).Return([]domain.ReviewAssignment{tt.assignment}, nil)
+			mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return([]domain.Team{{Name: "team-1"}}, nil)
+			mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(tt.settings, nil)
+			if tt.reassign {
+				var newReviewer *domain.User
+				if tt.reassignErr == nil {
+					newReviewer = &domain.User{ID: "u2", IsActive: true}
+				}
+				// замена выполняется от служебного пользователя с причиной review_sla в контексте
+				mockReassigner.EXPECT().ReassignRequest(gomock.Any(), "pr-1", "u1").DoAndReturn(func(ctx context.Context, _, _ string) (*domain.PullRequest, *domain.User, error) {
+					if reason := assignmentReason(ctx, ""); reason != domain.AssignmentReasonReviewSLA {
+						t.Fatalf("expected reassign reason %q, got %q", domain.AssignmentReasonReviewSLA, reason)
+					}
+					if actor := actorFromContext(ctx); actor != SystemActor {
+						t.Fatalf("expected system actor, got %+v", actor)
+					}
+					return nil, newReviewer, tt.reassignErr
+				})
+			}
+			if !tt.wantNotified.IsZero() {
+				mockNotifier.EXPECT().NotifyOverdueReview(ctx, tt.assignment, tt.wantNotified).Return(nil)
+				mockReqOwnerRepo.EXPECT().MarkAssignmentNotified(ctx, "pr-1", "u1", checkedAt).Return(nil)
+			}
+
+			uc := NewReviewSLA(mockReqOwnerRepo, mockUserRepo, mockTeamRepo, mockReassigner, mockNotifier, fixedClock(checkedAt))
+
+			// Act
+			got, err := uc.CheckOverdue(ctx)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			want := &domain.ReviewSLAReport{Notified: []domain.ReviewAssignment{}, Reassigned: []domain.ReviewerReplacement{}}
+			if !tt.wantNotified.IsZero() {
+				notified := tt.assignment
+				notified.NotifiedAt = checkedAt
+				want.Notified = append(want.Notified, notified)
+			}
+			if tt.wantReplaced {
+				want.Reassigned = append(want.Reassigned, domain.ReviewerReplacement{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"})
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestReviewSLA_CheckOverdue_ContinuesAfterError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	errDB := errors.New("db error")
+	now := time.Date(2025, 10, 22, 12, 0, 0, 0, time.UTC)
+	failed := domain.ReviewAssignment{PullRequestID: "pr-1", AuthorID: "author-1", ReviewerID: "u1", AssignedAt: now.Add(-26 * time.Hour)}
+	overdue := domain.ReviewAssignment{PullRequestID: "pr-2", AuthorID: "author-1", ReviewerID: "u2", AssignedAt: now.Add(-25 * time.Hour)}
+
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockNotifier := NewMockNotifier(ctrl)
+
+	mockReqOwnerRepo.EXPECT().GetPendingReviewAssignments(ctx).Return([]domain.ReviewAssignment{failed, overdue}, nil)
+	// настройки автора загружаются один раз на проверку
+	mockUserRepo.EXPECT().GetTeamsByUserID(ctx, "author-1").Return(nil, ErrTeamNotFound)
+	mockNotifier.EXPECT().NotifyOverdueReview(ctx, failed, now.Add(-2*time.Hour)).Return(errDB)
+	mockNotifier.EXPECT().NotifyOverdueReview(ctx, overdue, now.Add(-time.Hour)).Return(nil)
+	mockReqOwnerRepo.EXPECT().MarkAssignmentNotified(ctx, "pr-2", "u2", now).Return(nil)
+
+	uc := NewReviewSLA(mockReqOwnerRepo, mockUserRepo, mockTeamRepo, NewMockReviewerReassigner(ctrl), mockNotifier, fixedClock(now))
+
+	// Act
+	got, err := uc.CheckOverdue(ctx)
+
+	// Assert
+	if !errors.Is(err, errDB) {
+		t.Fatalf("expected error %v, got %v", errDB, err)
+	}
+	if len(got.Notified) != 1 || got.Notified[0].PullRequestID != "pr-2" {
+		t.Fatalf("expected pr-2 to be notified, got %#v", got.Notified)
+	}
+}
+
+func TestReviewSLA_CheckOverdue_NilDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewReviewSLA(NewMockRequestOwnerRepository(ctrl), NewMockUserRepository(ctrl), NewMockTeamRepository(ctrl), nil, NewMockNotifier(ctrl), nil)
+
+	if _, err := uc.CheckOverdue(context.Background()); !errors.Is(err, ErrReviewerReassignerNotFound) {
+		t.Fatalf("expected ErrReviewerReassignerNotFound, got %v", err)
+	}
+}
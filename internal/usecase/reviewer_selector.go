@@ -0,0 +1,472 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyWeighted    = "weighted"
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// ReviewerSelector - стратегия выбора ревьюверов из уже отфильтрованных кандидатов.
+type ReviewerSelector interface {
+	// Select - функция выбора не более n ревьюверов из candidates для команды teamName
+	Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, error)
+}
+
+// StrategyNamer - селектор, который сообщает название стратегии, применяемой для команды.
+// Название попадает в историю назначений.
+type StrategyNamer interface {
+	// Strategy - функция получения названия стратегии выбора для команды teamName
+	Strategy(teamName string) string
+}
+
+// selectorStrategy - название стратегии selector для команды teamName, пусто если селектор его не сообщает.
+func selectorStrategy(selector ReviewerSelector, teamName string) string {
+	if namer, ok := selector.(StrategyNamer); ok {
+		return namer.Strategy(teamName)
+	}
+	return ""
+}
+
+// TracingSelector - селектор, который объясняет выбор: решение и оценки стратегии по каждому кандидату.
+type TracingSelector interface {
+	// SelectTraced - функция выбора как Select, дополнительно возвращает решения по candidates в их порядке
+	SelectTraced(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error)
+}
+
+// selectReviewers выбирает ревьюверов selector и объясняет выбор. Для селектора без TracingSelector
+// все кандидаты считаются подходящими, оценок у них нет.
+func selectReviewers(ctx context.Context, selector ReviewerSelector, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	var (
+		picked    []domain.User
+		decisions []domain.CandidateDecision
+		err       error
+	)
+	if tracer, ok := selector.(TracingSelector); ok {
+		picked, decisions, err = tracer.SelectTraced(ctx, teamName, candidates, n)
+	} else {
+		picked, err = selector.Select(ctx, teamName, candidates, n)
+		decisions = eligibleDecisions(candidates)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	markSelected(decisions, picked)
+	return picked, decisions, nil
+}
+
+// PreviewSelector - селектор, который показывает выбор, не меняя своего состояния: курсоры round_robin
+// не сдвигаются, общий rng не расходуется.
+type PreviewSelector interface {
+	// PreviewSelect - функция выбора как SelectTraced без побочных эффектов
+	PreviewSelect(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error)
+}
+
+// previewReviewers показывает выбор selector без побочных эффектов. Селектор без PreviewSelector
+// нельзя вызвать без риска изменить его состояние, поэтому для него никто не предлагается.
+func previewReviewers(ctx context.Context, selector ReviewerSelector, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	previewer, ok := selector.(PreviewSelector)
+	if !ok {
+		return []domain.User{}, eligibleDecisions(candidates), nil
+	}
+	picked, decisions, err := previewer.PreviewSelect(ctx, teamName, candidates, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	markSelected(decisions, picked)
+	return picked, decisions, nil
+}
+
+// deterministicStrategy - выбор стратегии зависит только от кандидатов и состояния селектора,
+// поэтому превью совпадает с последующим выбором, если между ними селектор не вызывался.
+func deterministicStrategy(strategy string) bool {
+	return strategy == StrategyRoundRobin
+}
+
+// previewRand - rng случайных стратегий для превью, чтобы превью не сдвигало последовательность общего rng.
+var previewRand = newLockedRand(nil)
+
+func markSelected(decisions []domain.CandidateDecision, picked []domain.User) {
+	selected := make(map[string]struct{}, len(picked))
+	for _, user := range picked {
+		selected[user.ID] = struct{}{}
+	}
+	for i := range decisions {
+		if _, ok := selected[decisions[i].UserID]; ok {
+			decisions[i].Selected = true
+		}
+	}
+}
+
+// eligibleDecisions - решения по кандидатам, прошедшим фильтры, до выбора стратегией.
+func eligibleDecisions(candidates []domain.User) []domain.CandidateDecision {
+	decisions := make([]domain.CandidateDecision, len(candidates))
+	for i, c := range candidates {
+		decisions[i] = domain.CandidateDecision{UserID: c.ID, Eligible: true}
+	}
+	return decisions
+}
+
+// ReviewerSelectorConfig - настройки стратегий: стратегия по умолчанию и переопределения по командам.
+type ReviewerSelectorConfig struct {
+	// Default - стратегия для команд без отдельной настройки
+	Default string
+	// Teams - стратегия по названию команды
+	Teams map[string]string
+	// Weights - веса пользователей для стратегии weighted, по умолчанию 1
+	Weights map[string]int
+}
+
+// OpenReviewCounter - источник нагрузки ревьюверов для стратегии least_loaded.
+type OpenReviewCounter interface {
+	// GetOpenReviewCounts - функция получения количества OPEN PR, где пользователь ревьювер, по id пользователя
+	GetOpenReviewCounts(ctx context.Context) (map[string]int, error)
+}
+
+// NewReviewerSelector собирает селектор по конфигурации. Все стратегии используют общий rng,
+// поэтому при фиксированном seed выбор детерминирован. counter нужен только для least_loaded.
+func NewReviewerSelector(cfg ReviewerSelectorConfig, rng *rand.Rand, counter OpenReviewCounter) (ReviewerSelector, error) {
+	src := newLockedRand(rng)
+
+	build := func(name string) (ReviewerSelector, error) {
+		switch name {
+		case "", StrategyRandom:
+			return &RandomSelector{rng: src}, nil
+		case StrategyRoundRobin:
+			return NewRoundRobinSelector(), nil
+		case StrategyWeighted:
+			return &WeightedSelector{rng: src, weights: cfg.Weights}, nil
+		case StrategyLeastLoaded:
+			if counter == nil {
+				return nil, fmt.Errorf("%s: open review counter is nil", StrategyLeastLoaded)
+			}
+			return &LeastLoadedSelector{rng: src, counter: counter}, nil
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownReviewerStrategy, name)
+		}
+	}
+
+	fallback, err := build(cfg.Default)
+	if err != nil {
+		return nil, err
+	}
+	byTeam := make(map[string]ReviewerSelector, len(cfg.Teams))
+	for team, name := range cfg.Teams {
+		selector, err := build(name)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", team, err)
+		}
+		byTeam[team] = selector
+	}
+	return &TeamReviewerSelector{byTeam: byTeam, fallback: fallback}, nil
+}
+
+// TeamReviewerSelector делегирует выбор стратегии, настроенной для команды.
+type TeamReviewerSelector struct {
+	byTeam   map[string]ReviewerSelector
+	fallback ReviewerSelector
+}
+
+func (s *TeamReviewerSelector) Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, error) {
+	return s.selector(teamName).Select(ctx, teamName, candidates, n)
+}
+
+func (s *TeamReviewerSelector) SelectTraced(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	return selectReviewers(ctx, s.selector(teamName), teamName, candidates, n)
+}
+
+func (s *TeamReviewerSelector) PreviewSelect(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	return previewReviewers(ctx, s.selector(teamName), teamName, candidates, n)
+}
+
+func (s *TeamReviewerSelector) Strategy(teamName string) string {
+	return selectorStrategy(s.selector(teamName), teamName)
+}
+
+func (s *TeamReviewerSelector) selector(teamName string) ReviewerSelector {
+	if selector, ok := s.byTeam[teamName]; ok {
+		return selector
+	}
+	return s.fallback
+}
+
+// RandomSelector выбирает n случайных кандидатов.
+type RandomSelector struct {
+	rng *lockedRand
+}
+
+func NewRandomSelector(rng *rand.Rand) *RandomSelector {
+	return &RandomSelector{rng: newLockedRand(rng)}
+}
+
+func (s *RandomSelector) Strategy(string) string {
+	return StrategyRandom
+}
+
+func (s *RandomSelector) Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, error) {
+	picked, _, err := s.SelectTraced(ctx, teamName, candidates, n)
+	return picked, err
+}
+
+// SelectTraced - Draw кандидата - его позиция после перемешивания, выбираются первые n.
+func (s *RandomSelector) SelectTraced(_ context.Context, _ string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	picked, decisions := shuffleSelect(s.rng, candidates, n)
+	return picked, decisions, nil
+}
+
+func (s *RandomSelector) PreviewSelect(_ context.Context, _ string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	picked, decisions := shuffleSelect(previewRand, candidates, n)
+	return picked, decisions, nil
+}
+
+func shuffleSelect(rng *lockedRand, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision) {
+	decisions := eligibleDecisions(candidates)
+	if n <= 0 {
+		return []domain.User{}, decisions
+	}
+	order := candidateOrder(candidates)
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+	result := make([]domain.User, 0, min(n, len(order)))
+	for pos, i := range order {
+		decisions[i].Draw = intPtr(pos)
+		if pos < n {
+			result = append(result, candidates[i])
+		}
+	}
+	return result, decisions
+}
+
+// RoundRobinSelector выбирает кандидатов по кругу отдельно для каждой команды.
+// Кандидаты упорядочиваются по ID, поэтому порядок не зависит от порядка выдачи из БД.
+type RoundRobinSelector struct {
+	mu      sync.Mutex
+	cursors map[string]int
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{cursors: make(map[string]int)}
+}
+
+func (s *RoundRobinSelector) Strategy(string) string {
+	return StrategyRoundRobin
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, error) {
+	picked, _, err := s.SelectTraced(ctx, teamName, candidates, n)
+	return picked, err
+}
+
+// SelectTraced - Draw кандидата - его позиция в очереди от курсора команды, выбираются первые n.
+func (s *RoundRobinSelector) SelectTraced(_ context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	picked, decisions := s.pick(teamName, candidates, n, true)
+	return picked, decisions, nil
+}
+
+// PreviewSelect - выбор от текущего курсора команды, курсор не сдвигается.
+func (s *RoundRobinSelector) PreviewSelect(_ context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	picked, decisions := s.pick(teamName, candidates, n, false)
+	return picked, decisions, nil
+}
+
+// pick выбирает n кандидатов от курсора команды teamName, advance - сдвинуть курсор на выбранных.
+func (s *RoundRobinSelector) pick(teamName string, candidates []domain.User, n int, advance bool) ([]domain.User, []domain.CandidateDecision) {
+	decisions := eligibleDecisions(candidates)
+	if len(candidates) == 0 || n <= 0 {
+		return []domain.User{}, decisions
+	}
+	sorted := candidateOrder(candidates)
+	sort.Slice(sorted, func(i, j int) bool { return candidates[sorted[i]].ID < candidates[sorted[j]].ID })
+
+	n = min(n, len(sorted))
+
+	s.mu.Lock()
+	start := s.cursors[teamName] % len(sorted)
+	if advance {
+		s.cursors[teamName] = start + n
+	}
+	s.mu.Unlock()
+
+	result := make([]domain.User, 0, n)
+	for pos := range sorted {
+		i := sorted[(start+pos)%len(sorted)]
+		decisions[i].Draw = intPtr(pos)
+		if pos < n {
+			result = append(result, candidates[i])
+		}
+	}
+	return result, decisions
+}
+
+// WeightedSelector выбирает кандидатов случайно без повторов с вероятностью, пропорциональной весу.
+// Пользователи без веса получают вес 1, с весом <= 0 не выбираются.
+type WeightedSelector struct {
+	rng     *lockedRand
+	weights map[string]int
+}
+
+func NewWeightedSelector(rng *rand.Rand, weights map[string]int) *WeightedSelector {
+	return &WeightedSelector{rng: newLockedRand(rng), weights: weights}
+}
+
+func (s *WeightedSelector) Strategy(string) string {
+	return StrategyWeighted
+}
+
+func (s *WeightedSelector) Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, error) {
+	picked, _, err := s.SelectTraced(ctx, teamName, candidates, n)
+	return picked, err
+}
+
+// SelectTraced - Score кандидата - его вес, Draw выбранного - выпавшее значение из [0, сумма оставшихся весов).
+// Кандидаты с весом <= 0 исключаются как over_capacity.
+func (s *WeightedSelector) SelectTraced(_ context.Context, _ string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	picked, decisions := s.pick(s.rng, candidates, n)
+	return picked, decisions, nil
+}
+
+func (s *WeightedSelector) PreviewSelect(_ context.Context, _ string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	picked, decisions := s.pick(previewRand, candidates, n)
+	return picked, decisions, nil
+}
+
+func (s *WeightedSelector) pick(rng *lockedRand, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision) {
+	decisions := eligibleDecisions(candidates)
+	pool := make([]int, 0, len(candidates))
+	weights := make([]int, 0, len(candidates))
+	total := 0
+	for i, c := range candidates {
+		w, ok := s.weights[c.ID]
+		if !ok {
+			w = 1
+		}
+		decisions[i].Score = intPtr(w)
+		if w <= 0 {
+			decisions[i].Eligible = false
+			decisions[i].ExcludedReason = domain.CandidateExcludedOverCapacity
+			continue
+		}
+		pool = append(pool, i)
+		weights = append(weights, w)
+		total += w
+	}
+
+	result := make([]domain.User, 0, min(n, len(pool)))
+	for len(result) < n && len(pool) > 0 {
+		draw := rng.Intn(total)
+		value := draw
+		i := 0
+		for ; draw >= weights[i]; i++ {
+			draw -= weights[i]
+		}
+		decisions[pool[i]].Draw = intPtr(value)
+		result = append(result, candidates[pool[i]])
+		total -= weights[i]
+		pool = append(pool[:i], pool[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+	return result, decisions
+}
+
+// LeastLoadedSelector выбирает кандидатов с наименьшим числом OPEN PR на ревью,
+// при равной нагрузке - случайно.
+type LeastLoadedSelector struct {
+	rng     *lockedRand
+	counter OpenReviewCounter
+}
+
+func NewLeastLoadedSelector(rng *rand.Rand, counter OpenReviewCounter) *LeastLoadedSelector {
+	return &LeastLoadedSelector{rng: newLockedRand(rng), counter: counter}
+}
+
+func (s *LeastLoadedSelector) Strategy(string) string {
+	return StrategyLeastLoaded
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, teamName string, candidates []domain.User, n int) ([]domain.User, error) {
+	picked, _, err := s.SelectTraced(ctx, teamName, candidates, n)
+	return picked, err
+}
+
+// SelectTraced - Score кандидата - число его OPEN PR на ревью, Draw - позиция после перемешивания,
+// которая решает при равной нагрузке.
+func (s *LeastLoadedSelector) SelectTraced(ctx context.Context, _ string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	return s.pick(ctx, s.rng, candidates, n)
+}
+
+func (s *LeastLoadedSelector) PreviewSelect(ctx context.Context, _ string, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	return s.pick(ctx, previewRand, candidates, n)
+}
+
+func (s *LeastLoadedSelector) pick(ctx context.Context, rng *lockedRand, candidates []domain.User, n int) ([]domain.User, []domain.CandidateDecision, error) {
+	decisions := eligibleDecisions(candidates)
+	if len(candidates) == 0 || n <= 0 {
+		return []domain.User{}, decisions, nil
+	}
+	counts, err := s.counter.GetOpenReviewCounts(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ordered := candidateOrder(candidates)
+	rng.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	for pos, i := range ordered {
+		decisions[i].Score = intPtr(counts[candidates[i].ID])
+		decisions[i].Draw = intPtr(pos)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return counts[candidates[ordered[i]].ID] < counts[candidates[ordered[j]].ID] })
+
+	result := make([]domain.User, 0, min(n, len(ordered)))
+	for _, i := range ordered[:min(n, len(ordered))] {
+		result = append(result, candidates[i])
+	}
+	return result, decisions, nil
+}
+
+// candidateOrder - индексы candidates по порядку, стратегии переставляют их вместо самих кандидатов.
+func candidateOrder(candidates []domain.User) []int {
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// lockedRand - *rand.Rand, безопасный для конкурентного использования.
+type lockedRand struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func newLockedRand(rng *rand.Rand) *lockedRand {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &lockedRand{rng: rng}
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.Intn(n)
+}
+
+func (r *lockedRand) Shuffle(n int, swap func(i, j int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rng.Shuffle(n, swap)
+}
@@ -0,0 +1,488 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func selectorCandidates(ids ...string) []domain.User {
+	users := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		users = append(users, domain.User{ID: id, Username: id, IsActive: true})
+	}
+	return users
+}
+
+func userIDs(users []domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func TestRandomSelector_SameSeedSameChoice(t *testing.T) {
+	ctx := context.Background()
+	candidates := selectorCandidates("u1", "u2", "u3", "u4", "u5")
+
+	first := NewRandomSelector(rand.New(rand.NewSource(42)))
+	second := NewRandomSelector(rand.New(rand.NewSource(42)))
+
+	for i := 0; i < 10; i++ {
+		got1, err := first.Select(ctx, "team-1", candidates, 2)
+		if err != nil {
+			t.Fatalf("Select() unexpected error: %v", err)
+		}
+		got2, _ := second.Select(ctx, "team-1", candidates, 2)
+		if !reflect.DeepEqual(userIDs(got1), userIDs(got2)) {
+			t.Fatalf("round %d: same seed gave different picks %v and %v", i, userIDs(got1), userIDs(got2))
+		}
+		if len(got1) != 2 || got1[0].ID == got1[1].ID {
+			t.Fatalf("round %d: expected 2 distinct reviewers, got %v", i, userIDs(got1))
+		}
+	}
+}
+
+func TestRandomSelector_FewerCandidatesThanSlots(t *testing.T) {
+	selector := NewRandomSelector(rand.New(rand.NewSource(1)))
+
+	tests := []struct {
+		name       string
+		candidates []domain.User
+		n          int
+		wantLen    int
+	}{
+		{name: "no candidates", candidates: nil, n: 2, wantLen: 0},
+		{name: "one candidate", candidates: selectorCandidates("u1"), n: 2, wantLen: 1},
+		{name: "zero slots", candidates: selectorCandidates("u1", "u2"), n: 0, wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selector.Select(context.Background(), "team-1", tt.candidates, tt.n)
+			if err != nil {
+				t.Fatalf("Select() unexpected error: %v", err)
+			}
+			if len(got) != tt.wantLen {
+				t.Fatalf("expected %d reviewers, got %v", tt.wantLen, userIDs(got))
+			}
+		})
+	}
+}
+
+func TestRoundRobinSelector_RotatesPerTeam(t *testing.T) {
+	ctx := context.Background()
+	selector := NewRoundRobinSelector()
+
+	// порядок выдачи кандидатов не влияет на очередь
+	candidates := selectorCandidates("u3", "u1", "u2")
+
+	steps := []struct {
+		team string
+		n    int
+		want []string
+	}{
+		{team: "team-1", n: 2, want: []string{"u1", "u2"}},
+		{team: "team-1", n: 2, want: []string{"u3", "u1"}},
+		{team: "team-2", n: 1, want: []string{"u1"}},
+		{team: "team-1", n: 1, want: []string{"u2"}},
+		{team: "team-2", n: 5, want: []string{"u2", "u3", "u1"}},
+	}
+
+	for i, step := range steps {
+		got, err := selector.Select(ctx, step.team, candidates, step.n)
+		if err != nil {
+			t.Fatalf("step %d: Select() unexpected error: %v", i, err)
+		}
+		if !reflect.DeepEqual(userIDs(got), step.want) {
+			t.Fatalf("step %d: got %v, want %v", i, userIDs(got), step.want)
+		}
+	}
+}
+
+func TestWeightedSelector_RespectsWeights(t *testing.T) {
+	ctx := context.Background()
+	selector := NewWeightedSelector(rand.New(rand.NewSource(7)), map[string]int{
+		"heavy":    9,
+		"disabled": 0,
+	})
+	candidates := selectorCandidates("heavy", "light", "disabled")
+
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		got, err := selector.Select(ctx, "team-1", candidates, 1)
+		if err != nil {
+			t.Fatalf("Select() unexpected error: %v", err)
+		}
+		counts[got[0].ID]++
+	}
+
+	if counts["disabled"] != 0 {
+		t.Fatalf("user with zero weight was picked %d times", counts["disabled"])
+	}
+	// ожидаемая доля heavy - 90%
+	if counts["heavy"] < 850 || counts["heavy"] > 950 {
+		t.Fatalf("expected heavy to be picked ~900 times, got %v", counts)
+	}
+}
+
+func TestWeightedSelector_NoDuplicates(t *testing.T) {
+	selector := NewWeightedSelector(rand.New(rand.NewSource(3)), map[string]int{"u1": 100})
+
+	got, err := selector.Select(context.Background(), "team-1", selectorCandidates("u1", "u2", "u3"), 3)
+	if err != nil {
+		t.Fatalf("Select() unexpected error: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, u := range got {
+		if seen[u.ID] {
+			t.Fatalf("duplicate reviewer %s in %v", u.ID, userIDs(got))
+		}
+		seen[u.ID] = true
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 reviewers, got %v", userIDs(got))
+	}
+}
+
+func TestNewReviewerSelector_TeamOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	selector, err := NewReviewerSelector(ReviewerSelectorConfig{
+		Default: StrategyRandom,
+		Teams:   map[string]string{"backend": StrategyRoundRobin},
+	}, rand.New(rand.NewSource(1)), nil)
+	if err != nil {
+		t.Fatalf("NewReviewerSelector() unexpected error: %v", err)
+	}
+
+	candidates := selectorCandidates("u2", "u1")
+	first, _ := selector.Select(ctx, "backend", candidates, 1)
+	second, _ := selector.Select(ctx, "backend", candidates, 1)
+	if got := append(userIDs(first), userIDs(second)...); !reflect.DeepEqual(got, []string{"u1", "u2"}) {
+		t.Fatalf("expected round robin for backend, got %v", got)
+	}
+
+	other, err := selector.Select(ctx, "payments", candidates, 2)
+	if err != nil {
+		t.Fatalf("Select() unexpected error: %v", err)
+	}
+	if len(other) != 2 {
+		t.Fatalf("expected default strategy to pick 2 reviewers, got %v", userIDs(other))
+	}
+
+	if got := selectorStrategy(selector, "backend"); got != StrategyRoundRobin {
+		t.Fatalf("expected backend strategy %s, got %s", StrategyRoundRobin, got)
+	}
+	if got := selectorStrategy(selector, "payments"); got != StrategyRandom {
+		t.Fatalf("expected default strategy %s, got %s", StrategyRandom, got)
+	}
+}
+
+func TestNewReviewerSelector_UnknownStrategy(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ReviewerSelectorConfig
+	}{
+		{name: "default", cfg: ReviewerSelectorConfig{Default: "fastest"}},
+		{name: "team", cfg: ReviewerSelectorConfig{Teams: map[string]string{"backend": "fastest"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReviewerSelector(tt.cfg, nil, nil)
+			if !errors.Is(err, ErrUnknownReviewerStrategy) {
+				t.Fatalf("expected ErrUnknownReviewerStrategy, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLeastLoadedSelector_PicksFewestOpenReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	counter := NewMockRequestOwnerRepository(ctrl)
+	counter.EXPECT().
+		GetOpenReviewCounts(ctx).
+		Return(map[string]int{"busy": 5, "medium": 2, "idle": 1}, nil)
+
+	selector := NewLeastLoadedSelector(rand.New(rand.NewSource(1)), counter)
+
+	// у "fresh" нет открытых ревью, он должен быть выбран первым
+	got, err := selector.Select(ctx, "team-1", selectorCandidates("busy", "medium", "fresh", "idle"), 2)
+	if err != nil {
+		t.Fatalf("Select() unexpected error: %v", err)
+	}
+	if want := []string{"fresh", "idle"}; !reflect.DeepEqual(userIDs(got), want) {
+		t.Fatalf("got %v, want %v", userIDs(got), want)
+	}
+}
+
+func TestLeastLoadedSelector_RandomTieBreak(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	counter := NewMockRequestOwnerRepository(ctrl)
+	counter.EXPECT().
+		GetOpenReviewCounts(ctx).
+		Return(map[string]int{"busy": 3}, nil).
+		AnyTimes()
+
+	candidates := selectorCandidates("a", "b", "c", "busy")
+
+	first := NewLeastLoadedSelector(rand.New(rand.NewSource(11)), counter)
+	second := NewLeastLoadedSelector(rand.New(rand.NewSource(11)), counter)
+
+	picked := map[string]int{}
+	for i := 0; i < 60; i++ {
+		got1, err := first.Select(ctx, "team-1", candidates, 1)
+		if err != nil {
+			t.Fatalf("Select() unexpected error: %v", err)
+		}
+		got2, _ := second.Select(ctx, "team-1", candidates, 1)
+		if got1[0].ID != got2[0].ID {
+			t.Fatalf("round %d: same seed gave different picks %s and %s", i, got1[0].ID, got2[0].ID)
+		}
+		picked[got1[0].ID]++
+	}
+
+	if picked["busy"] != 0 {
+		t.Fatalf("loaded reviewer was picked over idle ones: %v", picked)
+	}
+	if len(picked) != 3 {
+		t.Fatalf("expected ties to be broken between all idle reviewers, got %v", picked)
+	}
+}
+
+func TestLeastLoadedSelector_CounterError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	counter := NewMockRequestOwnerRepository(ctrl)
+	counter.EXPECT().
+		GetOpenReviewCounts(ctx).
+		Return(nil, errors.New("db error"))
+
+	selector := NewLeastLoadedSelector(rand.New(rand.NewSource(1)), counter)
+
+	got, err := selector.Select(ctx, "team-1", selectorCandidates("u1"), 1)
+	if err == nil {
+		t.Fatalf("expected counter error, got %v", userIDs(got))
+	}
+}
+
+func TestNewReviewerSelector_LeastLoadedRequiresCounter(t *testing.T) {
+	_, err := NewReviewerSelector(ReviewerSelectorConfig{Default: StrategyLeastLoaded}, nil, nil)
+	if err == nil {
+		t.Fatalf("expected error for least_loaded without counter")
+	}
+}
+
+// plainSelector - селектор без объяснения выбора, берет первых n кандидатов.
+type plainSelector struct{}
+
+func (plainSelector) Select(_ context.Context, _ string, candidates []domain.User, n int) ([]domain.User, error) {
+	return candidates[:min(n, len(candidates))], nil
+}
+
+func TestSelectReviewers_Trace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	counter := NewMockRequestOwnerRepository(ctrl)
+	counter.EXPECT().GetOpenReviewCounts(gomock.Any()).Return(map[string]int{"u1": 3, "u3": 1}, nil)
+
+	tests := []struct {
+		name       string
+		selector   ReviewerSelector
+		candidates []domain.User
+		n          int
+		want       []string
+		// check - проверка решений, зависящих от rng
+		check         func(t *testing.T, decisions []domain.CandidateDecision)
+		wantDecisions []domain.CandidateDecision
+	}{
+		{
+			name:       "round robin draws queue positions",
+			selector:   NewRoundRobinSelector(),
+			candidates: selectorCandidates("u3", "u1", "u2"),
+			n:          2,
+			want:       []string{"u1", "u2"},
+			wantDecisions: []domain.CandidateDecision{
+				{UserID: "u3", Eligible: true, Draw: intPtr(2)},
+				{UserID: "u1", Eligible: true, Selected: true, Draw: intPtr(0)},
+				{UserID: "u2", Eligible: true, Selected: true, Draw: intPtr(1)},
+			},
+		},
+		{
+			name:       "weighted excludes zero weight as over capacity",
+			selector:   NewWeightedSelector(rand.New(rand.NewSource(1)), map[string]int{"u1": 0, "u2": 5}),
+			candidates: selectorCandidates("u1", "u2"),
+			n:          2,
+			want:       []string{"u2"},
+			check: func(t *testing.T, decisions []domain.CandidateDecision) {
+				over, picked := decisions[0], decisions[1]
+				if over.Eligible || over.ExcludedReason != domain.CandidateExcludedOverCapacity || over.Score == nil || *over.Score != 0 || over.Draw != nil {
+					t.Fatalf("unexpected over capacity decision: %#v", over)
+				}
+				if !picked.Selected || picked.Score == nil || *picked.Score != 5 || picked.Draw == nil || *picked.Draw < 0 || *picked.Draw >= 5 {
+					t.Fatalf("unexpected picked decision: %#v", picked)
+				}
+			},
+		},
+		{
+			name:       "least loaded scores open reviews",
+			selector:   NewLeastLoadedSelector(rand.New(rand.NewSource(1)), counter),
+			candidates: selectorCandidates("u1", "u2", "u3"),
+			n:          1,
+			want:       []string{"u2"},
+			check: func(t *testing.T, decisions []domain.CandidateDecision) {
+				for i, want := range []int{3, 0, 1} {
+					if d := decisions[i]; d.Score == nil || *d.Score != want || d.Draw == nil || d.Selected != (d.UserID == "u2") {
+						t.Fatalf("unexpected decision: %#v", d)
+					}
+				}
+			},
+		},
+		{
+			name:       "selector without trace",
+			selector:   plainSelector{},
+			candidates: selectorCandidates("u1", "u2"),
+			n:          1,
+			want:       []string{"u1"},
+			wantDecisions: []domain.CandidateDecision{
+				{UserID: "u1", Eligible: true, Selected: true},
+				{UserID: "u2", Eligible: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got, decisions, err := selectReviewers(context.Background(), tt.selector, "team-1", tt.candidates, tt.n)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("selectReviewers() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(userIDs(got), tt.want) {
+				t.Fatalf("got %v, want %v", userIDs(got), tt.want)
+			}
+			if tt.check != nil {
+				tt.check(t, decisions)
+			} else if !reflect.DeepEqual(decisions, tt.wantDecisions) {
+				t.Fatalf("got decisions %#v, want %#v", decisions, tt.wantDecisions)
+			}
+		})
+	}
+}
+
+func TestRandomSelector_TraceMatchesSelect(t *testing.T) {
+	ctx := context.Background()
+	candidates := selectorCandidates("u1", "u2", "u3", "u4")
+
+	plain := NewRandomSelector(rand.New(rand.NewSource(7)))
+	traced := NewRandomSelector(rand.New(rand.NewSource(7)))
+
+	want, err := plain.Select(ctx, "team-1", candidates, 2)
+	if err != nil {
+		t.Fatalf("Select() unexpected error: %v", err)
+	}
+	got, decisions, err := traced.SelectTraced(ctx, "team-1", candidates, 2)
+	if err != nil {
+		t.Fatalf("SelectTraced() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", userIDs(got), userIDs(want))
+	}
+	// выбираются кандидаты с первыми позициями после перемешивания
+	for _, d := range decisions {
+		if d.Draw == nil {
+			t.Fatalf("expected draw for %s", d.UserID)
+		}
+		if picked := *d.Draw < 2; picked != (d.UserID == got[0].ID || d.UserID == got[1].ID) {
+			t.Fatalf("draw %d of %s does not match selection %v", *d.Draw, d.UserID, userIDs(got))
+		}
+	}
+}
+
+func TestSelectors_PreviewHasNoSideEffects(t *testing.T) {
+	ctx := context.Background()
+	candidates := selectorCandidates("u1", "u2", "u3", "u4")
+
+	t.Run("round robin cursor", func(t *testing.T) {
+		selector := NewRoundRobinSelector()
+		if _, err := selector.Select(ctx, "team-1", candidates, 1); err != nil {
+			t.Fatalf("Select() unexpected error: %v", err)
+		}
+
+		preview, _, err := selector.PreviewSelect(ctx, "team-1", candidates, 2)
+		if err != nil {
+			t.Fatalf("PreviewSelect() unexpected error: %v", err)
+		}
+		got, err := selector.Select(ctx, "team-1", candidates, 2)
+		if err != nil {
+			t.Fatalf("Select() unexpected error: %v", err)
+		}
+		if want := []string{"u2", "u3"}; !reflect.DeepEqual(userIDs(preview), want) || !reflect.DeepEqual(userIDs(got), want) {
+			t.Fatalf("expected preview and select %v, got %v and %v", want, userIDs(preview), userIDs(got))
+		}
+	})
+
+	// превью случайных стратегий не расходует общий rng: выбор совпадает с селектором без превью
+	randomStrategies := []struct {
+		name  string
+		build func(rng *rand.Rand) ReviewerSelector
+	}{
+		{name: StrategyRandom, build: func(rng *rand.Rand) ReviewerSelector { return NewRandomSelector(rng) }},
+		{name: StrategyWeighted, build: func(rng *rand.Rand) ReviewerSelector { return NewWeightedSelector(rng, map[string]int{"u1": 3}) }},
+	}
+	for _, tt := range randomStrategies {
+		t.Run(tt.name+" rng", func(t *testing.T) {
+			withPreview := tt.build(rand.New(rand.NewSource(3)))
+			plain := tt.build(rand.New(rand.NewSource(3)))
+
+			for i := 0; i < 5; i++ {
+				if _, _, err := withPreview.(PreviewSelector).PreviewSelect(ctx, "team-1", candidates, 2); err != nil {
+					t.Fatalf("PreviewSelect() unexpected error: %v", err)
+				}
+				got, err := withPreview.Select(ctx, "team-1", candidates, 2)
+				if err != nil {
+					t.Fatalf("Select() unexpected error: %v", err)
+				}
+				want, err := plain.Select(ctx, "team-1", candidates, 2)
+				if err != nil {
+					t.Fatalf("Select() unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("iteration %d: got %v, want %v", i, userIDs(got), userIDs(want))
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewReviewers_SelectorWithoutPreview(t *testing.T) {
+	got, decisions, err := previewReviewers(context.Background(), plainSelector{}, "team-1", selectorCandidates("u1", "u2"), 1)
+	if err != nil {
+		t.Fatalf("previewReviewers() unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no proposal, got %v", userIDs(got))
+	}
+	want := []domain.CandidateDecision{{UserID: "u1", Eligible: true}, {UserID: "u2", Eligible: true}}
+	if !reflect.DeepEqual(decisions, want) {
+		t.Fatalf("got decisions %#v, want %#v", decisions, want)
+	}
+}
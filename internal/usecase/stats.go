@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type Stats struct {
+	statsRepository StatsRepository
+	teamRepository  TeamRepository
+}
+
+func NewStats(statsRepository StatsRepository, teamRepository TeamRepository) Stats {
+	return Stats{
+		statsRepository: statsRepository,
+		teamRepository:  teamRepository,
+	}
+}
+
+// GetStats собирает статистику назначений по PR, попавшим под фильтр.
+func (s *Stats) GetStats(ctx context.Context, filter domain.StatsFilter) (_ *domain.Stats, err error) {
+	ctx, span := startSpan(ctx, "Stats.GetStats", attribute.String("team.name", filter.TeamName))
+	defer endSpan(span, &err)
+
+	if s.statsRepository == nil {
+		return nil, ErrStatsRepositoryNotFound
+	}
+	if s.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, ErrInvalidStatsRange
+	}
+
+	if filter.TeamName != "" {
+		team, err := s.teamRepository.GetTeamByName(ctx, filter.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, ErrTeamNotFound
+		}
+	}
+
+	users, err := s.statsRepository.GetUserAssignmentStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	pullRequests, err := s.statsRepository.GetPullRequestReviewerStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.statsRepository.GetTeamStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	mergeTime, err := s.statsRepository.GetMergeTimeStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Stats{
+		Users:        users,
+		PullRequests: pullRequests,
+		Teams:        teams,
+		MergeTime:    *mergeTime,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestStats_GetStats(t *testing.T) {
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	users := []domain.UserAssignmentStats{{UserID: "user-1", Open: 1, Merged: 2}}
+	pullRequests := []domain.PullRequestReviewerStats{{PullRequestID: "pr-1", Status: domain.RequestStatusOpen, Reviewers: 2}}
+	teams := []domain.TeamStats{{TeamName: "team-1", OpenPullRequests: 1, MergedPullRequests: 2, Assignments: 4}}
+	mergeTime := &domain.MergeTimeStats{MergedPullRequests: 2, Median: time.Hour}
+
+	expectAll := func(ctx context.Context, statsRepo *MockStatsRepository, filter domain.StatsFilter) {
+		statsRepo.EXPECT().GetUserAssignmentStats(ctx, filter).Return(users, nil)
+		statsRepo.EXPECT().GetPullRequestReviewerStats(ctx, filter).Return(pullRequests, nil)
+		statsRepo.EXPECT().GetTeamStats(ctx, filter).Return(teams, nil)
+		statsRepo.EXPECT().GetMergeTimeStats(ctx, filter).Return(mergeTime, nil)
+	}
+	full := &domain.Stats{Users: users, PullRequests: pullRequests, Teams: teams, MergeTime: *mergeTime}
+
+	tests := []struct {
+		name    string
+		filter  domain.StatsFilter
+		mock    func(ctx context.Context, statsRepo *MockStatsRepository, teamRepo *MockTeamRepository, filter domain.StatsFilter)
+		want    *domain.Stats
+		wantErr error
+	}{
+		{
+			name:   "without filter",
+			filter: domain.StatsFilter{},
+			mock: func(ctx context.Context, statsRepo *MockStatsRepository, _ *MockTeamRepository, filter domain.StatsFilter) {
+				expectAll(ctx, statsRepo, filter)
+			},
+			want:    full,
+			wantErr: nil,
+		},
+		{
+			name:   "team and range",
+			filter: domain.StatsFilter{TeamName: "team-1", From: from, To: to},
+			mock: func(ctx context.Context, statsRepo *MockStatsRepository, teamRepo *MockTeamRepository, filter domain.StatsFilter) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(&domain.Team{Name: "team-1"}, nil)
+				expectAll(ctx, statsRepo, filter)
+			},
+			want:    full,
+			wantErr: nil,
+		},
+		{
+			name:    "empty range",
+			filter:  domain.StatsFilter{From: to, To: from},
+			mock:    func(context.Context, *MockStatsRepository, *MockTeamRepository, domain.StatsFilter) {},
+			want:    nil,
+			wantErr: ErrInvalidStatsRange,
+		},
+		{
+			name:   "team not found",
+			filter: domain.StatsFilter{TeamName: "missing"},
+			mock: func(ctx context.Context, _ *MockStatsRepository, teamRepo *MockTeamRepository, _ domain.StatsFilter) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "missing").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockStatsRepo := NewMockStatsRepository(ctrl)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			tt.mock(ctx, mockStatsRepo, mockTeamRepo, tt.filter)
+
+			uc := NewStats(mockStatsRepo, mockTeamRepo)
+
+			// Act
+			got, err := uc.GetStats(ctx, tt.filter)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStats_GetStats_RepositoryError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	errDB := errors.New("db error")
+	mockStatsRepo := NewMockStatsRepository(ctrl)
+	mockStatsRepo.EXPECT().GetUserAssignmentStats(ctx, domain.StatsFilter{}).Return([]domain.UserAssignmentStats{}, nil)
+	mockStatsRepo.EXPECT().GetPullRequestReviewerStats(ctx, domain.StatsFilter{}).Return(nil, errDB)
+
+	uc := NewStats(mockStatsRepo, NewMockTeamRepository(ctrl))
+
+	// Act
+	got, err := uc.GetStats(ctx, domain.StatsFilter{})
+
+	// Assert
+	if got != nil || !errors.Is(err, errDB) {
+		t.Fatalf("expected db error, got %#v, %v", got, err)
+	}
+}
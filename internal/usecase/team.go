@@ -4,34 +4,71 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Team struct {
-	teamRepository TeamRepository
-	userRepository UserRepository
+	teamRepository         TeamRepository
+	userRepository         UserRepository
+	requestOwnerRepository RequestOwnerRepository
+	transactor             Transactor
+	metrics                Metrics
 }
 
-func NewTeam(teamRepository TeamRepository, userRepository UserRepository) Team {
+func NewTeam(teamRepository TeamRepository, userRepository UserRepository, requestOwnerRepository RequestOwnerRepository, transactor Transactor, metrics Metrics) Team {
 	return Team{
-		teamRepository: teamRepository,
-		userRepository: userRepository,
+		teamRepository:         teamRepository,
+		userRepository:         userRepository,
+		requestOwnerRepository: requestOwnerRepository,
+		transactor:             transactor,
+		metrics:                metricsOrNop(metrics),
 	}
 }
 
-func (t *Team) CreateTeam(ctx context.Context, team *domain.Team, members []domain.User) (*domain.Team, error) {
+func (t *Team) CreateTeam(ctx context.Context, team *domain.Team, members []domain.User) (_ *domain.Team, err error) {
+	ctx, span := startSpan(ctx, "Team.CreateTeam")
+	defer endSpan(span, &err)
+
 	if team == nil || team.Name == "" {
 		return nil, ErrInvalidTeamName
 	}
+	span.SetAttributes(attribute.String("team.name", team.Name), attribute.Int("team.members", len(members)))
 	if len(members) == 0 {
 		return nil, ErrMemberNotFound
 	}
+	for userID, role := range team.Roles {
+		if role != domain.TeamRoleLead && role != domain.TeamRoleMember {
+			return nil, fmt.Errorf("%w: %q for %s", ErrInvalidTeamRole, role, userID)
+		}
+	}
 	if t.teamRepository == nil {
 		return nil, ErrTeamRepositoryNotFound
 	}
 	if t.userRepository == nil {
 		return nil, ErrUserRepositoryNotFound
 	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
 
+	var created *domain.Team
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = t.createTeam(ctx, team, members)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (t *Team) createTeam(ctx context.Context, team *domain.Team, members []domain.User) (*domain.Team, error) {
 	existing, err := t.teamRepository.GetTeamByName(ctx, team.Name)
 	if err != nil && !errors.Is(err, ErrTeamNotFound) {
 		return nil, err
@@ -45,28 +82,9 @@ func (t *Team) CreateTeam(ctx context.Context, team *domain.Team, members []doma
 	}
 
 	for _, member := range members {
-		user, err := t.userRepository.GetUserByID(ctx, member.ID)
+		user, err := t.upsertMember(ctx, member)
 		if err != nil {
-			if errors.Is(err, ErrMemberNotFound) {
-
-				user = &domain.User{
-					ID:       member.ID,
-					Username: member.Username,
-					IsActive: member.IsActive,
-				}
-				if err := t.userRepository.SaveUser(ctx, user); err != nil {
-					return nil, err
-				}
-			} else {
-				return nil, err
-			}
-		} else {
-			if user.IsActive != member.IsActive {
-				user.IsActive = member.IsActive
-				if err := t.userRepository.UpdateUser(ctx, user); err != nil {
-					return nil, err
-				}
-			}
+			return nil, err
 		}
 
 		if err := t.teamRepository.LinkUserToTeam(ctx, team, user); err != nil {
@@ -82,7 +100,38 @@ func (t *Team) CreateTeam(ctx context.Context, team *domain.Team, members []doma
 	return team, nil
 }
 
-func (t *Team) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+// upsertMember создает пользователя, если его нет, или обновляет у существующего флаг активности.
+func (t *Team) upsertMember(ctx context.Context, member domain.User) (*domain.User, error) {
+	user, err := t.userRepository.GetUserByID(ctx, member.ID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+
+			user = &domain.User{
+				ID:       member.ID,
+				Username: member.Username,
+				IsActive: member.IsActive,
+			}
+			if err := t.userRepository.SaveUser(ctx, user); err != nil {
+				return nil, err
+			}
+		} else {
+			return nil, err
+		}
+	} else {
+		if user.IsActive != member.IsActive {
+			user.IsActive = member.IsActive
+			if err := t.userRepository.UpdateUser(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return user, nil
+}
+
+func (t *Team) GetTeam(ctx context.Context, teamName string) (_ *domain.Team, err error) {
+	ctx, span := startSpan(ctx, "Team.GetTeam", attribute.String("team.name", teamName))
+	defer endSpan(span, &err)
+
 	if t.teamRepository == nil {
 		return nil, ErrInvalidTeamName
 	} else if t.userRepository == nil {
@@ -99,3 +148,655 @@ func (t *Team) GetTeam(ctx context.Context, teamName string) (*domain.Team, erro
 	}
 	return team, nil
 }
+
+// DeactivateMembers деактивирует участников команды (всех, если userIDs пуст) и передает их слоты
+// ревьюверов в OPEN PR оставшимся активным участникам команды. Все выполняется в одной транзакции.
+func (t *Team) DeactivateMembers(ctx context.Context, teamName string, userIDs []string) (_ *domain.TeamDeactivation, err error) {
+	ctx, span := startSpan(ctx, "Team.DeactivateMembers", attribute.String("team.name", teamName), attribute.Int("team.members", len(userIDs)))
+	defer endSpan(span, &err)
+
+	if teamName == "" {
+		return nil, ErrInvalidTeamName
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	}
+	if t.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var result *domain.TeamDeactivation
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = t.deactivateMembers(ctx, teamName, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.metrics.ReviewersAssigned(OperationDeactivate, len(result.Reassigned))
+	t.metrics.Reassigned(OperationDeactivate, len(result.Reassigned))
+	t.metrics.NoCandidate(OperationDeactivate, len(result.Unfilled))
+	return result, nil
+}
+
+func (t *Team) deactivateMembers(ctx context.Context, teamName string, userIDs []string) (*domain.TeamDeactivation, error) {
+	team, err := t.teamRepository.GetTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+	if err := requireTeamLead(ctx, team); err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]bool, len(team.Members))
+	for _, m := range team.Members {
+		members[m.ID] = true
+	}
+	for _, id := range userIDs {
+		if !members[id] {
+			return nil, fmt.Errorf("%w: %s is not in team %s", ErrMemberNotFound, id, teamName)
+		}
+	}
+
+	result := &domain.TeamDeactivation{
+		TeamName:           teamName,
+		DeactivatedUserIDs: []string{},
+		Reassigned:         []domain.ReviewerReplacement{},
+		Unfilled:           []domain.ReviewerReplacement{},
+	}
+
+	deactivated, err := t.userRepository.DeactivateTeamMembers(ctx, teamName, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(deactivated) == 0 {
+		return result, nil
+	}
+	result.DeactivatedUserIDs = deactivated
+
+	replacements, err := t.requestOwnerRepository.ReplaceTeamReviewers(ctx, teamName, deactivated)
+	if err != nil {
+		return nil, err
+	}
+	if err := saveAssignmentEvents(ctx, t.requestOwnerRepository, replacementEvents(ctx, replacements, domain.AssignmentReasonDeactivate)); err != nil {
+		return nil, err
+	}
+	result.Reassigned, result.Unfilled = splitReplacements(replacements)
+	return result, nil
+}
+
+// AddMember добавляет пользователя в существующую команду с ролью role (member, если пусто).
+// Пользователь создается или обновляется так же, как в CreateTeam.
+func (t *Team) AddMember(ctx context.Context, teamName string, member domain.User, role domain.TeamRole) (_ *domain.Team, err error) {
+	ctx, span := startSpan(ctx, "Team.AddMember", attribute.String("team.name", teamName), attribute.String("user.id", member.ID))
+	defer endSpan(span, &err)
+
+	if teamName == "" {
+		return nil, ErrInvalidTeamName
+	}
+	if member.ID == "" {
+		return nil, ErrMemberNotFound
+	}
+	if role == "" {
+		role = domain.TeamRoleMember
+	}
+	if role != domain.TeamRoleLead && role != domain.TeamRoleMember {
+		return nil, fmt.Errorf("%w: %q for %s", ErrInvalidTeamRole, role, member.ID)
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var updated *domain.Team
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := t.teamRepository.GetTeamByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return ErrTeamNotFound
+		}
+		if err := requireTeamLead(ctx, team); err != nil {
+			return err
+		}
+		if team.HasMember(member.ID) {
+			return fmt.Errorf("%w: %s is already in team %s", ErrMemberAlreadyInTeam, member.ID, teamName)
+		}
+
+		user, err := t.upsertMember(ctx, member)
+		if err != nil {
+			return err
+		}
+		link := &domain.Team{Name: teamName, Roles: map[string]domain.TeamRole{user.ID: role}}
+		if err := t.teamRepository.LinkUserToTeam(ctx, link, user); err != nil {
+			return err
+		}
+
+		updated, err = t.teamRepository.GetTeamByName(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RemoveMember исключает участника из команды и передает его слоты ревьюверов в OPEN PR авторов команды
+// оставшимся активным участникам. Все выполняется в одной транзакции.
+func (t *Team) RemoveMember(ctx context.Context, teamName, userID string) (_ *domain.TeamMemberChange, err error) {
+	ctx, span := startSpan(ctx, "Team.RemoveMember", attribute.String("team.name", teamName), attribute.String("user.id", userID))
+	defer endSpan(span, &err)
+
+	if teamName == "" {
+		return nil, ErrInvalidTeamName
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var result *domain.TeamMemberChange
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = t.leaveTeam(ctx, teamName, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.recordLeave(result)
+	return result, nil
+}
+
+// MoveMember переводит участника из команды fromTeam в toTeam с ролью role (member, если пусто).
+// Слоты ревьюверов в OPEN PR команды fromTeam передаются ее оставшимся участникам, как в RemoveMember.
+// Нужны права лида в обеих командах.
+func (t *Team) MoveMember(ctx context.Context, fromTeam, toTeam, userID string, role domain.TeamRole) (_ *domain.TeamMemberChange, err error) {
+	ctx, span := startSpan(ctx, "Team.MoveMember",
+		attribute.String("team.name", fromTeam), attribute.String("team.to", toTeam), attribute.String("user.id", userID))
+	defer endSpan(span, &err)
+
+	if fromTeam == "" || toTeam == "" {
+		return nil, ErrInvalidTeamName
+	}
+	if role == "" {
+		role = domain.TeamRoleMember
+	}
+	if role != domain.TeamRoleLead && role != domain.TeamRoleMember {
+		return nil, fmt.Errorf("%w: %q for %s", ErrInvalidTeamRole, role, userID)
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var result *domain.TeamMemberChange
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		target, err := t.teamRepository.GetTeamByName(ctx, toTeam)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return ErrTeamNotFound
+		}
+		if err := requireTeamLead(ctx, target); err != nil {
+			return err
+		}
+		if target.HasMember(userID) {
+			return fmt.Errorf("%w: %s is already in team %s", ErrMemberAlreadyInTeam, userID, toTeam)
+		}
+
+		result, err = t.leaveTeam(ctx, fromTeam, userID)
+		if err != nil {
+			return err
+		}
+		link := &domain.Team{Name: toTeam, Roles: map[string]domain.TeamRole{userID: role}}
+		if err := t.teamRepository.LinkUserToTeam(ctx, link, &domain.User{ID: userID}); err != nil {
+			return err
+		}
+		result.ToTeam = toTeam
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.recordLeave(result)
+	return result, nil
+}
+
+// DeleteTeam удаляет команду и исключает из нее всех участников. Пользователи сохраняются, а их назначения
+// ревьюверами в OPEN PR снимаются в той же транзакции до исключения. Возвращает id исключенных участников.
+func (t *Team) DeleteTeam(ctx context.Context, teamName string) (_ []string, err error) {
+	ctx, span := startSpan(ctx, "Team.DeleteTeam", attribute.String("team.name", teamName))
+	defer endSpan(span, &err)
+
+	if teamName == "" {
+		return nil, ErrInvalidTeamName
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := t.teamRepository.GetTeamByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return ErrTeamNotFound
+		}
+
+		memberIDs := make([]string, 0, len(team.Members))
+		for _, m := range team.Members {
+			memberIDs = append(memberIDs, m.ID)
+		}
+		if len(memberIDs) > 0 {
+			replacements, err := t.requestOwnerRepository.ReplaceTeamReviewers(ctx, teamName, memberIDs)
+			if err != nil {
+				return err
+			}
+			if err := saveAssignmentEvents(ctx, t.requestOwnerRepository, replacementEvents(ctx, replacements, domain.AssignmentReasonDeleteTeam)); err != nil {
+				return err
+			}
+		}
+
+		removed, err = t.teamRepository.DeleteTeam(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// SyncTeam приводит состав команды к members из внешнего источника: добавляет и исключает участников,
+// обновляет имена и флаги активности. Команды без записи создаются. Роли из team.Roles применяются только
+// к добавляемым участникам. Ревью исключенных и деактивированных участников передаются оставшимся
+// активным участникам. Все выполняется в одной транзакции, при dryRun изменения не применяются.
+func (t *Team) SyncTeam(ctx context.Context, team *domain.Team, members []domain.User, dryRun bool) (_ *domain.TeamSync, err error) {
+	ctx, span := startSpan(ctx, "Team.SyncTeam", attribute.Int("team.members", len(members)), attribute.Bool("team.dry_run", dryRun))
+	defer endSpan(span, &err)
+
+	if team == nil || team.Name == "" {
+		return nil, ErrInvalidTeamName
+	}
+	span.SetAttributes(attribute.String("team.name", team.Name))
+	// пустой состав из внешнего источника скорее ошибка выгрузки, чем расформирование команды
+	if len(members) == 0 {
+		return nil, ErrMemberNotFound
+	}
+	seen := make(map[string]bool, len(members))
+	for _, m := range members {
+		if m.ID == "" {
+			return nil, ErrMemberNotFound
+		}
+		if seen[m.ID] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateMember, m.ID)
+		}
+		seen[m.ID] = true
+	}
+	for userID, role := range team.Roles {
+		if role != domain.TeamRoleLead && role != domain.TeamRoleMember {
+			return nil, fmt.Errorf("%w: %q for %s", ErrInvalidTeamRole, role, userID)
+		}
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	}
+	if t.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var result *domain.TeamSync
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		plan, err := t.planSync(ctx, team, members)
+		if err != nil {
+			return err
+		}
+		result = plan.result
+		result.DryRun = dryRun
+		if dryRun {
+			return nil
+		}
+		return t.applySync(ctx, team, members, plan)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		t.metrics.ReviewersAssigned(OperationSyncTeam, len(result.Reassigned))
+		t.metrics.Reassigned(OperationSyncTeam, len(result.Reassigned))
+		t.metrics.NoCandidate(OperationSyncTeam, len(result.Unfilled))
+	}
+	return result, nil
+}
+
+// syncPlan - рассчитанная разница составов и текущие данные пользователей, нужные для ее применения.
+type syncPlan struct {
+	result *domain.TeamSync
+	// known - пользователи из members, которые уже есть в БД, по id
+	known map[string]domain.User
+}
+
+func (t *Team) planSync(ctx context.Context, team *domain.Team, members []domain.User) (*syncPlan, error) {
+	stored, err := t.teamRepository.GetTeamByName(ctx, team.Name)
+	if err != nil && !errors.Is(err, ErrTeamNotFound) {
+		return nil, err
+	}
+
+	plan := &syncPlan{
+		result: &domain.TeamSync{
+			TeamName:        team.Name,
+			Added:           []string{},
+			Removed:         []string{},
+			Renamed:         []domain.UsernameChange{},
+			ActivityChanged: []domain.ActivityChange{},
+			Reassigned:      []domain.ReviewerReplacement{},
+			Unfilled:        []domain.ReviewerReplacement{},
+		},
+		known: make(map[string]domain.User, len(members)),
+	}
+	if stored == nil {
+		if err := requireAdmin(ctx); err != nil {
+			return nil, err
+		}
+		plan.result.Created = true
+		stored = &domain.Team{Name: team.Name}
+	} else if err := requireTeamLead(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]domain.User, len(stored.Members))
+	for _, m := range stored.Members {
+		current[m.ID] = m
+	}
+	posted := make(map[string]bool, len(members))
+	for _, m := range members {
+		posted[m.ID] = true
+		user, ok := current[m.ID]
+		if !ok {
+			plan.result.Added = append(plan.result.Added, m.ID)
+			existing, err := t.userRepository.GetUserByID(ctx, m.ID)
+			if errors.Is(err, ErrMemberNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			user = *existing
+		}
+		plan.known[m.ID] = user
+		if user.Username != m.Username {
+			plan.result.Renamed = append(plan.result.Renamed, domain.UsernameChange{UserID: m.ID, From: user.Username, To: m.Username})
+		}
+		if user.IsActive != m.IsActive {
+			plan.result.ActivityChanged = append(plan.result.ActivityChanged, domain.ActivityChange{UserID: m.ID, IsActive: m.IsActive})
+		}
+	}
+	for _, m := range stored.Members {
+		if !posted[m.ID] {
+			plan.result.Removed = append(plan.result.Removed, m.ID)
+		}
+	}
+	return plan, nil
+}
+
+func (t *Team) applySync(ctx context.Context, team *domain.Team, members []domain.User, plan *syncPlan) error {
+	result := plan.result
+	if result.Created {
+		if err := t.teamRepository.SaveTeam(ctx, team); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range members {
+		user, ok := plan.known[m.ID]
+		desired := &domain.User{ID: m.ID, Username: m.Username, IsActive: m.IsActive}
+		switch {
+		case !ok:
+			if err := t.userRepository.SaveUser(ctx, desired); err != nil {
+				return err
+			}
+		case user.Username != m.Username || user.IsActive != m.IsActive:
+			if err := t.userRepository.UpdateUser(ctx, desired); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range result.Added {
+		if err := t.teamRepository.LinkUserToTeam(ctx, team, &domain.User{ID: id}); err != nil {
+			return err
+		}
+	}
+
+	var replacements []domain.ReviewerReplacement
+	if len(result.Removed) > 0 {
+		// сначала исключаем всех, чтобы исключаемые не стали кандидатами на слоты друг друга
+		for _, id := range result.Removed {
+			if err := t.teamRepository.UnlinkUserFromTeam(ctx, team.Name, id); err != nil {
+				return err
+			}
+		}
+		replaced, err := t.requestOwnerRepository.ReplaceTeamPullRequestReviewers(ctx, team.Name, result.Removed)
+		if err != nil {
+			return err
+		}
+		replacements = append(replacements, replaced...)
+	}
+
+	deactivated := make([]string, 0, len(result.ActivityChanged))
+	for _, c := range result.ActivityChanged {
+		if !c.IsActive {
+			deactivated = append(deactivated, c.UserID)
+		}
+	}
+	if len(deactivated) > 0 {
+		replaced, err := t.requestOwnerRepository.ReplaceTeamReviewers(ctx, team.Name, deactivated)
+		if err != nil {
+			return err
+		}
+		replacements = append(replacements, replaced...)
+	}
+	if err := saveAssignmentEvents(ctx, t.requestOwnerRepository, replacementEvents(ctx, replacements, domain.AssignmentReasonSyncTeam)); err != nil {
+		return err
+	}
+
+	result.Reassigned, result.Unfilled = splitReplacements(replacements)
+	return nil
+}
+
+// maxReviewersLimit - верхняя граница MaxReviewers в настройках команды.
+const maxReviewersLimit = 10
+
+// GetSettings возвращает настройки назначения ревьюверов команды или настройки по умолчанию, если их не меняли.
+func (t *Team) GetSettings(ctx context.Context, teamName string) (_ *domain.TeamSettings, err error) {
+	ctx, span := startSpan(ctx, "Team.GetSettings", attribute.String("team.name", teamName))
+	defer endSpan(span, &err)
+
+	if teamName == "" {
+		return nil, ErrInvalidTeamName
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+
+	team, err := t.teamRepository.GetTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+	settings, err := loadTeamSettings(ctx, t.teamRepository, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpdateSettings сохраняет настройки назначения ревьюверов команды. Уже созданные PR не пересчитываются,
+// а required_approvals и сроки ревью применяются ко всем еще не слитым PR авторов команды.
+func (t *Team) UpdateSettings(ctx context.Context, settings *domain.TeamSettings) (_ *domain.TeamSettings, err error) {
+	ctx, span := startSpan(ctx, "Team.UpdateSettings")
+	defer endSpan(span, &err)
+
+	if settings == nil || settings.TeamName == "" {
+		return nil, ErrInvalidTeamName
+	}
+	span.SetAttributes(attribute.String("team.name", settings.TeamName))
+	if settings.MinReviewers < 0 || settings.MaxReviewers < settings.MinReviewers || settings.MaxReviewers > maxReviewersLimit {
+		return nil, fmt.Errorf("%w: expected 0 <= min_reviewers <= max_reviewers <= %d, got %d and %d",
+			ErrInvalidTeamSettings, maxReviewersLimit, settings.MinReviewers, settings.MaxReviewers)
+	}
+	if settings.RequiredApprovals < 0 || settings.RequiredApprovals > settings.MaxReviewers {
+		return nil, fmt.Errorf("%w: expected 0 <= required_approvals <= max_reviewers, got %d and %d",
+			ErrInvalidTeamSettings, settings.RequiredApprovals, settings.MaxReviewers)
+	}
+	if settings.ReviewSLAHours < 0 || settings.ReassignAfterHours < 0 ||
+		(settings.ReassignAfterHours > 0 && settings.ReassignAfterHours <= settings.ReviewSLAHours) {
+		return nil, fmt.Errorf("%w: expected review_sla_hours >= 0 and reassign_after_hours 0 or greater than review_sla_hours, got %d and %d",
+			ErrInvalidTeamSettings, settings.ReviewSLAHours, settings.ReassignAfterHours)
+	}
+	if t.teamRepository == nil {
+		return nil, ErrTeamRepositoryNotFound
+	}
+	if t.transactor == nil {
+		return nil, ErrTransactorNotFound
+	}
+
+	var saved *domain.TeamSettings
+	err = t.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err := t.teamRepository.GetTeamByName(ctx, settings.TeamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return ErrTeamNotFound
+		}
+		if err := requireTeamLead(ctx, team); err != nil {
+			return err
+		}
+		if err := t.teamRepository.SaveTeamSettings(ctx, settings); err != nil {
+			return err
+		}
+		saved, err = t.teamRepository.GetTeamSettings(ctx, settings.TeamName)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// loadTeamSettings возвращает сохраненные настройки команды или настройки по умолчанию.
+func loadTeamSettings(ctx context.Context, teamRepository TeamRepository, teamName string) (domain.TeamSettings, error) {
+	if teamName == "" {
+		return domain.DefaultTeamSettings(teamName), nil
+	}
+	settings, err := teamRepository.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return domain.TeamSettings{}, err
+	}
+	if settings == nil {
+		return domain.DefaultTeamSettings(teamName), nil
+	}
+	return *settings, nil
+}
+
+// leaveTeam проверяет права на команду teamName, передает ревью участника в PR команды и исключает его.
+func (t *Team) leaveTeam(ctx context.Context, teamName, userID string) (*domain.TeamMemberChange, error) {
+	team, err := t.teamRepository.GetTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+	if err := requireTeamLead(ctx, team); err != nil {
+		return nil, err
+	}
+	if !team.HasMember(userID) {
+		return nil, fmt.Errorf("%w: %s is not in team %s", ErrMemberNotFound, userID, teamName)
+	}
+
+	// замена до исключения: кандидаты берутся из оставшихся участников, сам участник уже назначен и не подходит
+	replacements, err := t.requestOwnerRepository.ReplaceTeamPullRequestReviewers(ctx, teamName, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	if err := saveAssignmentEvents(ctx, t.requestOwnerRepository, replacementEvents(ctx, replacements, domain.AssignmentReasonLeaveTeam)); err != nil {
+		return nil, err
+	}
+	if err := t.teamRepository.UnlinkUserFromTeam(ctx, teamName, userID); err != nil {
+		return nil, err
+	}
+
+	result := &domain.TeamMemberChange{UserID: userID, FromTeam: teamName}
+	result.Reassigned, result.Unfilled = splitReplacements(replacements)
+	return result, nil
+}
+
+func (t *Team) recordLeave(result *domain.TeamMemberChange) {
+	t.metrics.ReviewersAssigned(OperationLeaveTeam, len(result.Reassigned))
+	t.metrics.Reassigned(OperationLeaveTeam, len(result.Reassigned))
+	t.metrics.NoCandidate(OperationLeaveTeam, len(result.Unfilled))
+}
+
+// splitReplacements делит замены на выполненные и слоты, оставшиеся без кандидата.
+func splitReplacements(replacements []domain.ReviewerReplacement) (reassigned, unfilled []domain.ReviewerReplacement) {
+	reassigned = []domain.ReviewerReplacement{}
+	unfilled = []domain.ReviewerReplacement{}
+	for _, r := range replacements {
+		if r.NewReviewerID == "" {
+			unfilled = append(unfilled, r)
+		} else {
+			reassigned = append(reassigned, r)
+		}
+	}
+	return reassigned, unfilled
+}
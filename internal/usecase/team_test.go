@@ -4,7 +4,9 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 )
@@ -14,14 +16,14 @@ func TestTeam_CreateTeam_TeamNil(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 
-	usecase := NewTeam(mockTeamRepo, mockUserRepo)
+	usecase := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
 
 	// Act
-	err := usecase.CreateTeam(ctx, nil, []domain.User{})
+	_, err := usecase.CreateTeam(ctx, nil, []domain.User{})
 
 	// Assert
 	if !errors.Is(err, ErrInvalidTeamName) {
@@ -34,11 +36,11 @@ func TestTeam_CreateTeam_TeamAlreadyExists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 
-	usecase := NewTeam(mockTeamRepo, mockUserRepo)
+	usecase := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
 
 	team := &domain.Team{Name: "team-1"}
 	members := []domain.User{
@@ -50,7 +52,7 @@ func TestTeam_CreateTeam_TeamAlreadyExists(t *testing.T) {
 		Return(&domain.Team{Name: "team-1"}, nil)
 
 	// Act
-	err := usecase.CreateTeam(ctx, team, members)
+	_, err := usecase.CreateTeam(ctx, team, members)
 
 	// Assert
 	if !errors.Is(err, ErrTeamAlreadyExists) {
@@ -63,31 +65,37 @@ func TestTeam_CreateTeam_SuccessExistingUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 
-	usecase := NewTeam(mockTeamRepo, mockUserRepo)
+	usecase := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
 
 	team := &domain.Team{Name: "team-1"}
 	member := domain.User{ID: "user-1", Username: "u1", IsActive: true}
 
-	mockTeamRepo.EXPECT().
-		GetTeamByName(ctx, team.Name).
-		Return(nil, nil)
-
 	existingUser := &domain.User{ID: "user-1", Username: "u1", IsActive: true}
 
-	mockUserRepo.EXPECT().
-		GetUserByID(ctx, member.ID).
-		Return(existingUser, nil)
-
-	mockTeamRepo.EXPECT().
-		LinkUserToTeam(ctx, team, existingUser).
-		Return(nil)
+	gomock.InOrder(
+		mockTeamRepo.EXPECT().
+			GetTeamByName(ctx, team.Name).
+			Return(nil, nil),
+		mockTeamRepo.EXPECT().
+			SaveTeam(ctx, team).
+			Return(nil),
+		mockUserRepo.EXPECT().
+			GetUserByID(ctx, member.ID).
+			Return(existingUser, nil),
+		mockTeamRepo.EXPECT().
+			LinkUserToTeam(ctx, team, existingUser).
+			Return(nil),
+		mockTeamRepo.EXPECT().
+			GetTeamByName(ctx, team.Name).
+			Return(&domain.Team{Name: team.Name, Members: []domain.User{*existingUser}}, nil),
+	)
 
 	// Act
-	err := usecase.CreateTeam(ctx, team, []domain.User{member})
+	_, err := usecase.CreateTeam(ctx, team, []domain.User{member})
 
 	// Assert
 	if err != nil {
@@ -100,11 +108,11 @@ func TestTeam_GetTeam_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 
-	usecase := NewTeam(mockTeamRepo, mockUserRepo)
+	usecase := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
 
 	expected := &domain.Team{Name: "team-1"}
 
@@ -129,11 +137,11 @@ func TestTeam_GetTeam_ErrorWrappedAsTeamNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 	mockTeamRepo := NewMockTeamRepository(ctrl)
 	mockUserRepo := NewMockUserRepository(ctrl)
 
-	usecase := NewTeam(mockTeamRepo, mockUserRepo)
+	usecase := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
 
 	mockTeamRepo.EXPECT().
 		GetTeamByName(ctx, "team-1").
@@ -150,3 +158,897 @@ func TestTeam_GetTeam_ErrorWrappedAsTeamNotFound(t *testing.T) {
 		t.Fatalf("expected ErrTeamNotFound, got %v", err)
 	}
 }
+
+func TestTeam_CreateTeam_RollbackOnFailure(t *testing.T) {
+	errStep := errors.New("step failed")
+
+	newMember := domain.User{ID: "user-1", Username: "u1", IsActive: true}
+
+	// шаги CreateTeam после проверки существования команды, в порядке выполнения
+	steps := []string{
+		"SaveTeam",
+		"GetUserByID",
+		"SaveUser",
+		"LinkUserToTeam",
+		"GetTeamByName",
+	}
+
+	for failAt, step := range steps {
+		t.Run(step, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+
+			var rolledBack error
+			uc := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newRollbackTransactor(ctrl, &rolledBack), nil)
+
+			team := &domain.Team{Name: "team-1"}
+
+			errAt := func(i int, ok error) error {
+				if i == failAt {
+					return errStep
+				}
+				return ok
+			}
+
+			mockTeamRepo.EXPECT().GetTeamByName(ctx, team.Name).Return(nil, nil)
+
+			calls := []*gomock.Call{
+				mockTeamRepo.EXPECT().SaveTeam(ctx, team).Return(errAt(0, nil)),
+				mockUserRepo.EXPECT().GetUserByID(ctx, newMember.ID).Return(nil, errAt(1, ErrMemberNotFound)),
+				mockUserRepo.EXPECT().SaveUser(ctx, &newMember).Return(errAt(2, nil)),
+				mockTeamRepo.EXPECT().LinkUserToTeam(ctx, team, &newMember).Return(errAt(3, nil)),
+				mockTeamRepo.EXPECT().GetTeamByName(ctx, team.Name).Return(nil, errAt(4, nil)),
+			}
+			for i := failAt + 1; i < len(calls); i++ {
+				calls[i].Times(0)
+			}
+			gomock.InOrder(calls[:failAt+1]...)
+
+			// Act
+			got, err := uc.CreateTeam(ctx, team, []domain.User{newMember})
+
+			// Assert
+			if got != nil {
+				t.Fatalf("expected nil result, got %#v", got)
+			}
+			if !errors.Is(err, errStep) {
+				t.Fatalf("expected step error, got %v", err)
+			}
+			if !errors.Is(rolledBack, errStep) {
+				t.Fatalf("expected transaction to be rolled back with step error, got %v", rolledBack)
+			}
+		})
+	}
+}
+
+func TestTeam_DeactivateMembers(t *testing.T) {
+	team := &domain.Team{Name: "team-1", Members: []domain.User{
+		{ID: "user-1", Username: "u1", IsActive: true},
+		{ID: "user-2", Username: "u2", IsActive: true},
+		{ID: "user-3", Username: "u3", IsActive: true},
+	}}
+
+	tests := []struct {
+		name    string
+		userIDs []string
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository)
+		want    *domain.TeamDeactivation
+		wantErr error
+	}{
+		{
+			name:    "team not found",
+			userIDs: []string{"user-1"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockUserRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: ErrTeamNotFound,
+		},
+		{
+			name:    "user not in team",
+			userIDs: []string{"user-1", "stranger"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockUserRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil)
+			},
+			want:    nil,
+			wantErr: ErrMemberNotFound,
+		},
+		{
+			name:    "nobody deactivated",
+			userIDs: nil,
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil)
+				userRepo.EXPECT().DeactivateTeamMembers(ctx, "team-1", nil).Return([]string{}, nil)
+			},
+			want: &domain.TeamDeactivation{
+				TeamName:           "team-1",
+				DeactivatedUserIDs: []string{},
+				Reassigned:         []domain.ReviewerReplacement{},
+				Unfilled:           []domain.ReviewerReplacement{},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "splits reassigned and unfilled",
+			userIDs: []string{"user-1", "user-2"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository) {
+				gomock.InOrder(
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil),
+					userRepo.EXPECT().
+						DeactivateTeamMembers(ctx, "team-1", []string{"user-1", "user-2"}).
+						Return([]string{"user-1", "user-2"}, nil),
+					ownerRepo.EXPECT().
+						ReplaceTeamReviewers(ctx, "team-1", []string{"user-1", "user-2"}).
+						Return([]domain.ReviewerReplacement{
+							{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+							{PullRequestID: "pr-2", OldReviewerID: "user-2"},
+						}, nil),
+					ownerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{
+						{PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "user-3", PreviousUserID: "user-1", Reason: domain.AssignmentReasonDeactivate},
+						{PullRequestID: "pr-2", Type: domain.AssignmentEventUnassigned, UserID: "user-2", Reason: domain.AssignmentReasonDeactivate},
+					}).Return(nil),
+				)
+			},
+			want: &domain.TeamDeactivation{
+				TeamName:           "team-1",
+				DeactivatedUserIDs: []string{"user-1", "user-2"},
+				Reassigned: []domain.ReviewerReplacement{
+					{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+				},
+				Unfilled: []domain.ReviewerReplacement{
+					{PullRequestID: "pr-2", OldReviewerID: "user-2"},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo, mockUserRepo, mockOwnerRepo)
+
+			uc := NewTeam(mockTeamRepo, mockUserRepo, mockOwnerRepo, newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.DeactivateMembers(ctx, "team-1", tt.userIDs)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_DeactivateMembers_RollbackOnReplaceFailure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+	errReplace := errors.New("replace failed")
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	var rolledBack error
+	// метрики не учитываются для откаченной транзакции
+	uc := NewTeam(mockTeamRepo, mockUserRepo, mockOwnerRepo, newRollbackTransactor(ctrl, &rolledBack), NewMockMetrics(ctrl))
+
+	mockTeamRepo.EXPECT().
+		GetTeamByName(ctx, "team-1").
+		Return(&domain.Team{Name: "team-1", Members: []domain.User{{ID: "user-1"}}}, nil)
+	mockUserRepo.EXPECT().
+		DeactivateTeamMembers(ctx, "team-1", nil).
+		Return([]string{"user-1"}, nil)
+	mockOwnerRepo.EXPECT().
+		ReplaceTeamReviewers(ctx, "team-1", []string{"user-1"}).
+		Return(nil, errReplace)
+
+	// Act
+	got, err := uc.DeactivateMembers(ctx, "team-1", nil)
+
+	// Assert
+	if got != nil {
+		t.Fatalf("expected nil result, got %#v", got)
+	}
+	if !errors.Is(err, errReplace) || !errors.Is(rolledBack, errReplace) {
+		t.Fatalf("expected rollback with replace error, got err=%v rolledBack=%v", err, rolledBack)
+	}
+}
+
+func TestTeam_AddMember(t *testing.T) {
+	team := &domain.Team{Name: "team-1", Members: []domain.User{{ID: "user-1", Username: "u1", IsActive: true}}}
+	updated := &domain.Team{
+		Name:    "team-1",
+		Members: []domain.User{{ID: "user-1", Username: "u1", IsActive: true}, {ID: "user-2", Username: "u2", IsActive: true}},
+		Roles:   map[string]domain.TeamRole{"user-2": domain.TeamRoleLead},
+	}
+
+	tests := []struct {
+		name    string
+		member  domain.User
+		role    domain.TeamRole
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository)
+		want    *domain.Team
+		wantErr error
+	}{
+		{
+			name:    "invalid role",
+			member:  domain.User{ID: "user-2"},
+			role:    "owner",
+			mock:    func(context.Context, *MockTeamRepository, *MockUserRepository) {},
+			wantErr: ErrInvalidTeamRole,
+		},
+		{
+			name:   "team not found",
+			member: domain.User{ID: "user-2"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockUserRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(nil, nil)
+			},
+			wantErr: ErrTeamNotFound,
+		},
+		{
+			name:   "already in team",
+			member: domain.User{ID: "user-1"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockUserRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil)
+			},
+			wantErr: ErrMemberAlreadyInTeam,
+		},
+		{
+			name:   "new user linked with role",
+			member: domain.User{ID: "user-2", Username: "u2", IsActive: true},
+			role:   domain.TeamRoleLead,
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository) {
+				user := &domain.User{ID: "user-2", Username: "u2", IsActive: true}
+				gomock.InOrder(
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil),
+					userRepo.EXPECT().GetUserByID(ctx, "user-2").Return(nil, ErrMemberNotFound),
+					userRepo.EXPECT().SaveUser(ctx, user).Return(nil),
+					teamRepo.EXPECT().
+						LinkUserToTeam(ctx, &domain.Team{Name: "team-1", Roles: map[string]domain.TeamRole{"user-2": domain.TeamRoleLead}}, user).
+						Return(nil),
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(updated, nil),
+				)
+			},
+			want: updated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo, mockUserRepo)
+
+			uc := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.AddMember(ctx, "team-1", tt.member, tt.role)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_RemoveMember(t *testing.T) {
+	team := &domain.Team{Name: "team-1", Members: []domain.User{{ID: "user-1"}, {ID: "user-2"}, {ID: "user-3"}}}
+
+	tests := []struct {
+		name    string
+		userID  string
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository)
+		want    *domain.TeamMemberChange
+		wantErr error
+	}{
+		{
+			name:   "not a member",
+			userID: "stranger",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil)
+			},
+			wantErr: ErrMemberNotFound,
+		},
+		{
+			name:   "reviews reassigned before unlink",
+			userID: "user-1",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				gomock.InOrder(
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil),
+					ownerRepo.EXPECT().
+						ReplaceTeamPullRequestReviewers(ctx, "team-1", []string{"user-1"}).
+						Return([]domain.ReviewerReplacement{
+							{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+							{PullRequestID: "pr-2", OldReviewerID: "user-1"},
+						}, nil),
+					ownerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{
+						{PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "user-3", PreviousUserID: "user-1", Reason: domain.AssignmentReasonLeaveTeam},
+						{PullRequestID: "pr-2", Type: domain.AssignmentEventUnassigned, UserID: "user-1", Reason: domain.AssignmentReasonLeaveTeam},
+					}).Return(nil),
+					teamRepo.EXPECT().UnlinkUserFromTeam(ctx, "team-1", "user-1").Return(nil),
+				)
+			},
+			want: &domain.TeamMemberChange{
+				UserID:     "user-1",
+				FromTeam:   "team-1",
+				Reassigned: []domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-3"}},
+				Unfilled:   []domain.ReviewerReplacement{{PullRequestID: "pr-2", OldReviewerID: "user-1"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo, mockOwnerRepo)
+
+			uc := NewTeam(mockTeamRepo, NewMockUserRepository(ctrl), mockOwnerRepo, newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.RemoveMember(ctx, "team-1", tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_MoveMember(t *testing.T) {
+	from := &domain.Team{Name: "team-1", Members: []domain.User{{ID: "user-1"}, {ID: "user-2"}}}
+	to := &domain.Team{Name: "team-2", Members: []domain.User{{ID: "user-3"}}}
+
+	tests := []struct {
+		name    string
+		toTeam  string
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository)
+		want    *domain.TeamMemberChange
+		wantErr error
+	}{
+		{
+			name:   "same team",
+			toTeam: "team-1",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(from, nil)
+			},
+			wantErr: ErrMemberAlreadyInTeam,
+		},
+		{
+			name:   "target team not found",
+			toTeam: "missing",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "missing").Return(nil, nil)
+			},
+			wantErr: ErrTeamNotFound,
+		},
+		{
+			name:   "moved",
+			toTeam: "team-2",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, ownerRepo *MockRequestOwnerRepository) {
+				gomock.InOrder(
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-2").Return(to, nil),
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(from, nil),
+					ownerRepo.EXPECT().
+						ReplaceTeamPullRequestReviewers(ctx, "team-1", []string{"user-1"}).
+						Return([]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2"}}, nil),
+					ownerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{
+						{PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "user-2", PreviousUserID: "user-1", Reason: domain.AssignmentReasonLeaveTeam},
+					}).Return(nil),
+					teamRepo.EXPECT().UnlinkUserFromTeam(ctx, "team-1", "user-1").Return(nil),
+					teamRepo.EXPECT().
+						LinkUserToTeam(ctx, &domain.Team{Name: "team-2", Roles: map[string]domain.TeamRole{"user-1": domain.TeamRoleMember}}, &domain.User{ID: "user-1"}).
+						Return(nil),
+				)
+			},
+			want: &domain.TeamMemberChange{
+				UserID:     "user-1",
+				FromTeam:   "team-1",
+				ToTeam:     "team-2",
+				Reassigned: []domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-2"}},
+				Unfilled:   []domain.ReviewerReplacement{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo, mockOwnerRepo)
+
+			uc := NewTeam(mockTeamRepo, NewMockUserRepository(ctrl), mockOwnerRepo, newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.MoveMember(ctx, "team-1", tt.toTeam, "user-1", "")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_MoveMember_RollbackOnLinkFailure(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+	errLink := errors.New("link failed")
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+
+	var rolledBack error
+	uc := NewTeam(mockTeamRepo, NewMockUserRepository(ctrl), mockOwnerRepo, newRollbackTransactor(ctrl, &rolledBack), NewMockMetrics(ctrl))
+
+	mockTeamRepo.EXPECT().GetTeamByName(ctx, "team-2").Return(&domain.Team{Name: "team-2"}, nil)
+	mockTeamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(&domain.Team{Name: "team-1", Members: []domain.User{{ID: "user-1"}}}, nil)
+	mockOwnerRepo.EXPECT().ReplaceTeamPullRequestReviewers(ctx, "team-1", []string{"user-1"}).Return(nil, nil)
+	mockTeamRepo.EXPECT().UnlinkUserFromTeam(ctx, "team-1", "user-1").Return(nil)
+	mockTeamRepo.EXPECT().LinkUserToTeam(ctx, gomock.Any(), gomock.Any()).Return(errLink)
+
+	// Act
+	got, err := uc.MoveMember(ctx, "team-1", "team-2", "user-1", "")
+
+	// Assert
+	if !errors.Is(err, errLink) || !errors.Is(rolledBack, errLink) {
+		t.Fatalf("expected rollback with %v, got err %v, rolledBack %v", errLink, err, rolledBack)
+	}
+	if got != nil {
+		t.Fatalf("expected nil result, got %#v", got)
+	}
+}
+
+func TestTeam_DeleteTeam(t *testing.T) {
+	errDB := errors.New("db error")
+	team := &domain.Team{Name: "team-1", Members: []domain.User{{ID: "user-1", IsActive: true}, {ID: "user-2", IsActive: false}}}
+	replacements := []domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-1"}}
+
+	tests := []struct {
+		name    string
+		actor   Actor
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository, reqOwnerRepo *MockRequestOwnerRepository)
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "deleted",
+			actor: Actor{Admin: true, UserID: "admin-1"},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, reqOwnerRepo *MockRequestOwnerRepository) {
+				// назначения снимаются до исключения участников из команды
+				gomock.InOrder(
+					teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil),
+					reqOwnerRepo.EXPECT().ReplaceTeamReviewers(ctx, "team-1", []string{"user-1", "user-2"}).Return(replacements, nil),
+					reqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{{
+						PullRequestID: "pr-1",
+						Type:          domain.AssignmentEventUnassigned,
+						UserID:        "user-1",
+						ActorUserID:   "admin-1",
+						Reason:        domain.AssignmentReasonDeleteTeam,
+					}}).Return(nil),
+					teamRepo.EXPECT().DeleteTeam(ctx, "team-1").Return([]string{"user-1", "user-2"}, nil),
+				)
+			},
+			want: []string{"user-1", "user-2"},
+		},
+		{
+			name:  "no members",
+			actor: Actor{Admin: true},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(&domain.Team{Name: "team-1"}, nil)
+				teamRepo.EXPECT().DeleteTeam(ctx, "team-1").Return([]string{}, nil)
+			},
+			want: []string{},
+		},
+		{
+			name:  "not found",
+			actor: Actor{Admin: true},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, _ *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(nil, nil)
+			},
+			wantErr: ErrTeamNotFound,
+		},
+		{
+			name:  "replace failed",
+			actor: Actor{Admin: true},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, reqOwnerRepo *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil)
+				reqOwnerRepo.EXPECT().ReplaceTeamReviewers(ctx, "team-1", []string{"user-1", "user-2"}).Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name:  "delete failed",
+			actor: Actor{Admin: true},
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, reqOwnerRepo *MockRequestOwnerRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(team, nil)
+				reqOwnerRepo.EXPECT().ReplaceTeamReviewers(ctx, "team-1", []string{"user-1", "user-2"}).Return(replacements, nil)
+				reqOwnerRepo.EXPECT().SaveAssignmentEvents(ctx, gomock.Any()).Return(nil)
+				teamRepo.EXPECT().DeleteTeam(ctx, "team-1").Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+		{
+			name:    "team lead is not enough",
+			actor:   Actor{UserID: "user-1"},
+			mock:    func(context.Context, *MockTeamRepository, *MockRequestOwnerRepository) {},
+			wantErr: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo, mockReqOwnerRepo)
+
+			// без прав транзакция не открывается
+			var rolledBack error
+			transactor := newPassThroughTransactor(ctrl)
+			if !errors.Is(tt.wantErr, ErrForbidden) {
+				transactor = newRollbackTransactor(ctrl, &rolledBack)
+			}
+
+			uc := NewTeam(mockTeamRepo, NewMockUserRepository(ctrl), mockReqOwnerRepo, transactor, nil)
+
+			// Act
+			got, err := uc.DeleteTeam(ctx, "team-1")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !errors.Is(tt.wantErr, ErrForbidden) && !errors.Is(rolledBack, tt.wantErr) {
+				t.Fatalf("expected rollback with %v, got %v", tt.wantErr, rolledBack)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_SyncTeam(t *testing.T) {
+	stored := &domain.Team{Name: "team-1", Members: []domain.User{
+		{ID: "user-1", Username: "Alice", IsActive: true},
+		{ID: "user-2", Username: "Bob", IsActive: true},
+		{ID: "user-3", Username: "Carol", IsActive: true},
+	}}
+	// user-1 переименован, user-2 исключен, user-3 деактивирован, user-4 новый, user-5 уже есть в другой команде
+	members := []domain.User{
+		{ID: "user-1", Username: "Alice Smith", IsActive: true},
+		{ID: "user-3", Username: "Carol", IsActive: false},
+		{ID: "user-4", Username: "Dan", IsActive: true},
+		{ID: "user-5", Username: "Eve", IsActive: true},
+	}
+	plan := func(dryRun bool) *domain.TeamSync {
+		return &domain.TeamSync{
+			TeamName:        "team-1",
+			DryRun:          dryRun,
+			Added:           []string{"user-4", "user-5"},
+			Removed:         []string{"user-2"},
+			Renamed:         []domain.UsernameChange{{UserID: "user-1", From: "Alice", To: "Alice Smith"}},
+			ActivityChanged: []domain.ActivityChange{{UserID: "user-3", IsActive: false}},
+			Reassigned:      []domain.ReviewerReplacement{},
+			Unfilled:        []domain.ReviewerReplacement{},
+		}
+	}
+
+	readPlan := func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository) {
+		teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(stored, nil)
+		userRepo.EXPECT().GetUserByID(ctx, "user-4").Return(nil, ErrMemberNotFound)
+		userRepo.EXPECT().GetUserByID(ctx, "user-5").Return(&domain.User{ID: "user-5", Username: "Eve", IsActive: true}, nil)
+	}
+
+	tests := []struct {
+		name    string
+		dryRun  bool
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository)
+		want    *domain.TeamSync
+		wantErr error
+	}{
+		{
+			name:   "dry run only reads",
+			dryRun: true,
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository, _ *MockRequestOwnerRepository) {
+				readPlan(ctx, teamRepo, userRepo)
+			},
+			want: plan(true),
+		},
+		{
+			name: "applies diff",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository, userRepo *MockUserRepository, ownerRepo *MockRequestOwnerRepository) {
+				readPlan(ctx, teamRepo, userRepo)
+				userRepo.EXPECT().UpdateUser(ctx, &domain.User{ID: "user-1", Username: "Alice Smith", IsActive: true}).Return(nil)
+				userRepo.EXPECT().UpdateUser(ctx, &domain.User{ID: "user-3", Username: "Carol", IsActive: false}).Return(nil)
+				userRepo.EXPECT().SaveUser(ctx, &domain.User{ID: "user-4", Username: "Dan", IsActive: true}).Return(nil)
+				teamRepo.EXPECT().LinkUserToTeam(ctx, gomock.Any(), &domain.User{ID: "user-4"}).Return(nil)
+				teamRepo.EXPECT().LinkUserToTeam(ctx, gomock.Any(), &domain.User{ID: "user-5"}).Return(nil)
+				gomock.InOrder(
+					teamRepo.EXPECT().UnlinkUserFromTeam(ctx, "team-1", "user-2").Return(nil),
+					ownerRepo.EXPECT().
+						ReplaceTeamPullRequestReviewers(ctx, "team-1", []string{"user-2"}).
+						Return([]domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-4"}}, nil),
+					ownerRepo.EXPECT().
+						ReplaceTeamReviewers(ctx, "team-1", []string{"user-3"}).
+						Return([]domain.ReviewerReplacement{{PullRequestID: "pr-2", OldReviewerID: "user-3"}}, nil),
+					ownerRepo.EXPECT().SaveAssignmentEvents(ctx, []domain.AssignmentEvent{
+						{PullRequestID: "pr-1", Type: domain.AssignmentEventReassigned, UserID: "user-4", PreviousUserID: "user-2", Reason: domain.AssignmentReasonSyncTeam},
+						{PullRequestID: "pr-2", Type: domain.AssignmentEventUnassigned, UserID: "user-3", Reason: domain.AssignmentReasonSyncTeam},
+					}).Return(nil),
+				)
+			},
+			want: func() *domain.TeamSync {
+				want := plan(false)
+				want.Reassigned = []domain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "user-2", NewReviewerID: "user-4"}}
+				want.Unfilled = []domain.ReviewerReplacement{{PullRequestID: "pr-2", OldReviewerID: "user-3"}}
+				return want
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			mockUserRepo := NewMockUserRepository(ctrl)
+			mockOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo, mockUserRepo, mockOwnerRepo)
+
+			uc := NewTeam(mockTeamRepo, mockUserRepo, mockOwnerRepo, newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.SyncTeam(ctx, &domain.Team{Name: "team-1"}, members, tt.dryRun)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_SyncTeam_CreatesMissingTeam(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+	team := &domain.Team{Name: "team-1", Roles: map[string]domain.TeamRole{"user-1": domain.TeamRoleLead}}
+	mockTeamRepo := NewMockTeamRepository(ctrl)
+	mockUserRepo := NewMockUserRepository(ctrl)
+
+	gomock.InOrder(
+		mockTeamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(nil, nil),
+		mockUserRepo.EXPECT().GetUserByID(ctx, "user-1").Return(nil, ErrMemberNotFound),
+		mockTeamRepo.EXPECT().SaveTeam(ctx, team).Return(nil),
+		mockUserRepo.EXPECT().SaveUser(ctx, &domain.User{ID: "user-1", Username: "Alice", IsActive: true}).Return(nil),
+		mockTeamRepo.EXPECT().LinkUserToTeam(ctx, team, &domain.User{ID: "user-1"}).Return(nil),
+	)
+
+	uc := NewTeam(mockTeamRepo, mockUserRepo, NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
+
+	// Act
+	got, err := uc.SyncTeam(ctx, team, []domain.User{{ID: "user-1", Username: "Alice", IsActive: true}}, false)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Created || !reflect.DeepEqual(got.Added, []string{"user-1"}) {
+		t.Fatalf("expected team to be created with user-1, got %#v", got)
+	}
+}
+
+func TestTeam_SyncTeam_InvalidRoster(t *testing.T) {
+	tests := []struct {
+		name    string
+		team    *domain.Team
+		members []domain.User
+		wantErr error
+	}{
+		{name: "no team name", team: &domain.Team{}, members: []domain.User{{ID: "user-1"}}, wantErr: ErrInvalidTeamName},
+		{name: "empty roster", team: &domain.Team{Name: "team-1"}, members: nil, wantErr: ErrMemberNotFound},
+		{name: "duplicate member", team: &domain.Team{Name: "team-1"}, members: []domain.User{{ID: "user-1"}, {ID: "user-1"}}, wantErr: ErrDuplicateMember},
+		{
+			name:    "invalid role",
+			team:    &domain.Team{Name: "team-1", Roles: map[string]domain.TeamRole{"user-1": "owner"}},
+			members: []domain.User{{ID: "user-1"}},
+			wantErr: ErrInvalidTeamRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := NewTeam(NewMockTeamRepository(ctrl), NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), NewMockTransactor(ctrl), nil)
+
+			_, err := uc.SyncTeam(WithActor(context.Background(), actorAdmin), tt.team, tt.members, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTeam_GetSettings(t *testing.T) {
+	saved := &domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 3}
+
+	tests := []struct {
+		name    string
+		mock    func(ctx context.Context, teamRepo *MockTeamRepository)
+		want    *domain.TeamSettings
+		wantErr error
+	}{
+		{
+			name: "saved settings",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(&domain.Team{Name: "team-1"}, nil)
+				teamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(saved, nil)
+			},
+			want: saved,
+		},
+		{
+			name: "defaults when never changed",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(&domain.Team{Name: "team-1"}, nil)
+				teamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(nil, nil)
+			},
+			want: &domain.TeamSettings{TeamName: "team-1", MinReviewers: 0, MaxReviewers: 2, ReviewSLAHours: 24},
+		},
+		{
+			name: "team not found",
+			mock: func(ctx context.Context, teamRepo *MockTeamRepository) {
+				teamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(nil, nil)
+			},
+			wantErr: ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), actorAdmin)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+			tt.mock(ctx, mockTeamRepo)
+
+			uc := NewTeam(mockTeamRepo, NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.GetSettings(ctx, "team-1")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeam_UpdateSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		actor    Actor
+		settings domain.TeamSettings
+		saved    bool
+		wantErr  error
+	}{
+		{name: "admin", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 3}, saved: true},
+		{name: "lead of team", actor: actorLead, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 0, MaxReviewers: 0}, saved: true},
+		{name: "member of team", actor: actorMember, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 3}, wantErr: ErrForbidden},
+		{name: "negative min", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: -1, MaxReviewers: 2}, wantErr: ErrInvalidTeamSettings},
+		{name: "min above max", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 3, MaxReviewers: 2}, wantErr: ErrInvalidTeamSettings},
+		{name: "max above limit", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: maxReviewersLimit + 1}, wantErr: ErrInvalidTeamSettings},
+		{name: "required approvals", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 3, RequiredApprovals: 2}, saved: true},
+		{name: "negative approvals", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, RequiredApprovals: -1}, wantErr: ErrInvalidTeamSettings},
+		{name: "approvals above max", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, RequiredApprovals: 3}, wantErr: ErrInvalidTeamSettings},
+		{name: "review sla with reassign", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, ReviewSLAHours: 24, ReassignAfterHours: 48}, saved: true},
+		{name: "reassign without reminder", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, ReassignAfterHours: 8}, saved: true},
+		{name: "negative review sla", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, ReviewSLAHours: -1}, wantErr: ErrInvalidTeamSettings},
+		{name: "reassign before reminder", actor: actorAdmin, settings: domain.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, ReviewSLAHours: 24, ReassignAfterHours: 24}, wantErr: ErrInvalidTeamSettings},
+		{name: "empty team name", actor: actorAdmin, settings: domain.TeamSettings{MinReviewers: 1, MaxReviewers: 2}, wantErr: ErrInvalidTeamName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := WithActor(context.Background(), tt.actor)
+			mockTeamRepo := NewMockTeamRepository(ctrl)
+
+			stored := tt.settings
+			stored.UpdatedAt = time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC)
+			if tt.wantErr == nil || errors.Is(tt.wantErr, ErrForbidden) {
+				mockTeamRepo.EXPECT().GetTeamByName(ctx, "team-1").Return(rolesTeam(), nil)
+			}
+			if tt.saved {
+				gomock.InOrder(
+					mockTeamRepo.EXPECT().SaveTeamSettings(ctx, &tt.settings).Return(nil),
+					mockTeamRepo.EXPECT().GetTeamSettings(ctx, "team-1").Return(&stored, nil),
+				)
+			}
+
+			uc := NewTeam(mockTeamRepo, NewMockUserRepository(ctrl), NewMockRequestOwnerRepository(ctrl), newPassThroughTransactor(ctrl), nil)
+
+			// Act
+			got, err := uc.UpdateSettings(ctx, &tt.settings)
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.saved && !reflect.DeepEqual(got, &stored) {
+				t.Fatalf("got %#v, want %#v", got, &stored)
+			}
+		})
+	}
+}
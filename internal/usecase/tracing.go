@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "avito-test/internal/usecase"
+
+// startSpan открывает спан метода usecase. Провайдер берется глобальный, без настройки это noop.
+// Если спан не записывается, возвращается исходный контекст: дочерним спанам в нем нечего продолжать,
+// а репозитории получают тот же ctx, что и usecase.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+	if !span.IsRecording() {
+		return ctx, span
+	}
+	return spanCtx, span
+}
+
+// endSpan закрывает спан и отмечает в нем ошибку метода. Вызывается через defer с указателем на именованный результат.
+func endSpan(span trace.Span, err *error) {
+	if *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+	span.End()
+}
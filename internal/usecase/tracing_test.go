@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"avito-test/internal/domain"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// useSpanRecorder подменяет глобальный TracerProvider на время теста.
+// После теста ставится noop провайдер, чтобы остальные тесты получали исходный ctx в моках.
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return recorder
+}
+
+func TestUser_GetUserPullRequests_Span(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := useSpanRecorder(t)
+	mockUserRepo := NewMockUserRepository(ctrl)
+
+	var repoSpan trace.SpanContext
+	mockUserRepo.EXPECT().
+		GetUserByID(gomock.Any(), "user-1").
+		DoAndReturn(func(ctx context.Context, _ string) (*domain.User, error) {
+			repoSpan = trace.SpanContextFromContext(ctx)
+			return nil, ErrMemberNotFound
+		})
+
+	u := NewUser(mockUserRepo, NewMockRequestOwnerRepository(ctrl), NewMockPullRequestRepository(ctrl), NewMockTeamRepository(ctrl))
+
+	// Act
+	_, err := u.GetUserPullRequests(context.Background(), "user-1", false)
+
+	// Assert
+	if err != ErrMemberNotFound {
+		t.Fatalf("expected ErrMemberNotFound, got %v", err)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "User.GetUserPullRequests" {
+		t.Fatalf("unexpected span name %q", span.Name())
+	}
+	if span.Status().Code != codes.Error {
+		t.Fatalf("expected error status, got %v", span.Status())
+	}
+	if repoSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Fatalf("repository was called outside of usecase span")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
+	"time"
 )
 
 var (
@@ -17,13 +18,66 @@ var (
 	ErrAuthorIsInactive               = errors.New("author is inactive")
 	ErrCannotFindActiveMembers        = errors.New("cannot find active members")
 	ErrPullRequestIsMerged            = errors.New("pull request is merged")
+	ErrReviewerNotAssigned            = errors.New("reviewer is not assigned to this pull request")
 	ErrPullRequestRepositoryNotFound  = errors.New("pull request repository is nil")
 	ErrUserRepositoryNotFound         = errors.New("user repository is nil")
 	ErrTeamRepositoryNotFound         = errors.New("team repository is nil")
 	ErrRequestOwnerRepositoryNotFound = errors.New("request owner repository is nil")
+	ErrTransactorNotFound             = errors.New("transactor is nil")
+	ErrReviewerSelectorNotFound       = errors.New("reviewer selector is nil")
+	ErrUnknownReviewerStrategy        = errors.New("unknown reviewer selection strategy")
+	ErrStatsRepositoryNotFound        = errors.New("stats repository is nil")
+	ErrInvalidStatsRange              = errors.New("invalid stats time range")
+	ErrTokenRepositoryNotFound        = errors.New("token repository is nil")
+	ErrTokenNotFound                  = errors.New("token not found")
+	ErrInvalidToken                   = errors.New("invalid or revoked token")
+	ErrInvalidTokenScope              = errors.New("invalid token scope")
+	ErrTokenUserRequired              = errors.New("user_id is required for user scope token")
+	ErrForbidden                      = errors.New("not enough permissions")
+	ErrInvalidTeamRole                = errors.New("invalid team role")
+	ErrMemberAlreadyInTeam            = errors.New("member already in team")
+	ErrDuplicateMember                = errors.New("duplicate member")
+	ErrInvalidTeamSettings            = errors.New("invalid team settings")
+	ErrInvalidReviewerRequest         = errors.New("invalid reviewer request")
+	ErrReviewerNotFound               = errors.New("reviewer not found")
+	ErrReviewerInactive               = errors.New("reviewer is inactive")
+	ErrReviewerNotInTeam              = errors.New("reviewer is not a member of author's teams")
+	ErrReviewerAlreadyAssigned        = errors.New("reviewer is already assigned to this pull request")
+	ErrReviewerLimitReached           = errors.New("pull request already has max reviewers")
+	ErrInvalidReviewState             = errors.New("invalid review decision")
+	ErrMergeBlocked                   = errors.New("merge blocked")
+	ErrInvalidStatusTransition        = errors.New("invalid pull request status transition")
+	ErrPullRequestNotOpen             = errors.New("pull request is not open for review")
+	ErrNotifierNotFound               = errors.New("notifier is nil")
+	ErrReviewerReassignerNotFound     = errors.New("reviewer reassigner is nil")
 )
 
 //go:generate mockgen -source usecase.go -package usecase -destination usecase_mock.go
+type Transactor interface {
+	// WithinTransaction - функция выполнения fn в одной транзакции: при ошибке все изменения откатываются.
+	// Репозитории, вызванные с переданным в fn контекстом, работают внутри этой транзакции.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Metrics interface {
+	// ReviewersAssigned - функция учета n назначенных ревьюверов в рамках операции
+	ReviewersAssigned(operation string, n int)
+	// Reassigned - функция учета n замен ревьюверов
+	Reassigned(operation string, n int)
+	// NoCandidate - функция учета n слотов ревьюверов, для которых не нашлось кандидата
+	NoCandidate(operation string, n int)
+}
+
+type Notifier interface {
+	// NotifyOverdueReview - функция напоминания ревьюверу, что срок ответа по назначению истек в dueAt
+	NotifyOverdueReview(ctx context.Context, assignment domain.ReviewAssignment, dueAt time.Time) error
+}
+
+type ReviewerReassigner interface {
+	// ReassignRequest - функция замены ревьювера userID другим участником команды по правилам переназначения
+	ReassignRequest(ctx context.Context, requestID, userID string) (*domain.PullRequest, *domain.User, error)
+}
+
 type UserRepository interface {
 	// SaveUser - функция сохранения пользователя
 	SaveUser(ctx context.Context, user *domain.User) error
@@ -35,6 +89,9 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user *domain.User) error
 	// GetTeamsByUserID - функция получения списка команд пользователя
 	GetTeamsByUserID(ctx context.Context, userID string) ([]domain.Team, error)
+	// DeactivateTeamMembers - функция деактивации участников команды, при пустом userIDs - всех участников.
+	// Возвращает id деактивированных пользователей
+	DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) ([]string, error)
 }
 
 type RequestOwnerRepository interface {
@@ -46,16 +103,54 @@ type RequestOwnerRepository interface {
 	GetRequestsByUserID(ctx context.Context, userID string) ([]domain.RequestOwner, error)
 	// GetUsersByPullRequestID - функция получения пользователей по id pr
 	GetUsersByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.RequestOwner, error)
+	// GetOpenReviewCounts - функция получения количества OPEN PR, где пользователь ревьювер, по id пользователя
+	GetOpenReviewCounts(ctx context.Context) (map[string]int, error)
+	// ReplaceTeamReviewers - функция замены ревьюверов userIDs во всех OPEN PR активными участниками команды teamName,
+	// кроме самих userIDs и исключенных автором PR. Слоты без подходящего кандидата освобождаются
+	ReplaceTeamReviewers(ctx context.Context, teamName string, userIDs []string) ([]domain.ReviewerReplacement, error)
+	// ReplaceTeamPullRequestReviewers - функция замены ревьюверов userIDs активными участниками команды teamName
+	// (кроме самих userIDs и исключенных автором PR) только в OPEN PR, автор которых состоит в этой команде. Слоты без подходящего кандидата освобождаются
+	ReplaceTeamPullRequestReviewers(ctx context.Context, teamName string, userIDs []string) ([]domain.ReviewerReplacement, error)
+	// SaveExcludedReviewers - функция сохранения пользователей, которых автор исключил из ревьюверов PR
+	SaveExcludedReviewers(ctx context.Context, pullRequestID string, userIDs []string) error
+	// GetExcludedReviewers - функция получения id пользователей, исключенных автором из ревьюверов PR
+	GetExcludedReviewers(ctx context.Context, pullRequestID string) ([]string, error)
+	// GetPendingReviewAssignments - функция получения назначений ревьюверов на OPEN PR, по которым после назначения
+	// нет одобрения или запроса изменений, в порядке назначения
+	GetPendingReviewAssignments(ctx context.Context) ([]domain.ReviewAssignment, error)
+	// MarkAssignmentNotified - функция отметки, что ревьювер userID получил напоминание по PR pullRequestID
+	MarkAssignmentNotified(ctx context.Context, pullRequestID, userID string, notifiedAt time.Time) error
+	// SaveAssignmentEvents - функция добавления событий в историю назначений, записи истории не изменяются
+	SaveAssignmentEvents(ctx context.Context, events []domain.AssignmentEvent) error
+	// GetAssignmentEventsByPullRequestID - функция получения истории назначений PR в порядке событий
+	GetAssignmentEventsByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.AssignmentEvent, error)
+	// GetAssignmentEventsByUserID - функция получения событий, где пользователь назначен или заменен, в порядке событий
+	GetAssignmentEventsByUserID(ctx context.Context, userID string) ([]domain.AssignmentEvent, error)
+	// SaveAssignmentExplanation - функция сохранения объяснения выбора ревьюверов
+	SaveAssignmentExplanation(ctx context.Context, explanation *domain.AssignmentExplanation) error
+	// GetAssignmentExplanationsByPullRequestID - функция получения объяснений выбора ревьюверов PR в порядке выбора
+	GetAssignmentExplanationsByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.AssignmentExplanation, error)
 }
 type TeamRepository interface {
 	// SaveTeam - функция сохранения команды
 	SaveTeam(ctx context.Context, team *domain.Team) error
 	// GetTeamByName - функция получения команды по ее ID
 	GetTeamByName(ctx context.Context, name string) (*domain.Team, error)
-	// GetTeams - функция получения всех команд
+	// GetTeams - функция получения всех команд (без участников)
 	GetTeams(ctx context.Context) ([]domain.Team, error)
-	// LinkUserToTeam - функция привязки пользователя к команде
+	// LinkUserToTeam - функция привязки пользователя к команде с ролью из team.Roles
 	LinkUserToTeam(ctx context.Context, team *domain.Team, user *domain.User) error
+	// UnlinkUserFromTeam - функция исключения пользователя из команды, ErrMemberNotFound если он в ней не состоит
+	UnlinkUserFromTeam(ctx context.Context, teamName, userID string) error
+	// DeleteTeam - функция удаления команды вместе со связями участников, ErrTeamNotFound если ее нет.
+	// Возвращает id исключенных участников
+	DeleteTeam(ctx context.Context, teamName string) ([]string, error)
+	// GetTeamSettings - функция получения настроек команды, nil если они не сохранялись
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	// SaveTeamSettings - функция сохранения настроек команды (создает или обновляет запись)
+	SaveTeamSettings(ctx context.Context, settings *domain.TeamSettings) error
+	// IsTeamLead - функция проверки, что leadID - лид команды, в которой состоит memberID
+	IsTeamLead(ctx context.Context, leadID, memberID string) (bool, error)
 }
 
 type PullRequestRepository interface {
@@ -67,4 +162,30 @@ type PullRequestRepository interface {
 	GetPullRequests(ctx context.Context) ([]domain.PullRequest, error)
 	// UpdatePullRequest - функция обновления пул реквеста
 	UpdatePullRequest(ctx context.Context, pull *domain.PullRequest) error
+	// SaveReviewDecision - функция сохранения решения ревьювера, предыдущие решения не перезаписываются
+	SaveReviewDecision(ctx context.Context, review *domain.ReviewDecision) error
+	// SaveMergeOverride - функция сохранения записи аудита о слиянии в обход условий
+	SaveMergeOverride(ctx context.Context, override *domain.MergeOverride) error
+}
+
+type StatsRepository interface {
+	// GetUserAssignmentStats - функция получения количества назначений ревьюверов по пользователям
+	GetUserAssignmentStats(ctx context.Context, filter domain.StatsFilter) ([]domain.UserAssignmentStats, error)
+	// GetPullRequestReviewerStats - функция получения количества ревьюверов по пул реквестам
+	GetPullRequestReviewerStats(ctx context.Context, filter domain.StatsFilter) ([]domain.PullRequestReviewerStats, error)
+	// GetTeamStats - функция получения итогов по командам
+	GetTeamStats(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamStats, error)
+	// GetMergeTimeStats - функция получения медианы времени от создания до слияния PR
+	GetMergeTimeStats(ctx context.Context, filter domain.StatsFilter) (*domain.MergeTimeStats, error)
+}
+
+type TokenRepository interface {
+	// SaveToken - функция сохранения токена
+	SaveToken(ctx context.Context, token *domain.APIToken) error
+	// GetTokenByHash - функция получения действующего токена по хешу, ErrTokenNotFound если его нет или он отозван
+	GetTokenByHash(ctx context.Context, hash string) (*domain.APIToken, error)
+	// GetTokens - функция получения всех токенов, включая отозванные
+	GetTokens(ctx context.Context) ([]domain.APIToken, error)
+	// RevokeToken - функция отзыва токена, ErrTokenNotFound если его нет или он уже отозван
+	RevokeToken(ctx context.Context, id string) error
 }
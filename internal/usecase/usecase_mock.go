@@ -8,10 +8,183 @@ import (
 	domain "avito-test/internal/domain"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// WithinTransaction mocks base method.
+func (m *MockTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTransaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTransaction indicates an expected call of WithinTransaction.
+func (mr *MockTransactorMockRecorder) WithinTransaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTransaction", reflect.TypeOf((*MockTransactor)(nil).WithinTransaction), ctx, fn)
+}
+
+// MockMetrics is a mock of Metrics interface.
+type MockMetrics struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsMockRecorder
+}
+
+// MockMetricsMockRecorder is the mock recorder for MockMetrics.
+type MockMetricsMockRecorder struct {
+	mock *MockMetrics
+}
+
+// NewMockMetrics creates a new mock instance.
+func NewMockMetrics(ctrl *gomock.Controller) *MockMetrics {
+	mock := &MockMetrics{ctrl: ctrl}
+	mock.recorder = &MockMetricsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
+	return m.recorder
+}
+
+// NoCandidate mocks base method.
+func (m *MockMetrics) NoCandidate(operation string, n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NoCandidate", operation, n)
+}
+
+// NoCandidate indicates an expected call of NoCandidate.
+func (mr *MockMetricsMockRecorder) NoCandidate(operation, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoCandidate", reflect.TypeOf((*MockMetrics)(nil).NoCandidate), operation, n)
+}
+
+// Reassigned mocks base method.
+func (m *MockMetrics) Reassigned(operation string, n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reassigned", operation, n)
+}
+
+// Reassigned indicates an expected call of Reassigned.
+func (mr *MockMetricsMockRecorder) Reassigned(operation, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reassigned", reflect.TypeOf((*MockMetrics)(nil).Reassigned), operation, n)
+}
+
+// ReviewersAssigned mocks base method.
+func (m *MockMetrics) ReviewersAssigned(operation string, n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReviewersAssigned", operation, n)
+}
+
+// ReviewersAssigned indicates an expected call of ReviewersAssigned.
+func (mr *MockMetricsMockRecorder) ReviewersAssigned(operation, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewersAssigned", reflect.TypeOf((*MockMetrics)(nil).ReviewersAssigned), operation, n)
+}
+
+// MockNotifier is a mock of Notifier interface.
+type MockNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotifierMockRecorder
+}
+
+// MockNotifierMockRecorder is the mock recorder for MockNotifier.
+type MockNotifierMockRecorder struct {
+	mock *MockNotifier
+}
+
+// NewMockNotifier creates a new mock instance.
+func NewMockNotifier(ctrl *gomock.Controller) *MockNotifier {
+	mock := &MockNotifier{ctrl: ctrl}
+	mock.recorder = &MockNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifier) EXPECT() *MockNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyOverdueReview mocks base method.
+func (m *MockNotifier) NotifyOverdueReview(ctx context.Context, assignment domain.ReviewAssignment, dueAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyOverdueReview", ctx, assignment, dueAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyOverdueReview indicates an expected call of NotifyOverdueReview.
+func (mr *MockNotifierMockRecorder) NotifyOverdueReview(ctx, assignment, dueAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyOverdueReview", reflect.TypeOf((*MockNotifier)(nil).NotifyOverdueReview), ctx, assignment, dueAt)
+}
+
+// MockReviewerReassigner is a mock of ReviewerReassigner interface.
+type MockReviewerReassigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewerReassignerMockRecorder
+}
+
+// MockReviewerReassignerMockRecorder is the mock recorder for MockReviewerReassigner.
+type MockReviewerReassignerMockRecorder struct {
+	mock *MockReviewerReassigner
+}
+
+// NewMockReviewerReassigner creates a new mock instance.
+func NewMockReviewerReassigner(ctrl *gomock.Controller) *MockReviewerReassigner {
+	mock := &MockReviewerReassigner{ctrl: ctrl}
+	mock.recorder = &MockReviewerReassignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewerReassigner) EXPECT() *MockReviewerReassignerMockRecorder {
+	return m.recorder
+}
+
+// ReassignRequest mocks base method.
+func (m *MockReviewerReassigner) ReassignRequest(ctx context.Context, requestID, userID string) (*domain.PullRequest, *domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignRequest", ctx, requestID, userID)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(*domain.User)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReassignRequest indicates an expected call of ReassignRequest.
+func (mr *MockReviewerReassignerMockRecorder) ReassignRequest(ctx, requestID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignRequest", reflect.TypeOf((*MockReviewerReassigner)(nil).ReassignRequest), ctx, requestID, userID)
+}
+
 // MockUserRepository is a mock of UserRepository interface.
 type MockUserRepository struct {
 	ctrl     *gomock.Controller
@@ -35,6 +208,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DeactivateTeamMembers mocks base method.
+func (m *MockUserRepository) DeactivateTeamMembers(ctx context.Context, teamName string, userIDs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTeamMembers", ctx, teamName, userIDs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTeamMembers indicates an expected call of DeactivateTeamMembers.
+func (mr *MockUserRepositoryMockRecorder) DeactivateTeamMembers(ctx, teamName, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeamMembers", reflect.TypeOf((*MockUserRepository)(nil).DeactivateTeamMembers), ctx, teamName, userIDs)
+}
+
 // GetTeamsByUserID mocks base method.
 func (m *MockUserRepository) GetTeamsByUserID(ctx context.Context, userID string) ([]domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +333,96 @@ func (mr *MockRequestOwnerRepositoryMockRecorder) DeleteRequestOwner(ctx, reques
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRequestOwner", reflect.TypeOf((*MockRequestOwnerRepository)(nil).DeleteRequestOwner), ctx, requestOwner)
 }
 
+// GetAssignmentEventsByPullRequestID mocks base method.
+func (m *MockRequestOwnerRepository) GetAssignmentEventsByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.AssignmentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentEventsByPullRequestID", ctx, pullRequestID)
+	ret0, _ := ret[0].([]domain.AssignmentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentEventsByPullRequestID indicates an expected call of GetAssignmentEventsByPullRequestID.
+func (mr *MockRequestOwnerRepositoryMockRecorder) GetAssignmentEventsByPullRequestID(ctx, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentEventsByPullRequestID", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetAssignmentEventsByPullRequestID), ctx, pullRequestID)
+}
+
+// GetAssignmentEventsByUserID mocks base method.
+func (m *MockRequestOwnerRepository) GetAssignmentEventsByUserID(ctx context.Context, userID string) ([]domain.AssignmentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentEventsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]domain.AssignmentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentEventsByUserID indicates an expected call of GetAssignmentEventsByUserID.
+func (mr *MockRequestOwnerRepositoryMockRecorder) GetAssignmentEventsByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentEventsByUserID", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetAssignmentEventsByUserID), ctx, userID)
+}
+
+// GetAssignmentExplanationsByPullRequestID mocks base method.
+func (m *MockRequestOwnerRepository) GetAssignmentExplanationsByPullRequestID(ctx context.Context, pullRequestID string) ([]domain.AssignmentExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignmentExplanationsByPullRequestID", ctx, pullRequestID)
+	ret0, _ := ret[0].([]domain.AssignmentExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignmentExplanationsByPullRequestID indicates an expected call of GetAssignmentExplanationsByPullRequestID.
+func (mr *MockRequestOwnerRepositoryMockRecorder) GetAssignmentExplanationsByPullRequestID(ctx, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignmentExplanationsByPullRequestID", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetAssignmentExplanationsByPullRequestID), ctx, pullRequestID)
+}
+
+// GetExcludedReviewers mocks base method.
+func (m *MockRequestOwnerRepository) GetExcludedReviewers(ctx context.Context, pullRequestID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedReviewers", ctx, pullRequestID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExcludedReviewers indicates an expected call of GetExcludedReviewers.
+func (mr *MockRequestOwnerRepositoryMockRecorder) GetExcludedReviewers(ctx, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedReviewers", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetExcludedReviewers), ctx, pullRequestID)
+}
+
+// GetOpenReviewCounts mocks base method.
+func (m *MockRequestOwnerRepository) GetOpenReviewCounts(ctx context.Context) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenReviewCounts", ctx)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenReviewCounts indicates an expected call of GetOpenReviewCounts.
+func (mr *MockRequestOwnerRepositoryMockRecorder) GetOpenReviewCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReviewCounts", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetOpenReviewCounts), ctx)
+}
+
+// GetPendingReviewAssignments mocks base method.
+func (m *MockRequestOwnerRepository) GetPendingReviewAssignments(ctx context.Context) ([]domain.ReviewAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingReviewAssignments", ctx)
+	ret0, _ := ret[0].([]domain.ReviewAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingReviewAssignments indicates an expected call of GetPendingReviewAssignments.
+func (mr *MockRequestOwnerRepositoryMockRecorder) GetPendingReviewAssignments(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingReviewAssignments", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetPendingReviewAssignments), ctx)
+}
+
 // GetRequestsByUserID mocks base method.
 func (m *MockRequestOwnerRepository) GetRequestsByUserID(ctx context.Context, userID string) ([]domain.RequestOwner, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +453,92 @@ func (mr *MockRequestOwnerRepositoryMockRecorder) GetUsersByPullRequestID(ctx, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByPullRequestID", reflect.TypeOf((*MockRequestOwnerRepository)(nil).GetUsersByPullRequestID), ctx, pullRequestID)
 }
 
+// MarkAssignmentNotified mocks base method.
+func (m *MockRequestOwnerRepository) MarkAssignmentNotified(ctx context.Context, pullRequestID, userID string, notifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAssignmentNotified", ctx, pullRequestID, userID, notifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAssignmentNotified indicates an expected call of MarkAssignmentNotified.
+func (mr *MockRequestOwnerRepositoryMockRecorder) MarkAssignmentNotified(ctx, pullRequestID, userID, notifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAssignmentNotified", reflect.TypeOf((*MockRequestOwnerRepository)(nil).MarkAssignmentNotified), ctx, pullRequestID, userID, notifiedAt)
+}
+
+// ReplaceTeamPullRequestReviewers mocks base method.
+func (m *MockRequestOwnerRepository) ReplaceTeamPullRequestReviewers(ctx context.Context, teamName string, userIDs []string) ([]domain.ReviewerReplacement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTeamPullRequestReviewers", ctx, teamName, userIDs)
+	ret0, _ := ret[0].([]domain.ReviewerReplacement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceTeamPullRequestReviewers indicates an expected call of ReplaceTeamPullRequestReviewers.
+func (mr *MockRequestOwnerRepositoryMockRecorder) ReplaceTeamPullRequestReviewers(ctx, teamName, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTeamPullRequestReviewers", reflect.TypeOf((*MockRequestOwnerRepository)(nil).ReplaceTeamPullRequestReviewers), ctx, teamName, userIDs)
+}
+
+// ReplaceTeamReviewers mocks base method.
+func (m *MockRequestOwnerRepository) ReplaceTeamReviewers(ctx context.Context, teamName string, userIDs []string) ([]domain.ReviewerReplacement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTeamReviewers", ctx, teamName, userIDs)
+	ret0, _ := ret[0].([]domain.ReviewerReplacement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceTeamReviewers indicates an expected call of ReplaceTeamReviewers.
+func (mr *MockRequestOwnerRepositoryMockRecorder) ReplaceTeamReviewers(ctx, teamName, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTeamReviewers", reflect.TypeOf((*MockRequestOwnerRepository)(nil).ReplaceTeamReviewers), ctx, teamName, userIDs)
+}
+
+// SaveAssignmentEvents mocks base method.
+func (m *MockRequestOwnerRepository) SaveAssignmentEvents(ctx context.Context, events []domain.AssignmentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAssignmentEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAssignmentEvents indicates an expected call of SaveAssignmentEvents.
+func (mr *MockRequestOwnerRepositoryMockRecorder) SaveAssignmentEvents(ctx, events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAssignmentEvents", reflect.TypeOf((*MockRequestOwnerRepository)(nil).SaveAssignmentEvents), ctx, events)
+}
+
+// SaveAssignmentExplanation mocks base method.
+func (m *MockRequestOwnerRepository) SaveAssignmentExplanation(ctx context.Context, explanation *domain.AssignmentExplanation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAssignmentExplanation", ctx, explanation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAssignmentExplanation indicates an expected call of SaveAssignmentExplanation.
+func (mr *MockRequestOwnerRepositoryMockRecorder) SaveAssignmentExplanation(ctx, explanation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAssignmentExplanation", reflect.TypeOf((*MockRequestOwnerRepository)(nil).SaveAssignmentExplanation), ctx, explanation)
+}
+
+// SaveExcludedReviewers mocks base method.
+func (m *MockRequestOwnerRepository) SaveExcludedReviewers(ctx context.Context, pullRequestID string, userIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveExcludedReviewers", ctx, pullRequestID, userIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveExcludedReviewers indicates an expected call of SaveExcludedReviewers.
+func (mr *MockRequestOwnerRepositoryMockRecorder) SaveExcludedReviewers(ctx, pullRequestID, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveExcludedReviewers", reflect.TypeOf((*MockRequestOwnerRepository)(nil).SaveExcludedReviewers), ctx, pullRequestID, userIDs)
+}
+
 // SaveRequestOwner mocks base method.
 func (m *MockRequestOwnerRepository) SaveRequestOwner(ctx context.Context, request *domain.RequestOwner) error {
 	m.ctrl.T.Helper()
@@ -212,6 +576,21 @@ func (m *MockTeamRepository) EXPECT() *MockTeamRepositoryMockRecorder {
 	return m.recorder
 }
 
+// DeleteTeam mocks base method.
+func (m *MockTeamRepository) DeleteTeam(ctx context.Context, teamName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", ctx, teamName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockTeamRepositoryMockRecorder) DeleteTeam(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeamRepository)(nil).DeleteTeam), ctx, teamName)
+}
+
 // GetTeamByName mocks base method.
 func (m *MockTeamRepository) GetTeamByName(ctx context.Context, name string) (*domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +606,21 @@ func (mr *MockTeamRepositoryMockRecorder) GetTeamByName(ctx, name interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByName", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamByName), ctx, name)
 }
 
+// GetTeamSettings mocks base method.
+func (m *MockTeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamSettings", ctx, teamName)
+	ret0, _ := ret[0].(*domain.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamSettings indicates an expected call of GetTeamSettings.
+func (mr *MockTeamRepositoryMockRecorder) GetTeamSettings(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSettings", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamSettings), ctx, teamName)
+}
+
 // GetTeams mocks base method.
 func (m *MockTeamRepository) GetTeams(ctx context.Context) ([]domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +636,21 @@ func (mr *MockTeamRepositoryMockRecorder) GetTeams(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeams", reflect.TypeOf((*MockTeamRepository)(nil).GetTeams), ctx)
 }
 
+// IsTeamLead mocks base method.
+func (m *MockTeamRepository) IsTeamLead(ctx context.Context, leadID, memberID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTeamLead", ctx, leadID, memberID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTeamLead indicates an expected call of IsTeamLead.
+func (mr *MockTeamRepositoryMockRecorder) IsTeamLead(ctx, leadID, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTeamLead", reflect.TypeOf((*MockTeamRepository)(nil).IsTeamLead), ctx, leadID, memberID)
+}
+
 // LinkUserToTeam mocks base method.
 func (m *MockTeamRepository) LinkUserToTeam(ctx context.Context, team *domain.Team, user *domain.User) error {
 	m.ctrl.T.Helper()
@@ -270,6 +679,34 @@ func (mr *MockTeamRepositoryMockRecorder) SaveTeam(ctx, team interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeam", reflect.TypeOf((*MockTeamRepository)(nil).SaveTeam), ctx, team)
 }
 
+// SaveTeamSettings mocks base method.
+func (m *MockTeamRepository) SaveTeamSettings(ctx context.Context, settings *domain.TeamSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeamSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTeamSettings indicates an expected call of SaveTeamSettings.
+func (mr *MockTeamRepositoryMockRecorder) SaveTeamSettings(ctx, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamSettings", reflect.TypeOf((*MockTeamRepository)(nil).SaveTeamSettings), ctx, settings)
+}
+
+// UnlinkUserFromTeam mocks base method.
+func (m *MockTeamRepository) UnlinkUserFromTeam(ctx context.Context, teamName, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkUserFromTeam", ctx, teamName, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkUserFromTeam indicates an expected call of UnlinkUserFromTeam.
+func (mr *MockTeamRepositoryMockRecorder) UnlinkUserFromTeam(ctx, teamName, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkUserFromTeam", reflect.TypeOf((*MockTeamRepository)(nil).UnlinkUserFromTeam), ctx, teamName, userID)
+}
+
 // MockPullRequestRepository is a mock of PullRequestRepository interface.
 type MockPullRequestRepository struct {
 	ctrl     *gomock.Controller
@@ -323,6 +760,20 @@ func (mr *MockPullRequestRepositoryMockRecorder) GetPullRequests(ctx interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequests", reflect.TypeOf((*MockPullRequestRepository)(nil).GetPullRequests), ctx)
 }
 
+// SaveMergeOverride mocks base method.
+func (m *MockPullRequestRepository) SaveMergeOverride(ctx context.Context, override *domain.MergeOverride) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMergeOverride", ctx, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMergeOverride indicates an expected call of SaveMergeOverride.
+func (mr *MockPullRequestRepositoryMockRecorder) SaveMergeOverride(ctx, override interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMergeOverride", reflect.TypeOf((*MockPullRequestRepository)(nil).SaveMergeOverride), ctx, override)
+}
+
 // SavePullRequest mocks base method.
 func (m *MockPullRequestRepository) SavePullRequest(ctx context.Context, pull *domain.PullRequest) error {
 	m.ctrl.T.Helper()
@@ -337,6 +788,20 @@ func (mr *MockPullRequestRepositoryMockRecorder) SavePullRequest(ctx, pull inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePullRequest", reflect.TypeOf((*MockPullRequestRepository)(nil).SavePullRequest), ctx, pull)
 }
 
+// SaveReviewDecision mocks base method.
+func (m *MockPullRequestRepository) SaveReviewDecision(ctx context.Context, review *domain.ReviewDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReviewDecision", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReviewDecision indicates an expected call of SaveReviewDecision.
+func (mr *MockPullRequestRepositoryMockRecorder) SaveReviewDecision(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReviewDecision", reflect.TypeOf((*MockPullRequestRepository)(nil).SaveReviewDecision), ctx, review)
+}
+
 // UpdatePullRequest mocks base method.
 func (m *MockPullRequestRepository) UpdatePullRequest(ctx context.Context, pull *domain.PullRequest) error {
 	m.ctrl.T.Helper()
@@ -350,3 +815,167 @@ func (mr *MockPullRequestRepositoryMockRecorder) UpdatePullRequest(ctx, pull int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequest", reflect.TypeOf((*MockPullRequestRepository)(nil).UpdatePullRequest), ctx, pull)
 }
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMergeTimeStats mocks base method.
+func (m *MockStatsRepository) GetMergeTimeStats(ctx context.Context, filter domain.StatsFilter) (*domain.MergeTimeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergeTimeStats", ctx, filter)
+	ret0, _ := ret[0].(*domain.MergeTimeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergeTimeStats indicates an expected call of GetMergeTimeStats.
+func (mr *MockStatsRepositoryMockRecorder) GetMergeTimeStats(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergeTimeStats", reflect.TypeOf((*MockStatsRepository)(nil).GetMergeTimeStats), ctx, filter)
+}
+
+// GetPullRequestReviewerStats mocks base method.
+func (m *MockStatsRepository) GetPullRequestReviewerStats(ctx context.Context, filter domain.StatsFilter) ([]domain.PullRequestReviewerStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestReviewerStats", ctx, filter)
+	ret0, _ := ret[0].([]domain.PullRequestReviewerStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestReviewerStats indicates an expected call of GetPullRequestReviewerStats.
+func (mr *MockStatsRepositoryMockRecorder) GetPullRequestReviewerStats(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestReviewerStats", reflect.TypeOf((*MockStatsRepository)(nil).GetPullRequestReviewerStats), ctx, filter)
+}
+
+// GetTeamStats mocks base method.
+func (m *MockStatsRepository) GetTeamStats(ctx context.Context, filter domain.StatsFilter) ([]domain.TeamStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamStats", ctx, filter)
+	ret0, _ := ret[0].([]domain.TeamStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamStats indicates an expected call of GetTeamStats.
+func (mr *MockStatsRepositoryMockRecorder) GetTeamStats(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamStats", reflect.TypeOf((*MockStatsRepository)(nil).GetTeamStats), ctx, filter)
+}
+
+// GetUserAssignmentStats mocks base method.
+func (m *MockStatsRepository) GetUserAssignmentStats(ctx context.Context, filter domain.StatsFilter) ([]domain.UserAssignmentStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAssignmentStats", ctx, filter)
+	ret0, _ := ret[0].([]domain.UserAssignmentStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAssignmentStats indicates an expected call of GetUserAssignmentStats.
+func (mr *MockStatsRepositoryMockRecorder) GetUserAssignmentStats(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAssignmentStats", reflect.TypeOf((*MockStatsRepository)(nil).GetUserAssignmentStats), ctx, filter)
+}
+
+// MockTokenRepository is a mock of TokenRepository interface.
+type MockTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepositoryMockRecorder
+}
+
+// MockTokenRepositoryMockRecorder is the mock recorder for MockTokenRepository.
+type MockTokenRepositoryMockRecorder struct {
+	mock *MockTokenRepository
+}
+
+// NewMockTokenRepository creates a new mock instance.
+func NewMockTokenRepository(ctrl *gomock.Controller) *MockTokenRepository {
+	mock := &MockTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetTokenByHash mocks base method.
+func (m *MockTokenRepository) GetTokenByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenByHash", ctx, hash)
+	ret0, _ := ret[0].(*domain.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenByHash indicates an expected call of GetTokenByHash.
+func (mr *MockTokenRepositoryMockRecorder) GetTokenByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenByHash", reflect.TypeOf((*MockTokenRepository)(nil).GetTokenByHash), ctx, hash)
+}
+
+// GetTokens mocks base method.
+func (m *MockTokenRepository) GetTokens(ctx context.Context) ([]domain.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokens", ctx)
+	ret0, _ := ret[0].([]domain.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokens indicates an expected call of GetTokens.
+func (mr *MockTokenRepositoryMockRecorder) GetTokens(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokens", reflect.TypeOf((*MockTokenRepository)(nil).GetTokens), ctx)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRepository) RevokeToken(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRepositoryMockRecorder) RevokeToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRepository)(nil).RevokeToken), ctx, id)
+}
+
+// SaveToken mocks base method.
+func (m *MockTokenRepository) SaveToken(ctx context.Context, token *domain.APIToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveToken indicates an expected call of SaveToken.
+func (mr *MockTokenRepositoryMockRecorder) SaveToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveToken", reflect.TypeOf((*MockTokenRepository)(nil).SaveToken), ctx, token)
+}
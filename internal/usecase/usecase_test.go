@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/golang/mock/gomock"
+)
+
+// newPassThroughTransactor - транзактор для тестов, который просто выполняет fn и возвращает ее ошибку.
+func newPassThroughTransactor(ctrl *gomock.Controller) *MockTransactor {
+	transactor := NewMockTransactor(ctrl)
+	transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	return transactor
+}
+
+// newRollbackTransactor - транзактор для тестов, который запоминает ошибку, с которой транзакция была откачена.
+func newRollbackTransactor(ctrl *gomock.Controller, rolledBack *error) *MockTransactor {
+	transactor := NewMockTransactor(ctrl)
+	transactor.EXPECT().
+		WithinTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			err := fn(ctx)
+			if err != nil {
+				*rolledBack = err
+			}
+			return err
+		}).
+		Times(1)
+	return transactor
+}
+
+// newTestSelector - случайная стратегия с фиксированным seed, чтобы выбор в тестах был воспроизводимым.
+func newTestSelector() *RandomSelector {
+	return NewRandomSelector(rand.New(rand.NewSource(1)))
+}
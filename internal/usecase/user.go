@@ -4,30 +4,29 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type User struct {
 	userRepository         UserRepository
 	requestOwnerRepository RequestOwnerRepository
 	pullRequestRepository  PullRequestRepository
+	teamRepository         TeamRepository
 }
 
-func NewUser(userRepository UserRepository, requestOwnerRepository RequestOwnerRepository, pullRequestRepository PullRequestRepository) User {
+func NewUser(userRepository UserRepository, requestOwnerRepository RequestOwnerRepository, pullRequestRepository PullRequestRepository, teamRepository TeamRepository) User {
 	return User{
 		userRepository:         userRepository,
 		requestOwnerRepository: requestOwnerRepository,
 		pullRequestRepository:  pullRequestRepository,
+		teamRepository:         teamRepository,
 	}
 }
 
-func (u *User) SetActive(ctx context.Context, userID string, active bool) (*domain.User, error) {
-	if u.userRepository == nil {
-		return nil, ErrUserRepositoryNotFound
-	} else if u.requestOwnerRepository == nil {
-		return nil, ErrRequestOwnerRepositoryNotFound
-	} else if u.pullRequestRepository == nil {
-		return nil, ErrPullRequestRepositoryNotFound
-	}
+func (u *User) SetActive(ctx context.Context, userID string, active bool) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "User.SetActive", attribute.String("user.id", userID), attribute.Bool("user.is_active", active))
+	defer endSpan(span, &err)
 
 	if u.userRepository == nil {
 		return nil, ErrMemberNotFound
@@ -36,6 +35,10 @@ func (u *User) SetActive(ctx context.Context, userID string, active bool) (*doma
 	if err != nil {
 		return nil, ErrMemberNotFound
 	}
+	// активность переключает админ или лид команды пользователя
+	if err := requireLeadOf(ctx, u.teamRepository, userID, false); err != nil {
+		return nil, err
+	}
 	user.IsActive = active
 	err = u.userRepository.UpdateUser(ctx, user)
 	if err != nil {
@@ -44,7 +47,12 @@ func (u *User) SetActive(ctx context.Context, userID string, active bool) (*doma
 	return user, nil
 }
 
-func (u *User) GetUserPullRequests(ctx context.Context, userID string) ([]domain.PullRequest, error) {
+// GetUserPullRequests возвращает PR, где пользователь назначен ревьювером. При awaitingOnly - только
+// открытые PR, которые он еще не одобрил и по которым не запросил изменения.
+func (u *User) GetUserPullRequests(ctx context.Context, userID string, awaitingOnly bool) (_ []domain.PullRequest, err error) {
+	ctx, span := startSpan(ctx, "User.GetUserPullRequests", attribute.String("user.id", userID), attribute.Bool("awaiting_only", awaitingOnly))
+	defer endSpan(span, &err)
+
 	if u.userRepository == nil {
 		return nil, ErrUserRepositoryNotFound
 	} else if u.requestOwnerRepository == nil {
@@ -52,7 +60,7 @@ func (u *User) GetUserPullRequests(ctx context.Context, userID string) ([]domain
 	} else if u.pullRequestRepository == nil {
 		return nil, ErrPullRequestRepositoryNotFound
 	}
-	_, err := u.userRepository.GetUserByID(ctx, userID)
+	_, err = u.userRepository.GetUserByID(ctx, userID)
 	if errors.Is(err, ErrMemberNotFound) {
 		return nil, ErrMemberNotFound
 	} else if err != nil {
@@ -69,8 +77,34 @@ func (u *User) GetUserPullRequests(ctx context.Context, userID string) ([]domain
 			if err != nil {
 				return nil, errors.Join(ErrPullRequestNotFound, err)
 			}
+			if awaitingOnly && !gottenPr.AwaitingReviewFrom(userID) {
+				continue
+			}
 			result = append(result, *gottenPr)
 		}
 	}
 	return result, nil
 }
+
+// GetHistory возвращает события истории назначений, где пользователь назначен, снят или заменен.
+// Доступно самому пользователю, лиду его команды и админу.
+func (u *User) GetHistory(ctx context.Context, userID string) (_ []domain.AssignmentEvent, err error) {
+	ctx, span := startSpan(ctx, "User.GetHistory", attribute.String("user.id", userID))
+	defer endSpan(span, &err)
+
+	if u.userRepository == nil {
+		return nil, ErrUserRepositoryNotFound
+	} else if u.requestOwnerRepository == nil {
+		return nil, ErrRequestOwnerRepositoryNotFound
+	}
+	_, err = u.userRepository.GetUserByID(ctx, userID)
+	if errors.Is(err, ErrMemberNotFound) {
+		return nil, ErrMemberNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if err := requireLeadOf(ctx, u.teamRepository, userID, true); err != nil {
+		return nil, err
+	}
+	return u.requestOwnerRepository.GetAssignmentEventsByUserID(ctx, userID)
+}
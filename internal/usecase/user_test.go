@@ -4,6 +4,7 @@ import (
 	"avito-test/internal/domain"
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,7 +12,7 @@ import (
 
 func TestUser_SetActive_UserRepositoryNil(t *testing.T) {
 	// Arrange
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 	u := &User{
 		userRepository: nil,
 	}
@@ -30,7 +31,7 @@ func TestUser_SetActive_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockUserRepo := NewMockUserRepository(ctrl)
 	u := &User{
@@ -73,7 +74,7 @@ func TestUser_GetUserPullRequests_UserNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
@@ -90,7 +91,7 @@ func TestUser_GetUserPullRequests_UserNotFound(t *testing.T) {
 		Return(nil, ErrMemberNotFound)
 
 	// Act
-	got, err := u.GetUserPullRequests(ctx, "user-1")
+	got, err := u.GetUserPullRequests(ctx, "user-1", false)
 
 	// Assert
 	if got != nil {
@@ -106,7 +107,7 @@ func TestUser_GetUserPullRequests_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
@@ -135,7 +136,7 @@ func TestUser_GetUserPullRequests_Success(t *testing.T) {
 		Return(&domain.PullRequest{ID: "pr-1", Name: "PR 1"}, nil)
 
 	// Act
-	got, err := u.GetUserPullRequests(ctx, "user-1")
+	got, err := u.GetUserPullRequests(ctx, "user-1", false)
 
 	// Assert
 	if err != nil {
@@ -149,12 +150,71 @@ func TestUser_GetUserPullRequests_Success(t *testing.T) {
 	}
 }
 
+func TestUser_GetUserPullRequests_AwaitingOnly(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := WithActor(context.Background(), actorAdmin)
+
+	mockUserRepo := NewMockUserRepository(ctrl)
+	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
+	mockPRRepo := NewMockPullRequestRepository(ctrl)
+
+	u := &User{
+		userRepository:         mockUserRepo,
+		requestOwnerRepository: mockReqOwnerRepo,
+		pullRequestRepository:  mockPRRepo,
+	}
+
+	mockUserRepo.EXPECT().
+		GetUserByID(ctx, "user-1").
+		Return(&domain.User{ID: "user-1", Username: "u1", IsActive: true}, nil)
+
+	reviewed := func(state domain.ReviewState) []domain.ReviewDecision {
+		return []domain.ReviewDecision{{UserID: "user-1", State: state}}
+	}
+	prs := map[string]*domain.PullRequest{
+		"pr-new":       {ID: "pr-new", Status: domain.RequestStatusOpen},
+		"pr-commented": {ID: "pr-commented", Status: domain.RequestStatusOpen, Reviews: reviewed(domain.ReviewStateCommented)},
+		"pr-approved":  {ID: "pr-approved", Status: domain.RequestStatusOpen, Reviews: reviewed(domain.ReviewStateApproved)},
+		"pr-changes":   {ID: "pr-changes", Status: domain.RequestStatusOpen, Reviews: reviewed(domain.ReviewStateChangesRequested)},
+		"pr-merged":    {ID: "pr-merged", Status: domain.RequestStatusMerged},
+		"pr-other":     {ID: "pr-other", Status: domain.RequestStatusOpen, Reviews: []domain.ReviewDecision{{UserID: "user-2", State: domain.ReviewStateApproved}}},
+	}
+	order := []string{"pr-new", "pr-commented", "pr-approved", "pr-changes", "pr-merged", "pr-other"}
+
+	reqOwners := make([]domain.RequestOwner, 0, len(order))
+	for _, id := range order {
+		reqOwners = append(reqOwners, domain.RequestOwner{UserID: "user-1", RequestID: id, Role: domain.UserRoleReviewer})
+		mockPRRepo.EXPECT().GetPullRequestByID(ctx, id).Return(prs[id], nil)
+	}
+	mockReqOwnerRepo.EXPECT().
+		GetRequestsByUserID(ctx, "user-1").
+		Return(reqOwners, nil)
+
+	// Act
+	got, err := u.GetUserPullRequests(ctx, "user-1", true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GetUserPullRequests() unexpected error: %v", err)
+	}
+	gotIDs := make([]string, 0, len(got))
+	for _, pr := range got {
+		gotIDs = append(gotIDs, pr.ID)
+	}
+	if want := []string{"pr-new", "pr-commented", "pr-other"}; !reflect.DeepEqual(gotIDs, want) {
+		t.Fatalf("got %v, want %v", gotIDs, want)
+	}
+}
+
 func TestUser_GetUserPullRequests_PullRequestErrorWrapped(t *testing.T) {
 	// Arrange
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx := context.Background()
+	ctx := WithActor(context.Background(), actorAdmin)
 
 	mockUserRepo := NewMockUserRepository(ctrl)
 	mockReqOwnerRepo := NewMockRequestOwnerRepository(ctrl)
@@ -182,7 +242,7 @@ func TestUser_GetUserPullRequests_PullRequestErrorWrapped(t *testing.T) {
 		Return(nil, underlyingErr)
 
 	// Act
-	got, err := u.GetUserPullRequests(ctx, "user-1")
+	got, err := u.GetUserPullRequests(ctx, "user-1", false)
 
 	// Assert
 	if got != nil {